@@ -22,6 +22,11 @@ const (
 	ForceRotateAnnotation                 string         = "services.cloud.sap.com/forceRotate"
 	PreventDeletion                       string         = "services.cloud.sap.com/preventDeletion"
 	UseInstanceMetadataNameInSecret       string         = "services.cloud.sap.com/useInstanceMetadataName"
+	DryRunAnnotation                      string         = "services.cloud.sap.com/dry-run"
+	HandoverToAnnotation                  string         = "services.cloud.sap.com/handoverTo"
+	ReplicaOfBindingLabel                 string         = "services.cloud.sap.com/replica-of-binding"
+	ReplicaOfAnnotation                   string         = "services.cloud.sap.com/replica-of"
+	AllowReplicationFromAnnotation        string         = "services.cloud.sap.com/allow-secret-replication-from"
 )
 
 type HTTPStatusCodeError struct {
@@ -67,6 +72,12 @@ const (
 
 	// ConditionShared represents information about the instance share situation
 	ConditionShared = "Shared"
+
+	// ConditionDegraded represents that the resource can not be reconciled because Service Manager is unavailable
+	ConditionDegraded = "Degraded"
+
+	// ConditionDrifted represents whether the instance in Service Manager differs from its spec
+	ConditionDrifted = "Drifted"
 )
 
 // +kubebuilder:object:generate=false
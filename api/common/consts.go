@@ -6,6 +6,9 @@ const (
 	InstanceSecretRefLabel    = "services.cloud.sap.com/secret-ref_"
 	WatchSecretAnnotation     = "services.cloud.sap.com/watch-secret-"
 	WatchSecretLabel          = "services.cloud.sap.com/watch-secret"
+	InstanceConfigMapRefLabel = "services.cloud.sap.com/configmap-ref_"
+	WatchConfigMapAnnotation  = "services.cloud.sap.com/watch-configmap-"
+	WatchConfigMapLabel       = "services.cloud.sap.com/watch-configmap"
 
 	NamespaceLabel = "_namespace"
 	K8sNameLabel   = "_k8sname"
@@ -33,10 +36,37 @@ const (
 	UnShareFailed     = "UnShareFailed"
 	UnShareSucceeded  = "UnShareSucceeded"
 	ResourceNotFound  = "NotFound"
+	PlanNotUpdatable  = "PlanNotUpdatable"
+	DriftDetected     = "DriftDetected"
+	NoDrift           = "NoDrift"
+	AdoptionRefused   = "AdoptionRefused"
+
+	Aggregated      = "Aggregated"
+	BindingsPending = "BindingsPending"
 
 	Blocked = "Blocked"
 	Unknown = "Unknown"
 
+	ServiceManagerUnavailable = "ServiceManagerUnavailable"
+
+	// Event reasons not covered by the condition reasons
+	Recovered             = "Recovered"
+	Adopted               = "Adopted"
+	Retained              = "Retained"
+	HandedOver            = "HandedOver"
+	SecretCreated         = "SecretCreated"
+	SecretDeleted         = "SecretDeleted"
+	SecretReplicated      = "SecretReplicated"
+	ReplicationFailed     = "ReplicationFailed"
+	CredRotationStarted   = "CredRotationStarted"
+	CredRotationSucceeded = "CredRotationSucceeded"
+	CredRotationFailed    = "CredRotationFailed"
+	Rebinding             = "Rebinding"
+	Rebound               = "Rebound"
+	StaleBindingDeleted   = "StaleBindingDeleted"
+	InvalidParameters     = "InvalidParameters"
+	DryRunComputed        = "DryRunComputed"
+
 	// Cred Rotation
 	CredPreparing = "Preparing"
 	CredRotating  = "Rotating"
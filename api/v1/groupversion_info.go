@@ -42,6 +42,8 @@ func addKnownTypes(s *runtime.Scheme) error {
 		&ServiceInstanceList{},
 		&ServiceBinding{},
 		&ServiceBindingList{},
+		&VCAPServices{},
+		&VCAPServicesList{},
 	)
 	metav1.AddToGroupVersion(s, GroupVersion)
 	return nil
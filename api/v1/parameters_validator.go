@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ParametersValidator validates the parameters of service instances and bindings against the schemas of their service plan.
+// The validator is set by the manager when parameters schema validation is enabled.
+// +kubebuilder:object:generate=false
+type ParametersValidator interface {
+	ValidateInstanceParameters(ctx context.Context, instance *ServiceInstance, create bool) (admission.Warnings, error)
+	ValidateBindingParameters(ctx context.Context, binding *ServiceBinding) (admission.Warnings, error)
+}
+
+var parametersValidator ParametersValidator
+
+// SetParametersValidator sets the parameters validator used by the validating webhooks
+func SetParametersValidator(validator ParametersValidator) {
+	parametersValidator = validator
+}
+
+// parametersChanged reports whether the parameters or the plan of the instance changed
+func (si *ServiceInstance) parametersChanged(oldInstance *ServiceInstance) bool {
+	return !reflect.DeepEqual(si.Spec.Parameters, oldInstance.Spec.Parameters) ||
+		!reflect.DeepEqual(si.Spec.ParametersFrom, oldInstance.Spec.ParametersFrom) ||
+		si.Spec.ServicePlanName != oldInstance.Spec.ServicePlanName ||
+		si.Spec.ServicePlanID != oldInstance.Spec.ServicePlanID
+}
@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// PlanChangeValidator validates that the plan of a service instance can be changed to the plan requested in its spec.
+// The validator is set by the manager when webhooks are enabled.
+// +kubebuilder:object:generate=false
+type PlanChangeValidator interface {
+	ValidatePlanChange(ctx context.Context, oldInstance, newInstance *ServiceInstance) (admission.Warnings, error)
+}
+
+var planChangeValidator PlanChangeValidator
+
+// SetPlanChangeValidator sets the plan change validator used by the validating webhook
+func SetPlanChangeValidator(validator PlanChangeValidator) {
+	planChangeValidator = validator
+}
+
+// planChanged reports whether the plan of the instance changed
+func (si *ServiceInstance) planChanged(oldInstance *ServiceInstance) bool {
+	return si.Spec.ServicePlanName != oldInstance.Spec.ServicePlanName || si.Spec.ServicePlanID != oldInstance.Spec.ServicePlanID
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package v1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"github.com/SAP/sap-btp-service-operator/api/common"
 	"github.com/SAP/sap-btp-service-operator/client/sm/types"
 	v1 "k8s.io/api/authentication/v1"
@@ -100,6 +104,23 @@ type ServiceBindingSpec struct {
 	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
 	SecretTemplate string `json:"secretTemplate,omitempty"`
+
+	// SecretFormat is the layout of the binding secret. ServiceBinding stores a servicebinding.io secret with the type and
+	// provider keys, it can't be combined with SecretKey, SecretRootKey or SecretTemplate. Defaults to Default.
+	// +kubebuilder:validation:Enum=Default;ServiceBinding
+	// +optional
+	SecretFormat SecretFormat `json:"secretFormat,omitempty"`
+
+	// SecretReplication copies the binding secret to other namespaces, the namespaces must allow it with the
+	// services.cloud.sap.com/allow-secret-replication-from annotation
+	// +optional
+	SecretReplication *SecretReplication `json:"secretReplication,omitempty"`
+
+	// DeletionPolicy defines what happens to the binding in Service Manager when this resource is deleted.
+	// Delete deletes it, Retain keeps it and removes the labels that tie it to this cluster. Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // ServiceBindingStatus defines the observed state of ServiceBinding
@@ -133,9 +154,25 @@ type ServiceBindingStatus struct {
 	// The subaccount id of the service binding
 	SubaccountID string `json:"subaccountID,omitempty"`
 
+	// The request the operator would send to Service Manager, set while the binding is in dry-run mode
+	// +optional
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
+
 	// Last generation that was acted on
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// Binding references the secret of the binding, the ProvisionedService duck type of servicebinding.io
+	// +optional
+	Binding *BindingSecretReference `json:"binding,omitempty"`
+
+	// The namespaces the binding secret is replicated to
+	// +optional
+	ReplicatedTo []string `json:"replicatedTo,omitempty"`
+
+	// HashedParameters is the hash of the parameters the binding was created with, the binding is created again when they change
+	// +optional
+	HashedParameters string `json:"hashedParameters,omitempty"`
+
 	AsyncBindFailed *bool `json:"asyncBindFailed,omitempty"`
 }
 
@@ -174,6 +211,13 @@ func (sb *ServiceBinding) GetParameters() *runtime.RawExtension {
 	return sb.Spec.Parameters
 }
 
+// GetParametersHash returns the hash of the parameters and parametersFrom fields
+func (sb *ServiceBinding) GetParametersHash() string {
+	parametersBytes, _ := json.Marshal([]interface{}{sb.Spec.Parameters, sb.Spec.ParametersFrom})
+	hash := sha256.Sum256(parametersBytes)
+	return hex.EncodeToString(hash[:])
+}
+
 func (sb *ServiceBinding) GetStatus() interface{} {
 	return sb.Status
 }
@@ -215,6 +259,17 @@ type ServiceBindingList struct {
 	Items           []ServiceBinding `json:"items"`
 }
 
+// SecretReplication selects the namespaces the binding secret is copied to
+type SecretReplication struct {
+	// The namespaces to copy the secret to
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selects the namespaces to copy the secret to by their labels
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
 type CredentialsRotationPolicy struct {
 	Enabled bool `json:"enabled"`
 	// What frequency to perform binding rotation.
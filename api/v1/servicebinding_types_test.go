@@ -80,6 +80,16 @@ var _ = Describe("Service Binding Type Test", func() {
 		Expect(binding.GetParameters()).To(Equal(params))
 	})
 
+	It("should change the parameters hash only when the parameters change", func() {
+		initialHash := binding.GetParametersHash()
+		binding.Spec.SecretTemplate = "new-template"
+		binding.Spec.ExternalName = "new-name"
+		Expect(binding.GetParametersHash()).To(Equal(initialHash))
+
+		binding.Spec.ParametersFrom = nil
+		Expect(binding.GetParametersHash()).ToNot(Equal(initialHash))
+	})
+
 	It("should update status", func() {
 		status := ServiceBindingStatus{BindingID: "1234"}
 		binding.SetStatus(status)
@@ -20,9 +20,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/SAP/sap-btp-service-operator/api/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -43,18 +46,27 @@ func (sb *ServiceBinding) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ admission.Validator[*ServiceBinding] = &ServiceBinding{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (sb *ServiceBinding) ValidateCreate(_ context.Context, obj *ServiceBinding) (admission.Warnings, error) {
+func (sb *ServiceBinding) ValidateCreate(ctx context.Context, obj *ServiceBinding) (admission.Warnings, error) {
 	servicebindinglog.Info("validate create", "name", obj.ObjectMeta.Name)
 	if obj.Spec.CredRotationPolicy != nil {
 		if err := obj.validateCredRotatingConfig(); err != nil {
 			return nil, err
 		}
 	}
+	if err := obj.validateSecretReplication(); err != nil {
+		return nil, err
+	}
+	if err := obj.validateSecretFormat(); err != nil {
+		return nil, err
+	}
+	if _, isStale := obj.Labels[common.StaleBindingIDLabel]; parametersValidator != nil && !isStale {
+		return parametersValidator.ValidateBindingParameters(ctx, obj)
+	}
 	return nil, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (sb *ServiceBinding) ValidateUpdate(_ context.Context, oldObj, newObj *ServiceBinding) (admission.Warnings, error) {
+func (sb *ServiceBinding) ValidateUpdate(ctx context.Context, oldObj, newObj *ServiceBinding) (admission.Warnings, error) {
 	servicebindinglog.Info("validate update", "name", newObj.ObjectMeta.Name)
 	if newObj.Spec.CredRotationPolicy != nil {
 		if err := newObj.validateCredRotatingConfig(); err != nil {
@@ -74,16 +86,27 @@ func (sb *ServiceBinding) ValidateUpdate(_ context.Context, oldObj, newObj *Serv
 		}
 	}
 
+	if err := newObj.validateSecretReplication(); err != nil {
+		return nil, err
+	}
+	if err := newObj.validateSecretFormat(); err != nil {
+		return nil, err
+	}
+
 	if newObj.Spec.UserInfo == nil {
 		newObj.Spec.UserInfo = oldObj.Spec.UserInfo
 	} else if !reflect.DeepEqual(newObj.Spec.UserInfo, oldObj.Spec.UserInfo) {
 		return nil, fmt.Errorf("modifying spec.userInfo is not allowed")
 	}
 
-	isSpecChanged := newObj.specChanged(oldObj)
-	if isSpecChanged && (newObj.Status.BindingID != "" || isStale) {
-
-		return nil, fmt.Errorf("updating service bindings is not supported")
+	if isStale && newObj.specChanged(oldObj) {
+		return nil, fmt.Errorf("updating rotated service bindings is not supported")
+	}
+	if newObj.Status.BindingID != "" && newObj.immutableSpecChanged(oldObj) {
+		return nil, fmt.Errorf("updating the instance, external name or secret name of service bindings is not supported")
+	}
+	if !isStale && parametersValidator != nil && newObj.parametersChanged(oldObj) {
+		return parametersValidator.ValidateBindingParameters(ctx, newObj)
 	}
 	return nil, nil
 }
@@ -114,9 +137,36 @@ func (sb *ServiceBinding) specChanged(oldBinding *ServiceBinding) bool {
 	oldSpec.SecretTemplate = ""
 	newSpec.SecretTemplate = ""
 
+	//allow changing DeletionPolicy
+	oldSpec.DeletionPolicy = ""
+	newSpec.DeletionPolicy = ""
+
+	//allow changing SecretReplication
+	oldSpec.SecretReplication = nil
+	newSpec.SecretReplication = nil
+
 	return !reflect.DeepEqual(oldSpec, newSpec)
 }
 
+// immutableSpecChanged reports changes of the fields that can't be applied to an existing binding, the parameters are
+// applied by binding again and the secret keys by regenerating the secret
+func (sb *ServiceBinding) immutableSpecChanged(oldBinding *ServiceBinding) bool {
+	oldSpec := oldBinding.Spec.DeepCopy()
+	newSpec := sb.Spec.DeepCopy()
+	for _, spec := range []*ServiceBindingSpec{oldSpec, newSpec} {
+		spec.Parameters = nil
+		spec.ParametersFrom = nil
+		spec.SecretKey = nil
+		spec.SecretRootKey = nil
+		spec.SecretFormat = ""
+	}
+	return (&ServiceBinding{Spec: *newSpec}).specChanged(&ServiceBinding{Spec: *oldSpec})
+}
+
+func (sb *ServiceBinding) parametersChanged(oldBinding *ServiceBinding) bool {
+	return !reflect.DeepEqual(sb.Spec.Parameters, oldBinding.Spec.Parameters) || !reflect.DeepEqual(sb.Spec.ParametersFrom, oldBinding.Spec.ParametersFrom)
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (sb *ServiceBinding) ValidateDelete(_ context.Context, _ *ServiceBinding) (admission.Warnings, error) {
 	servicebindinglog.Info("validate delete", "name", sb.ObjectMeta.Name)
@@ -137,3 +187,31 @@ func (sb *ServiceBinding) validateCredRotatingConfig() error {
 
 	return nil
 }
+
+func (sb *ServiceBinding) validateSecretReplication() error {
+	replication := sb.Spec.SecretReplication
+	if replication == nil {
+		return nil
+	}
+	for _, namespace := range replication.Namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("secretReplication.namespaces: invalid namespace %q: %s", namespace, strings.Join(errs, ", "))
+		}
+	}
+	if replication.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(replication.NamespaceSelector); err != nil {
+			return fmt.Errorf("secretReplication.namespaceSelector: %w", err)
+		}
+	}
+	return nil
+}
+
+func (sb *ServiceBinding) validateSecretFormat() error {
+	if sb.Spec.SecretFormat != SecretFormatServiceBinding {
+		return nil
+	}
+	if sb.Spec.SecretKey != nil || sb.Spec.SecretRootKey != nil || len(sb.Spec.SecretTemplate) > 0 {
+		return fmt.Errorf("secretFormat %s can't be combined with secretKey, secretRootKey or secretTemplate", SecretFormatServiceBinding)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -31,6 +32,25 @@ var _ = Describe("Service Binding Webhook Test", func() {
 				_, err := binding.ValidateCreate(nil, binding)
 				Expect(err).ToNot(HaveOccurred())
 			})
+			It("should fail when the servicebinding.io format is combined with a secret key", func() {
+				binding.Spec.SecretFormat = SecretFormatServiceBinding
+				secretKey := "credentials"
+				binding.Spec.SecretKey = &secretKey
+				_, err := binding.ValidateCreate(nil, binding)
+				Expect(err).To(MatchError(ContainSubstring("can't be combined with secretKey")))
+			})
+			It("should fail on invalid secret replication namespace", func() {
+				binding.Spec.SecretReplication = &SecretReplication{Namespaces: []string{"Invalid_Namespace"}}
+				_, err := binding.ValidateCreate(nil, binding)
+				Expect(err).To(MatchError(ContainSubstring("secretReplication.namespaces")))
+			})
+			It("should fail on invalid secret replication selector", func() {
+				binding.Spec.SecretReplication = &SecretReplication{NamespaceSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}},
+				}}
+				_, err := binding.ValidateCreate(nil, binding)
+				Expect(err).To(MatchError(ContainSubstring("secretReplication.namespaceSelector")))
+			})
 		})
 
 		Context("Validate update of spec before binding is created (failure recovery)", func() {
@@ -189,57 +209,57 @@ var _ = Describe("Service Binding Webhook Test", func() {
 				})
 
 				When("SecretKey name changed", func() {
-					It("should fail", func() {
+					It("should succeed", func() {
 						secretKey := "secret-key"
 						newBinding.Spec.SecretKey = &secretKey
 						_, err := newBinding.ValidateUpdate(nil, binding, newBinding)
-						Expect(err).To(HaveOccurred())
+						Expect(err).ToNot(HaveOccurred())
 					})
 				})
 
 				When("SecretRootKey name changed", func() {
-					It("should fail", func() {
+					It("should succeed", func() {
 						secretRootKey := "root"
 						newBinding.Spec.SecretRootKey = &secretRootKey
 						_, err := newBinding.ValidateUpdate(nil, binding, newBinding)
-						Expect(err).To(HaveOccurred())
+						Expect(err).ToNot(HaveOccurred())
 					})
 				})
 
 				When("Parameters were changed", func() {
-					It("should fail", func() {
+					It("should succeed", func() {
 						newBinding.Spec.Parameters = &runtime.RawExtension{
 							Raw: []byte("params"),
 						}
 						_, err := newBinding.ValidateUpdate(nil, binding, newBinding)
-						Expect(err).To(HaveOccurred())
+						Expect(err).ToNot(HaveOccurred())
 					})
 				})
 
 				When("ParametersFrom were changed", func() {
-					It("should fail on changed name", func() {
+					It("should succeed on changed name", func() {
 						newBinding.Spec.ParametersFrom[0].SecretKeyRef.Name = "newName"
 						_, err := newBinding.ValidateUpdate(nil, binding, newBinding)
-						Expect(err).To(HaveOccurred())
+						Expect(err).ToNot(HaveOccurred())
 					})
 
-					It("should fail on changed key", func() {
+					It("should succeed on changed key", func() {
 						newBinding.Spec.ParametersFrom[0].SecretKeyRef.Key = "newName"
 						_, err := newBinding.ValidateUpdate(nil, binding, newBinding)
-						Expect(err).To(HaveOccurred())
+						Expect(err).ToNot(HaveOccurred())
 					})
 
-					It("should fail on nil array", func() {
+					It("should succeed on nil array", func() {
 						newBinding.Spec.ParametersFrom = nil
 						_, err := newBinding.ValidateUpdate(nil, binding, newBinding)
-						Expect(err).To(HaveOccurred())
+						Expect(err).ToNot(HaveOccurred())
 					})
 
-					It("should fail on changed array", func() {
+					It("should succeed on changed array", func() {
 						p := ParametersFromSource{}
 						newBinding.Spec.ParametersFrom[0] = p
 						_, err := newBinding.ValidateUpdate(nil, binding, newBinding)
-						Expect(err).To(HaveOccurred())
+						Expect(err).ToNot(HaveOccurred())
 					})
 
 				})
@@ -251,6 +271,30 @@ var _ = Describe("Service Binding Webhook Test", func() {
 						Expect(err).ToNot(HaveOccurred())
 					})
 				})
+
+				When("deletionPolicy changed", func() {
+					It("should succeed", func() {
+						newBinding.Spec.DeletionPolicy = DeletionPolicyRetain
+						_, err := newBinding.ValidateUpdate(nil, binding, newBinding)
+						Expect(err).ToNot(HaveOccurred())
+					})
+				})
+
+				When("secretFormat changed", func() {
+					It("should succeed", func() {
+						newBinding.Spec.SecretFormat = SecretFormatServiceBinding
+						_, err := newBinding.ValidateUpdate(nil, binding, newBinding)
+						Expect(err).ToNot(HaveOccurred())
+					})
+				})
+
+				When("secretReplication changed", func() {
+					It("should succeed", func() {
+						newBinding.Spec.SecretReplication = &SecretReplication{Namespaces: []string{"other-namespace"}}
+						_, err := newBinding.ValidateUpdate(nil, binding, newBinding)
+						Expect(err).ToNot(HaveOccurred())
+					})
+				})
 			})
 
 			When("Metadata changed", func() {
@@ -20,6 +20,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"strings"
 
 	"github.com/SAP/sap-btp-service-operator/api/common"
 	"github.com/SAP/sap-btp-service-operator/client/sm/types"
@@ -78,7 +79,7 @@ type ServiceInstanceSpec struct {
 	// +optional
 	ParametersFrom []ParametersFromSource `json:"parametersFrom,omitempty"`
 
-	// indicate instance will update on secrets from parametersFrom change
+	// indicate instance will update on secrets and config maps from parametersFrom change
 	// +optional
 	WatchParametersFromChanges *bool `json:"watchParametersFromChanges,omitempty"`
 
@@ -94,8 +95,35 @@ type ServiceInstanceSpec struct {
 
 	// The name of the btp access credentials secret
 	BTPAccessCredentialsSecret string `json:"btpAccessCredentialsSecret,omitempty"`
+
+	// DriftPolicy defines how differences between the instance in Service Manager and its spec are handled when drift detection is enabled.
+	// Report sets the Drifted condition, Reapply also updates the instance in Service Manager to match the spec, Ignore skips the check.
+	// Defaults to Report.
+	// +kubebuilder:validation:Enum=Ignore;Report;Reapply
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// The ID of an existing instance in Service Manager to adopt instead of provisioning a new one.
+	// The instance must not be managed by another cluster. Can't be changed once the instance is adopted.
+	// +optional
+	InstanceID string `json:"instanceID,omitempty"`
+
+	// DeletionPolicy defines what happens to the instance in Service Manager when this resource is deleted.
+	// Delete deletes it, Retain keeps it and removes the labels that tie it to this cluster so it can be adopted again. Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+// DriftPolicy defines how the operator handles differences between the instance in Service Manager and its spec.
+type DriftPolicy string
+
+const (
+	DriftPolicyIgnore  DriftPolicy = "Ignore"
+	DriftPolicyReport  DriftPolicy = "Report"
+	DriftPolicyReapply DriftPolicy = "Reapply"
+)
+
 // ServiceInstanceStatus defines the observed state of ServiceInstance
 type ServiceInstanceStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -126,6 +154,31 @@ type ServiceInstanceStatus struct {
 	// The subaccount id of the service instance
 	SubaccountID string `json:"subaccountID,omitempty"`
 
+	// The ID of the plan the instance was last provisioned or updated with
+	// +optional
+	ServicePlanID string `json:"servicePlanID,omitempty"`
+
+	// The names of the plans the instance can be updated to from its current plan, empty when the plan is not updatable
+	// +optional
+	AllowedPlans []string `json:"allowedPlans,omitempty"`
+
+	// The request the operator would send to Service Manager, set while the instance is in dry-run mode
+	// +optional
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
+
+	// The last time the instance in Service Manager was compared with its spec
+	// +optional
+	LastDriftCheckTime *metav1.Time `json:"lastDriftCheckTime,omitempty"`
+
+	// The namespace the instance was handed over to, prefixed by the cluster ID when it was handed over to another cluster
+	// +optional
+	HandedOverTo string `json:"handedOverTo,omitempty"`
+
+	// Binding references the secret of a binding of the instance in its namespace, the ProvisionedService duck type of
+	// servicebinding.io
+	// +optional
+	Binding *BindingSecretReference `json:"binding,omitempty"`
+
 	// if true need to update instance
 	ForceReconcile bool `json:"forceReconcile,omitempty"`
 
@@ -216,6 +269,26 @@ func (si *ServiceInstance) GetShared() bool {
 	return si.Spec.Shared != nil && *si.Spec.Shared
 }
 
+func (si *ServiceInstance) GetDriftPolicy() DriftPolicy {
+	if len(si.Spec.DriftPolicy) == 0 {
+		return DriftPolicyReport
+	}
+	return si.Spec.DriftPolicy
+}
+
+// GetHandoverTarget returns the cluster ID and namespace set in the handoverTo annotation, the cluster ID is empty when
+// the instance is handed over within the cluster
+func (si *ServiceInstance) GetHandoverTarget() (clusterID, namespace string, ok bool) {
+	target, ok := si.Annotations[common.HandoverToAnnotation]
+	if !ok {
+		return "", "", false
+	}
+	if i := strings.LastIndex(target, "/"); i >= 0 {
+		return target[:i], target[i+1:], true
+	}
+	return "", target, true
+}
+
 func (si *ServiceInstance) IsSubscribedToParamSecretsChanges() bool {
 	return si.Spec.WatchParametersFromChanges != nil && *si.Spec.WatchParametersFromChanges
 }
@@ -223,6 +296,9 @@ func (si *ServiceInstance) IsSubscribedToParamSecretsChanges() bool {
 func (si *ServiceInstance) GetSpecHash() string {
 	spec := si.Spec
 	spec.Shared = ptr.To(false)
+	spec.DriftPolicy = ""
+	spec.InstanceID = ""
+	spec.DeletionPolicy = ""
 	specBytes, _ := json.Marshal(spec)
 	s := string(specBytes)
 	hash := sha256.Sum256([]byte(s))
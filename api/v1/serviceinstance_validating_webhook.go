@@ -23,6 +23,7 @@ import (
 
 	"github.com/SAP/sap-btp-service-operator/api/common"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -39,12 +40,36 @@ var _ admission.Validator[*ServiceInstance] = &ServiceInstance{}
 // log is for logging in this package.
 var serviceinstancelog = logf.Log.WithName("serviceinstance-resource")
 
-func (si *ServiceInstance) ValidateCreate(_ context.Context, _ *ServiceInstance) (warnings admission.Warnings, err error) {
+func (si *ServiceInstance) ValidateCreate(ctx context.Context, obj *ServiceInstance) (warnings admission.Warnings, err error) {
+	if err := obj.validateHandoverTarget(); err != nil {
+		return nil, err
+	}
+	if parametersValidator != nil {
+		return parametersValidator.ValidateInstanceParameters(ctx, obj, true)
+	}
 	return nil, nil
 }
 
-func (si *ServiceInstance) ValidateUpdate(_ context.Context, _, _ *ServiceInstance) (warnings admission.Warnings, err error) {
-	return nil, nil
+func (si *ServiceInstance) ValidateUpdate(ctx context.Context, oldObj, newObj *ServiceInstance) (warnings admission.Warnings, err error) {
+	if !newObj.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	if len(oldObj.Status.InstanceID) > 0 && newObj.Spec.InstanceID != oldObj.Spec.InstanceID {
+		return nil, fmt.Errorf("modifying spec.instanceID is not allowed once the instance is created or adopted")
+	}
+	if err := newObj.validateHandoverTarget(); err != nil {
+		return nil, err
+	}
+	if planChangeValidator != nil && newObj.planChanged(oldObj) {
+		if warnings, err = planChangeValidator.ValidatePlanChange(ctx, oldObj, newObj); err != nil {
+			return warnings, err
+		}
+	}
+	if parametersValidator != nil && newObj.parametersChanged(oldObj) {
+		parametersWarnings, err := parametersValidator.ValidateInstanceParameters(ctx, newObj, false)
+		return append(warnings, parametersWarnings...), err
+	}
+	return warnings, nil
 }
 
 func (si *ServiceInstance) ValidateDelete(_ context.Context, obj *ServiceInstance) (warnings admission.Warnings, err error) {
@@ -57,3 +82,20 @@ func (si *ServiceInstance) ValidateDelete(_ context.Context, obj *ServiceInstanc
 	}
 	return nil, nil
 }
+
+func (si *ServiceInstance) validateHandoverTarget() error {
+	clusterID, namespace, ok := si.GetHandoverTarget()
+	if !ok {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace '%s' in annotation %s: %s", namespace, common.HandoverToAnnotation, strings.Join(errs, ", "))
+	}
+	if strings.Contains(si.Annotations[common.HandoverToAnnotation], "/") && len(clusterID) == 0 {
+		return fmt.Errorf("missing cluster ID in annotation %s, the format is [<cluster ID>/]<namespace>", common.HandoverToAnnotation)
+	}
+	if len(clusterID) == 0 && namespace == si.Namespace {
+		return fmt.Errorf("annotation %s must reference another namespace or cluster", common.HandoverToAnnotation)
+	}
+	return nil
+}
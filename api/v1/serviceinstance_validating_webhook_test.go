@@ -1,11 +1,25 @@
 package v1
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/SAP/sap-btp-service-operator/api/common"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+type fakePlanChangeValidator struct {
+	calls int
+	err   error
+}
+
+func (f *fakePlanChangeValidator) ValidatePlanChange(_ context.Context, _, _ *ServiceInstance) (admission.Warnings, error) {
+	f.calls++
+	return nil, f.err
+}
+
 var _ = Describe("Service Instance Webhook Test", func() {
 	var instance *ServiceInstance
 	BeforeEach(func() {
@@ -40,4 +54,72 @@ var _ = Describe("Service Instance Webhook Test", func() {
 			})
 		})
 	})
+
+	Context("Validate Update", func() {
+		var validator *fakePlanChangeValidator
+
+		BeforeEach(func() {
+			validator = &fakePlanChangeValidator{}
+			SetPlanChangeValidator(validator)
+		})
+
+		AfterEach(func() {
+			SetPlanChangeValidator(nil)
+		})
+
+		It("should validate plan changes", func() {
+			validator.err = fmt.Errorf("plan is not updatable")
+			newInstance := instance.DeepCopy()
+			newInstance.Spec.ServicePlanName = "other-plan"
+			_, err := instance.ValidateUpdate(context.Background(), instance, newInstance)
+			Expect(err).To(MatchError("plan is not updatable"))
+			Expect(validator.calls).To(Equal(1))
+		})
+
+		It("should not validate the plan when it did not change", func() {
+			newInstance := instance.DeepCopy()
+			newInstance.Spec.ExternalName = "other-name"
+			_, err := instance.ValidateUpdate(context.Background(), instance, newInstance)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(validator.calls).To(BeZero())
+		})
+
+		It("should allow changing the instance to adopt until it is adopted", func() {
+			newInstance := instance.DeepCopy()
+			newInstance.Spec.InstanceID = "instance-id"
+			_, err := instance.ValidateUpdate(context.Background(), instance, newInstance)
+			Expect(err).ToNot(HaveOccurred())
+
+			instance.Spec.InstanceID = "instance-id"
+			instance.Status.InstanceID = "instance-id"
+			newInstance.Spec.InstanceID = "other-instance-id"
+			_, err = instance.ValidateUpdate(context.Background(), instance, newInstance)
+			Expect(err).To(MatchError(ContainSubstring("modifying spec.instanceID is not allowed")))
+		})
+	})
+
+	Context("Validate handover target", func() {
+		It("should accept another namespace or cluster", func() {
+			instance.Annotations = map[string]string{common.HandoverToAnnotation: "namespace-2"}
+			_, err := instance.ValidateCreate(context.Background(), instance)
+			Expect(err).ToNot(HaveOccurred())
+
+			newInstance := instance.DeepCopy()
+			newInstance.Annotations[common.HandoverToAnnotation] = "cluster-2/namespace-1"
+			_, err = instance.ValidateUpdate(context.Background(), instance, newInstance)
+			Expect(err).ToNot(HaveOccurred())
+			clusterID, namespace, ok := newInstance.GetHandoverTarget()
+			Expect(ok).To(BeTrue())
+			Expect(clusterID).To(Equal("cluster-2"))
+			Expect(namespace).To(Equal("namespace-1"))
+		})
+
+		It("should reject invalid targets", func() {
+			for _, target := range []string{"namespace-1", "Namespace_2", "cluster-2/", "/namespace-2"} {
+				instance.Annotations = map[string]string{common.HandoverToAnnotation: target}
+				_, err := instance.ValidateCreate(context.Background(), instance)
+				Expect(err).To(HaveOccurred(), target)
+			}
+		})
+	})
 })
@@ -1,10 +1,78 @@
 package v1
 
-// ParametersFromSource represents the source of a set of Parameters
+// ParametersFromSource represents the source of a set of Parameters.
+// Exactly one of the sources must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.secretKeyRef), has(self.configMapKeyRef), has(self.secretRef), has(self.configMapRef)].filter(x, x).size() == 1",message="exactly one of secretKeyRef, configMapKeyRef, secretRef or configMapRef must be set"
 type ParametersFromSource struct {
 	// The Secret key to select from.
 	// The value must be a JSON object.
-	SecretKeyRef *SecretKeyReference `json:"secretKeyRef" validate:"required"`
+	// +optional
+	SecretKeyRef *SecretKeyReference `json:"secretKeyRef,omitempty"`
+
+	// The ConfigMap key to select from.
+	// The value must be a JSON object.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeyReference `json:"configMapKeyRef,omitempty"`
+
+	// The Secret to select from.
+	// Every key of the secret is used as a top-level parameter with the key's value as string.
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+
+	// The ConfigMap to select from.
+	// Every key of the config map is used as a top-level parameter with the key's value as string.
+	// +optional
+	ConfigMapRef *ConfigMapReference `json:"configMapRef,omitempty"`
+
+	// Path is a dot separated path in the parameters document under which the parameters of this source are placed,
+	// for example oauth2-configuration.credentials. If not specified the parameters are placed at the top level.
+	// +kubebuilder:validation:Pattern=`^[^.]+(\.[^.]+)*$`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// MergeStrategy defines how the parameters of this source are merged into the parameters collected so far.
+	// Error fails on any duplicate key, DeepMerge recursively merges objects with this source winning on conflicting values,
+	// LastWins replaces duplicate keys with the values of this source. Defaults to Error.
+	// +kubebuilder:validation:Enum=Error;DeepMerge;LastWins
+	// +optional
+	MergeStrategy MergeStrategy `json:"mergeStrategy,omitempty"`
+}
+
+// MergeStrategy defines how parameters from a source are merged with other parameters.
+type MergeStrategy string
+
+const (
+	MergeStrategyError     MergeStrategy = "Error"
+	MergeStrategyDeepMerge MergeStrategy = "DeepMerge"
+	MergeStrategyLastWins  MergeStrategy = "LastWins"
+)
+
+// DeletionPolicy defines what happens to the resource in Service Manager when its custom resource is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the resource from Service Manager.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps the resource in Service Manager and removes the labels that tie it to the cluster.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// SecretFormat defines the layout of the binding secret.
+type SecretFormat string
+
+const (
+	// SecretFormatDefault stores the credentials and the instance info as the keys of the secret.
+	SecretFormatDefault SecretFormat = "Default"
+	// SecretFormatServiceBinding stores the secret in the servicebinding.io layout, with the servicebinding.io/<type>
+	// secret type and the type and provider keys.
+	SecretFormatServiceBinding SecretFormat = "ServiceBinding"
+)
+
+// BindingSecretReference references the secret holding the credentials of a resource, it is the ProvisionedService duck
+// type of servicebinding.io.
+type BindingSecretReference struct {
+	// The name of the secret in the namespace of the resource.
+	Name string `json:"name"`
 }
 
 // SecretKeyReference references a key of a Secret.
@@ -14,3 +82,59 @@ type SecretKeyReference struct {
 	// The key of the secret to select from.  Must be a valid secret key.
 	Key string `json:"key"`
 }
+
+// ConfigMapKeyReference references a key of a ConfigMap.
+type ConfigMapKeyReference struct {
+	// The name of the config map in the pod's namespace to select from.
+	Name string `json:"name"`
+	// The key of the config map to select from.  Must be a valid config map key.
+	Key string `json:"key"`
+}
+
+// SecretReference references a whole Secret.
+type SecretReference struct {
+	// The name of the secret in the pod's namespace to select from.
+	Name string `json:"name"`
+}
+
+// ConfigMapReference references a whole ConfigMap.
+type ConfigMapReference struct {
+	// The name of the config map in the pod's namespace to select from.
+	Name string `json:"name"`
+}
+
+// DryRunOperation is the operation the operator would execute in Service Manager.
+type DryRunOperation string
+
+const (
+	DryRunCreate  DryRunOperation = "Create"
+	DryRunUpdate  DryRunOperation = "Update"
+	DryRunShare   DryRunOperation = "Share"
+	DryRunUnShare DryRunOperation = "UnShare"
+	DryRunAdopt   DryRunOperation = "Adopt"
+	DryRunNone    DryRunOperation = "None"
+)
+
+// DryRunStatus previews the request the operator would send to Service Manager for the current spec.
+// It is published while the resource is annotated with services.cloud.sap.com/dry-run: "true".
+type DryRunStatus struct {
+	// The operation the operator would execute.
+	// +kubebuilder:validation:Enum=Create;Update;Share;UnShare;Adopt;None
+	Operation DryRunOperation `json:"operation"`
+
+	// The ID of the plan the request would be sent with.
+	// +optional
+	ServicePlanID string `json:"servicePlanID,omitempty"`
+
+	// The changes compared to the state in Service Manager. Parameter values are redacted.
+	// +optional
+	Changes []string `json:"changes,omitempty"`
+
+	// The reason the request would be rejected before it is sent to Service Manager.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// The generation the preview was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
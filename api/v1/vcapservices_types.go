@@ -0,0 +1,93 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VCAPServicesKey is the key of the VCAP_SERVICES document in the secret
+const VCAPServicesKey = "VCAP_SERVICES"
+
+// VCAPServicesSpec defines the desired state of VCAPServices
+type VCAPServicesSpec struct {
+	// SecretName is the name of the secret the VCAP_SERVICES document is stored in, defaults to the name of the resource
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// The names of the ServiceBindings in the namespace to aggregate
+	// +optional
+	Bindings []string `json:"bindings,omitempty"`
+
+	// Selects the ServiceBindings in the namespace to aggregate by their labels
+	// +optional
+	BindingSelector *metav1.LabelSelector `json:"bindingSelector,omitempty"`
+}
+
+// VCAPServicesStatus defines the observed state of VCAPServices
+type VCAPServicesStatus struct {
+	// Service VCAP services conditions
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Ready is true once the secret holds all the selected bindings
+	Ready metav1.ConditionStatus `json:"ready,omitempty"`
+
+	// The names of the ServiceBindings in the VCAP_SERVICES document
+	// +optional
+	Bindings []string `json:"bindings,omitempty"`
+
+	// The names of the selected ServiceBindings that are not ready yet, they are added once they are ready
+	// +optional
+	PendingBindings []string `json:"pendingBindings,omitempty"`
+
+	// Last generation that was acted on
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vcapservices,singular=vcapservices
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[0].reason",name="Status",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.ready",name="Ready",type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type=date
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[0].message",name="Message",type=string,priority=1
+
+// VCAPServices aggregates the credentials of ServiceBindings into a secret with a Cloud Foundry VCAP_SERVICES document
+type VCAPServices struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VCAPServicesSpec   `json:"spec,omitempty"`
+	Status VCAPServicesStatus `json:"status,omitempty"`
+}
+
+// GetSecretName returns the name of the secret the VCAP_SERVICES document is stored in
+func (vs *VCAPServices) GetSecretName() string {
+	if len(vs.Spec.SecretName) > 0 {
+		return vs.Spec.SecretName
+	}
+	return vs.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VCAPServicesList contains a list of VCAPServices
+type VCAPServicesList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VCAPServices `json:"items"`
+}
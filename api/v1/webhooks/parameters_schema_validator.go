@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	servicesv1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// smCallTimeout bounds the Service Manager calls made during admission
+const smCallTimeout = 5 * time.Second
+
+var parameterslog = logf.Log.WithName("parameters-schema-validator")
+
+// ParametersSchemaValidator validates the resolved parameters of instances and bindings against the schemas of their plan.
+// Schema violations are rejected when Enforce is set and returned as warnings otherwise. When the parameters or the plan
+// can't be resolved a warning is returned and the controller validates the parameters again before calling SM.
+type ParametersSchemaValidator struct {
+	Client      client.Client
+	GetSMClient func(ctx context.Context, instance *servicesv1.ServiceInstance) (sm.Client, error)
+	Enforce     bool
+}
+
+var _ servicesv1.ParametersValidator = &ParametersSchemaValidator{}
+
+func (v *ParametersSchemaValidator) ValidateInstanceParameters(ctx context.Context, instance *servicesv1.ServiceInstance, create bool) (admission.Warnings, error) {
+	ctx, cancel := context.WithTimeout(ctx, smCallTimeout)
+	defer cancel()
+
+	parameters, _, err := utils.BuildSMRequestParameters(ctx, instance.Namespace, instance.Spec.Parameters, instance.Spec.ParametersFrom)
+	if err != nil {
+		return skippedValidationWarning(err), nil
+	}
+
+	operation := utils.InstanceUpdateSchema
+	if create {
+		operation = utils.InstanceCreateSchema
+	}
+	return v.validate(ctx, instance, operation, parameters)
+}
+
+func (v *ParametersSchemaValidator) ValidateBindingParameters(ctx context.Context, binding *servicesv1.ServiceBinding) (admission.Warnings, error) {
+	ctx, cancel := context.WithTimeout(ctx, smCallTimeout)
+	defer cancel()
+
+	namespace := binding.Namespace
+	if len(binding.Spec.ServiceInstanceNamespace) > 0 {
+		namespace = binding.Spec.ServiceInstanceNamespace
+	}
+	instance := &servicesv1.ServiceInstance{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: binding.Spec.ServiceInstanceName, Namespace: namespace}, instance); err != nil {
+		return skippedValidationWarning(err), nil
+	}
+
+	parameters, _, err := utils.BuildSMRequestParameters(ctx, binding.Namespace, binding.Spec.Parameters, binding.Spec.ParametersFrom)
+	if err != nil {
+		return skippedValidationWarning(err), nil
+	}
+	return v.validate(ctx, instance, utils.BindingCreateSchema, parameters)
+}
+
+func (v *ParametersSchemaValidator) validate(ctx context.Context, instance *servicesv1.ServiceInstance, operation utils.PlanSchemaOperation, parameters []byte) (admission.Warnings, error) {
+	smClient, err := v.GetSMClient(ctx, instance)
+	if err != nil {
+		return skippedValidationWarning(err), nil
+	}
+	plan, _, err := smClient.GetPlan(ctx, instance.Spec.ServicePlanID, instance.Spec.ServiceOfferingName, instance.Spec.ServicePlanName, instance.Spec.DataCenter)
+	if err != nil {
+		return skippedValidationWarning(err), nil
+	}
+
+	err = utils.ValidateParametersSchema(plan, operation, parameters)
+	var schemaErr *utils.ParametersSchemaError
+	if err == nil {
+		return nil, nil
+	} else if !errors.As(err, &schemaErr) {
+		return skippedValidationWarning(err), nil
+	}
+
+	if v.Enforce {
+		return nil, schemaErr
+	}
+	return admission.Warnings{schemaErr.Error()}, nil
+}
+
+func skippedValidationWarning(err error) admission.Warnings {
+	parameterslog.Info(fmt.Sprintf("skipping parameters schema validation: %s", err.Error()))
+	return admission.Warnings{fmt.Sprintf("parameters were not validated against the plan schema: %s", err.Error())}
+}
@@ -0,0 +1,138 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	servicesv1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/client/sm/smfakes"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/SAP/sap-btp-service-operator/internal/config"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ParametersSchemaValidator", func() {
+	var (
+		validator *ParametersSchemaValidator
+		smClient  *smfakes.FakeClient
+		instance  *servicesv1.ServiceInstance
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(servicesv1.AddToScheme(scheme)).To(Succeed())
+
+		instance = &servicesv1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"},
+			Spec: servicesv1.ServiceInstanceSpec{
+				ServiceOfferingName: "xsuaa",
+				ServicePlanName:     "application",
+				Parameters:          &runtime.RawExtension{Raw: []byte(`{"xsappname":"app"}`)},
+			},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).Build()
+		utils.InitializeSecretsClient(k8sClient, k8sClient, config.Config{})
+
+		smClient = &smfakes.FakeClient{}
+		smClient.GetPlanReturns(&smClientTypes.ServicePlan{
+			Name: "application",
+			Schemas: []byte(`{
+				"service_instance": {"create": {"parameters": {"type": "object", "required": ["xsappname"], "properties": {"xsappname": {"type": "string"}}}}},
+				"service_binding": {"create": {"parameters": {"type": "object", "additionalProperties": false}}}
+			}`),
+		}, nil, nil)
+		validator = &ParametersSchemaValidator{
+			Client: k8sClient,
+			GetSMClient: func(_ context.Context, _ *servicesv1.ServiceInstance) (sm.Client, error) {
+				return smClient, nil
+			},
+			Enforce: true,
+		}
+	})
+
+	Describe("ValidateInstanceParameters", func() {
+		It("accepts valid parameters", func() {
+			warnings, err := validator.ValidateInstanceParameters(context.Background(), instance, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+			_, planID, offering, plan, _ := smClient.GetPlanArgsForCall(0)
+			Expect(planID).To(BeEmpty())
+			Expect(offering).To(Equal("xsuaa"))
+			Expect(plan).To(Equal("application"))
+		})
+
+		It("rejects invalid parameters when enforced", func() {
+			instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"xsappname":1}`)}
+			_, err := validator.ValidateInstanceParameters(context.Background(), instance, true)
+			Expect(err).To(MatchError(ContainSubstring("parameters.xsappname in body must be of type string")))
+		})
+
+		It("warns about invalid parameters when not enforced", func() {
+			validator.Enforce = false
+			instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{}`)}
+			warnings, err := validator.ValidateInstanceParameters(context.Background(), instance, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("parameters.xsappname in body is required")))
+		})
+
+		It("warns when the parameters can't be resolved", func() {
+			instance.Spec.ParametersFrom = []servicesv1.ParametersFromSource{
+				{SecretKeyRef: &servicesv1.SecretKeyReference{Name: "missing", Key: "key"}},
+			}
+			warnings, err := validator.ValidateInstanceParameters(context.Background(), instance, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("parameters were not validated against the plan schema")))
+			Expect(smClient.GetPlanCallCount()).To(BeZero())
+		})
+
+		It("warns when the plan can't be resolved", func() {
+			smClient.GetPlanReturns(nil, nil, fmt.Errorf("couldn't find the service plan"))
+			warnings, err := validator.ValidateInstanceParameters(context.Background(), instance, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("couldn't find the service plan")))
+		})
+
+		It("uses the update schema on update", func() {
+			instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{}`)}
+			warnings, err := validator.ValidateInstanceParameters(context.Background(), instance, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Describe("ValidateBindingParameters", func() {
+		var binding *servicesv1.ServiceBinding
+
+		BeforeEach(func() {
+			binding = &servicesv1.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "default"},
+				Spec: servicesv1.ServiceBindingSpec{
+					ServiceInstanceName: "instance",
+				},
+			}
+		})
+
+		It("validates the parameters against the plan of the instance", func() {
+			warnings, err := validator.ValidateBindingParameters(context.Background(), binding)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+
+			binding.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"role":"admin"}`)}
+			_, err = validator.ValidateBindingParameters(context.Background(), binding)
+			Expect(err).To(MatchError(ContainSubstring("parameters.role in body is a forbidden property")))
+		})
+
+		It("warns when the instance is not found", func() {
+			binding.Spec.ServiceInstanceName = "missing"
+			warnings, err := validator.ValidateBindingParameters(context.Background(), binding)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("not found")))
+		})
+	})
+})
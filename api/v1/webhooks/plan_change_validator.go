@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	servicesv1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var planchangelog = logf.Log.WithName("plan-change-validator")
+
+// PlanChangeValidator rejects plan changes of instances whose current plan is not updatable.
+// When the plans can't be resolved a warning is returned and the controller validates the change again before calling SM.
+type PlanChangeValidator struct {
+	GetSMClient func(ctx context.Context, instance *servicesv1.ServiceInstance) (sm.Client, error)
+}
+
+var _ servicesv1.PlanChangeValidator = &PlanChangeValidator{}
+
+func (v *PlanChangeValidator) ValidatePlanChange(ctx context.Context, oldInstance, newInstance *servicesv1.ServiceInstance) (admission.Warnings, error) {
+	if len(oldInstance.Status.ServicePlanID) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, smCallTimeout)
+	defer cancel()
+
+	smClient, err := v.GetSMClient(ctx, newInstance)
+	if err != nil {
+		return skippedPlanChangeWarning(err), nil
+	}
+
+	err = utils.ValidatePlanTransition(ctx, smClient, oldInstance.Status.ServicePlanID, newInstance)
+	var planErr *utils.PlanNotUpdatableError
+	if err == nil {
+		return nil, nil
+	} else if !errors.As(err, &planErr) {
+		return skippedPlanChangeWarning(err), nil
+	}
+	return nil, planErr
+}
+
+func skippedPlanChangeWarning(err error) admission.Warnings {
+	planchangelog.Info(fmt.Sprintf("skipping plan change validation: %s", err.Error()))
+	return admission.Warnings{fmt.Sprintf("plan change was not validated: %s", err.Error())}
+}
@@ -0,0 +1,78 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	servicesv1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/client/sm/smfakes"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("PlanChangeValidator", func() {
+	var (
+		validator   *PlanChangeValidator
+		smClient    *smfakes.FakeClient
+		oldInstance *servicesv1.ServiceInstance
+		newInstance *servicesv1.ServiceInstance
+		offering    *smClientTypes.ServiceOffering
+	)
+
+	BeforeEach(func() {
+		oldInstance = &servicesv1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"},
+			Spec: servicesv1.ServiceInstanceSpec{
+				ServiceOfferingName: "xsuaa",
+				ServicePlanName:     "application",
+			},
+			Status: servicesv1.ServiceInstanceStatus{ServicePlanID: "application-id"},
+		}
+		newInstance = oldInstance.DeepCopy()
+		newInstance.Spec.ServicePlanName = "broker"
+
+		offering = &smClientTypes.ServiceOffering{ID: "xsuaa-id", CatalogName: "xsuaa"}
+		smClient = &smfakes.FakeClient{}
+		smClient.GetPlanReturns(&smClientTypes.ServicePlan{ID: "broker-id", CatalogName: "broker", ServiceOfferingID: "xsuaa-id"}, offering, nil)
+		smClient.ListPlansReturns(&smClientTypes.ServicePlans{ServicePlans: []smClientTypes.ServicePlan{
+			{ID: "application-id", CatalogName: "application", ServiceOfferingID: "xsuaa-id"},
+		}}, nil)
+		smClient.ListOfferingsStub = func(_ context.Context, _ *sm.Parameters) (*smClientTypes.ServiceOfferings, error) {
+			return &smClientTypes.ServiceOfferings{ServiceOfferings: []smClientTypes.ServiceOffering{*offering}}, nil
+		}
+		validator = &PlanChangeValidator{
+			GetSMClient: func(_ context.Context, _ *servicesv1.ServiceInstance) (sm.Client, error) {
+				return smClient, nil
+			},
+		}
+	})
+
+	It("rejects plan changes when the plan is not updatable", func() {
+		_, err := validator.ValidatePlanChange(context.Background(), oldInstance, newInstance)
+		Expect(err).To(MatchError("plan 'application' of service offering 'xsuaa' is not updatable, the instance can't be moved to plan 'broker'"))
+	})
+
+	It("accepts plan changes when the offering is plan updatable", func() {
+		offering.PlanUpdatable = true
+		warnings, err := validator.ValidatePlanChange(context.Background(), oldInstance, newInstance)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("skips instances that were not provisioned yet", func() {
+		oldInstance.Status.ServicePlanID = ""
+		_, err := validator.ValidatePlanChange(context.Background(), oldInstance, newInstance)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(smClient.GetPlanCallCount()).To(BeZero())
+	})
+
+	It("warns when the plan can't be resolved", func() {
+		smClient.GetPlanReturns(nil, nil, fmt.Errorf("couldn't find the service plan"))
+		warnings, err := validator.ValidatePlanChange(context.Background(), oldInstance, newInstance)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("couldn't find the service plan")))
+	})
+})
@@ -0,0 +1,137 @@
+package sm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/client/sm/types"
+)
+
+const (
+	catalogKindOfferings = "offerings"
+	catalogKindPlans     = "plans"
+)
+
+type catalogEntry struct {
+	offerings []types.ServiceOffering
+	plans     []types.ServicePlan
+	expiresAt time.Time
+}
+
+// CatalogCache keeps the results of offering and plan queries for a limited time.
+// Entries are scoped per Service Manager URL and client ID, since every subaccount sees its own catalog.
+// A single cache is safe to share between clients.
+type CatalogCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	scopes    map[string]map[string]*catalogEntry
+	lastSweep time.Time
+	nowFunc   func() time.Time
+}
+
+func NewCatalogCache(ttl time.Duration) *CatalogCache {
+	return &CatalogCache{
+		ttl:     ttl,
+		scopes:  make(map[string]map[string]*catalogEntry),
+		nowFunc: time.Now,
+	}
+}
+
+// Invalidate drops all cached catalog entries of the given scope
+func (c *CatalogCache) Invalidate(scope string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.scopes, scope)
+}
+
+// InvalidateAll drops all cached catalog entries
+func (c *CatalogCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scopes = make(map[string]map[string]*catalogEntry)
+}
+
+func (c *CatalogCache) getOfferings(scope string, q *Parameters) (*types.ServiceOfferings, bool) {
+	entry, ok := c.get(scope, catalogKindOfferings, q)
+	if !ok {
+		return nil, false
+	}
+	return &types.ServiceOfferings{ServiceOfferings: append([]types.ServiceOffering(nil), entry.offerings...)}, true
+}
+
+func (c *CatalogCache) setOfferings(scope string, q *Parameters, offerings *types.ServiceOfferings) {
+	c.set(scope, catalogKindOfferings, q, &catalogEntry{offerings: append([]types.ServiceOffering(nil), offerings.ServiceOfferings...)})
+}
+
+func (c *CatalogCache) getPlans(scope string, q *Parameters) (*types.ServicePlans, bool) {
+	entry, ok := c.get(scope, catalogKindPlans, q)
+	if !ok {
+		return nil, false
+	}
+	return &types.ServicePlans{ServicePlans: append([]types.ServicePlan(nil), entry.plans...)}, true
+}
+
+func (c *CatalogCache) setPlans(scope string, q *Parameters, plans *types.ServicePlans) {
+	c.set(scope, catalogKindPlans, q, &catalogEntry{plans: append([]types.ServicePlan(nil), plans.ServicePlans...)})
+}
+
+func (c *CatalogCache) get(scope, kind string, q *Parameters) (*catalogEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, ok := c.scopes[scope]
+	if !ok {
+		return nil, false
+	}
+	key := catalogKey(kind, q)
+	entry, ok := entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.nowFunc().Before(entry.expiresAt) {
+		delete(entries, key)
+		return nil, false
+	}
+	return entry, true
+}
+
+func (c *CatalogCache) set(scope, kind string, q *Parameters, entry *catalogEntry) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.nowFunc()
+	if now.Sub(c.lastSweep) >= c.ttl {
+		c.sweep(now)
+	}
+	entries, ok := c.scopes[scope]
+	if !ok {
+		entries = make(map[string]*catalogEntry)
+		c.scopes[scope] = entries
+	}
+	entry.expiresAt = now.Add(c.ttl)
+	entries[catalogKey(kind, q)] = entry
+}
+
+// sweep drops the expired entries of all scopes, get drops them only when the same query is repeated.
+// It runs at most once per ttl, so every entry is dropped at the latest one ttl after it expired.
+func (c *CatalogCache) sweep(now time.Time) {
+	for scope, entries := range c.scopes {
+		for key, entry := range entries {
+			if !now.Before(entry.expiresAt) {
+				delete(entries, key)
+			}
+		}
+		if len(entries) == 0 {
+			delete(c.scopes, scope)
+		}
+	}
+	c.lastSweep = now
+}
+
+func catalogKey(kind string, q *Parameters) string {
+	return kind + "?" + q.Encode()
+}
@@ -0,0 +1,135 @@
+package sm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/client/sm/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CatalogCache", func() {
+	var (
+		cache *CatalogCache
+		now   time.Time
+		scope string
+		query *Parameters
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		cache = NewCatalogCache(time.Minute)
+		cache.nowFunc = func() time.Time { return now }
+		scope = ClientConfig{URL: "https://sm.url", ClientID: "client-id"}.Scope()
+		query = &Parameters{FieldQuery: []string{"catalog_name eq 'small'"}}
+		cache.setPlans(scope, query, &types.ServicePlans{ServicePlans: []types.ServicePlan{{ID: "plan-id"}}})
+	})
+
+	It("returns cached entries for the same query", func() {
+		plans, found := cache.getPlans(scope, query)
+		Expect(found).To(BeTrue())
+		Expect(plans.ServicePlans).To(HaveLen(1))
+		Expect(plans.ServicePlans[0].ID).To(Equal("plan-id"))
+	})
+
+	It("does not return entries of another query, kind or scope", func() {
+		_, found := cache.getPlans(scope, &Parameters{FieldQuery: []string{"catalog_name eq 'large'"}})
+		Expect(found).To(BeFalse())
+		_, found = cache.getOfferings(scope, query)
+		Expect(found).To(BeFalse())
+		_, found = cache.getPlans(ClientConfig{URL: "https://sm.url", ClientID: "other-client-id"}.Scope(), query)
+		Expect(found).To(BeFalse())
+	})
+
+	It("expires entries after the ttl", func() {
+		now = now.Add(time.Minute)
+		_, found := cache.getPlans(scope, query)
+		Expect(found).To(BeFalse())
+	})
+
+	It("drops expired entries of other queries and scopes when setting entries", func() {
+		otherScope := ClientConfig{URL: "https://sm.url", ClientID: "other-client-id"}.Scope()
+		now = now.Add(30 * time.Second)
+		cache.setOfferings(otherScope, query, &types.ServiceOfferings{})
+		Expect(cache.scopes).To(HaveLen(2))
+
+		now = now.Add(30 * time.Second)
+		cache.setOfferings(otherScope, &Parameters{}, &types.ServiceOfferings{})
+		Expect(cache.scopes).To(HaveLen(1))
+		Expect(cache.scopes[otherScope]).To(HaveLen(2))
+	})
+
+	It("drops entries of the scope on Invalidate", func() {
+		cache.Invalidate(scope)
+		_, found := cache.getPlans(scope, query)
+		Expect(found).To(BeFalse())
+	})
+
+	It("drops all entries on InvalidateAll", func() {
+		cache.InvalidateAll()
+		_, found := cache.getPlans(scope, query)
+		Expect(found).To(BeFalse())
+	})
+
+	It("returns copies that do not affect the cached entry", func() {
+		plans, _ := cache.getPlans(scope, query)
+		plans.ServicePlans[0].ID = "changed"
+		cached, _ := cache.getPlans(scope, query)
+		Expect(cached.ServicePlans[0].ID).To(Equal("plan-id"))
+	})
+
+	When("ttl is not positive", func() {
+		It("does not cache", func() {
+			cache = NewCatalogCache(0)
+			cache.setPlans(scope, query, &types.ServicePlans{})
+			_, found := cache.getPlans(scope, query)
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	When("the query spans several pages", func() {
+		var (
+			server   *httptest.Server
+			requests int
+		)
+
+		BeforeEach(func() {
+			requests = 0
+			server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, req *http.Request) {
+				requests++
+				page := listResponse{Token: "next", Items: []types.ServicePlan{{ID: "first-plan"}}}
+				if req.URL.Query().Get("token") == "next" {
+					page = listResponse{Items: []types.ServicePlan{{ID: "second-plan"}}}
+				}
+				body, _ := json.Marshal(page)
+				response.WriteHeader(http.StatusOK)
+				response.Write(body)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("caches all pages under the query of the caller and keeps the query unchanged", func() {
+			smClient, err := NewClient(context.TODO(), &ClientConfig{URL: server.URL, CatalogCache: cache}, &FakeAuthClient{AccessToken: validToken})
+			Expect(err).ToNot(HaveOccurred())
+			q := &Parameters{FieldQuery: []string{"catalog_name eq 'paged'"}}
+
+			plans, err := smClient.ListPlans(context.TODO(), q)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plans.ServicePlans).To(HaveLen(2))
+			Expect(requests).To(Equal(2))
+			Expect(q.GeneralParams).To(BeEmpty())
+
+			plans, err = smClient.ListPlans(context.TODO(), q)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plans.ServicePlans).To(HaveLen(2))
+			Expect(requests).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,125 @@
+package sm
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+)
+
+// ServiceManagerUnavailableError is returned without contacting Service Manager while the circuit breaker of its endpoint is open
+type ServiceManagerUnavailableError struct {
+	URL     string
+	RetryAt time.Time
+}
+
+func (e *ServiceManagerUnavailableError) Error() string {
+	return fmt.Sprintf("Service Manager at %s is unavailable, calls are suspended until %s", e.URL, e.RetryAt.UTC().Format(time.RFC3339))
+}
+
+type circuitState struct {
+	failures  int
+	openUntil time.Time
+	// probing is set while the single call let through after the open duration is in flight
+	probing bool
+}
+
+// CircuitBreaker tracks the availability of Service Manager endpoints.
+// After failureThreshold consecutive transient failures (network errors, 502/503/504) the circuit of the endpoint
+// opens and all calls to it fail fast for openDuration. Once the duration elapsed the circuit is half-open, a single
+// probe call is let through while the others keep failing fast. A failure of the probe re-opens the circuit and a
+// success closes it.
+// A single breaker is safe to share between clients.
+type CircuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	openDuration     time.Duration
+	endpoints        map[string]*circuitState
+	nowFunc          func() time.Time
+}
+
+func NewCircuitBreaker(failureThreshold int, openDuration time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		endpoints:        make(map[string]*circuitState),
+		nowFunc:          time.Now,
+	}
+}
+
+// Allow returns a *ServiceManagerUnavailableError while the circuit of the endpoint is open, or while it is half-open
+// and the probe call is in flight. A caller that was allowed must report the outcome with recordSuccess, recordFailure
+// or releaseProbe.
+func (b *CircuitBreaker) Allow(endpoint string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.endpoints[endpoint]
+	if !ok || state.openUntil.IsZero() {
+		return nil
+	}
+	now := b.nowFunc()
+	if now.Before(state.openUntil) {
+		metrics.SMCircuitBreakerRejected.WithLabelValues(endpointHost(endpoint)).Inc()
+		return &ServiceManagerUnavailableError{URL: endpoint, RetryAt: state.openUntil}
+	}
+	if state.probing {
+		metrics.SMCircuitBreakerRejected.WithLabelValues(endpointHost(endpoint)).Inc()
+		return &ServiceManagerUnavailableError{URL: endpoint, RetryAt: now}
+	}
+	state.probing = true
+	return nil
+}
+
+// releaseProbe lets another probe through when the probe call ended without telling whether Service Manager is
+// available, for example when the caller canceled it
+func (b *CircuitBreaker) releaseProbe(endpoint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if state, ok := b.endpoints[endpoint]; ok {
+		state.probing = false
+	}
+}
+
+func (b *CircuitBreaker) recordSuccess(endpoint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.endpoints[endpoint]; ok {
+		delete(b.endpoints, endpoint)
+		metrics.SMCircuitBreakerOpen.WithLabelValues(endpointHost(endpoint)).Set(0)
+	}
+}
+
+func (b *CircuitBreaker) recordFailure(endpoint string) {
+	if b.failureThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.endpoints[endpoint]
+	if !ok {
+		state = &circuitState{}
+		b.endpoints[endpoint] = state
+	}
+	state.failures++
+	state.probing = false
+
+	// a failure after the circuit was open (half-open) re-opens it immediately
+	if state.failures >= b.failureThreshold || !state.openUntil.IsZero() {
+		state.openUntil = b.nowFunc().Add(b.openDuration)
+		metrics.SMCircuitBreakerOpen.WithLabelValues(endpointHost(endpoint)).Set(1)
+	}
+}
+
+func endpointHost(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil && len(u.Host) > 0 {
+		return u.Host
+	}
+	return endpoint
+}
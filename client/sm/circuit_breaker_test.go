@@ -0,0 +1,207 @@
+package sm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/client/sm/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CircuitBreaker", func() {
+	var (
+		breaker  *CircuitBreaker
+		now      time.Time
+		endpoint = "https://service-manager.cfapps.sap.hana.ondemand.com"
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		breaker = NewCircuitBreaker(3, time.Minute)
+		breaker.nowFunc = func() time.Time { return now }
+	})
+
+	It("opens after consecutive failures reach the threshold", func() {
+		breaker.recordFailure(endpoint)
+		breaker.recordFailure(endpoint)
+		Expect(breaker.Allow(endpoint)).To(Succeed())
+
+		breaker.recordFailure(endpoint)
+		err := breaker.Allow(endpoint)
+		var unavailableErr *ServiceManagerUnavailableError
+		Expect(errors.As(err, &unavailableErr)).To(BeTrue())
+		Expect(unavailableErr.RetryAt).To(Equal(now.Add(time.Minute)))
+	})
+
+	It("resets the failure count on success", func() {
+		breaker.recordFailure(endpoint)
+		breaker.recordFailure(endpoint)
+		breaker.recordSuccess(endpoint)
+		breaker.recordFailure(endpoint)
+		Expect(breaker.Allow(endpoint)).To(Succeed())
+	})
+
+	It("lets calls through after the open duration and re-opens on a single failure", func() {
+		for i := 0; i < 3; i++ {
+			breaker.recordFailure(endpoint)
+		}
+		now = now.Add(time.Minute)
+		Expect(breaker.Allow(endpoint)).To(Succeed())
+
+		breaker.recordFailure(endpoint)
+		Expect(breaker.Allow(endpoint)).ToNot(Succeed())
+	})
+
+	It("closes after a success once the open duration elapsed", func() {
+		for i := 0; i < 3; i++ {
+			breaker.recordFailure(endpoint)
+		}
+		now = now.Add(time.Minute)
+		breaker.recordSuccess(endpoint)
+		breaker.recordFailure(endpoint)
+		Expect(breaker.Allow(endpoint)).To(Succeed())
+	})
+
+	It("lets a single probe through while the circuit is half-open", func() {
+		for i := 0; i < 3; i++ {
+			breaker.recordFailure(endpoint)
+		}
+		now = now.Add(time.Minute)
+
+		var allowed atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if breaker.Allow(endpoint) == nil {
+					allowed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		Expect(allowed.Load()).To(Equal(int32(1)))
+
+		breaker.recordSuccess(endpoint)
+		Expect(breaker.Allow(endpoint)).To(Succeed())
+		Expect(breaker.Allow(endpoint)).To(Succeed())
+	})
+
+	It("re-opens the circuit when the probe fails", func() {
+		for i := 0; i < 3; i++ {
+			breaker.recordFailure(endpoint)
+		}
+		now = now.Add(time.Minute)
+		Expect(breaker.Allow(endpoint)).To(Succeed())
+		Expect(breaker.Allow(endpoint)).ToNot(Succeed())
+
+		breaker.recordFailure(endpoint)
+		now = now.Add(time.Second)
+		Expect(breaker.Allow(endpoint)).ToNot(Succeed())
+		now = now.Add(time.Minute)
+		Expect(breaker.Allow(endpoint)).To(Succeed())
+	})
+
+	It("lets another probe through when the probe is released", func() {
+		for i := 0; i < 3; i++ {
+			breaker.recordFailure(endpoint)
+		}
+		now = now.Add(time.Minute)
+		Expect(breaker.Allow(endpoint)).To(Succeed())
+		breaker.releaseProbe(endpoint)
+		Expect(breaker.Allow(endpoint)).To(Succeed())
+		Expect(breaker.Allow(endpoint)).ToNot(Succeed())
+	})
+
+	It("tracks endpoints separately", func() {
+		for i := 0; i < 3; i++ {
+			breaker.recordFailure(endpoint)
+		}
+		Expect(breaker.Allow("https://other.endpoint")).To(Succeed())
+	})
+
+	Context("When used by the client", func() {
+		BeforeEach(func() {
+			handlerDetails = []HandlerDetails{
+				{Method: http.MethodGet, Path: types.ServiceInstancesURL + "/", ResponseStatusCode: http.StatusServiceUnavailable},
+			}
+		})
+
+		It("fails fast once the circuit is open", func() {
+			var err error
+			client, err = NewClient(context.TODO(), &ClientConfig{URL: smServer.URL, CircuitBreaker: NewCircuitBreaker(2, time.Minute)}, fakeAuthClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := 0; i < 2; i++ {
+				_, err = client.GetInstanceByID(context.TODO(), "instanceID", nil)
+				expectErrorToContainSubstringAndStatusCode(err, "", http.StatusServiceUnavailable)
+			}
+			Expect(fakeAuthClient.requests).To(Equal(2))
+
+			_, err = client.GetInstanceByID(context.TODO(), "instanceID", nil)
+			var unavailableErr *ServiceManagerUnavailableError
+			Expect(errors.As(err, &unavailableErr)).To(BeTrue())
+			Expect(fakeAuthClient.requests).To(Equal(2))
+		})
+
+		It("fails sharing with the unavailable error once the circuit is open", func() {
+			breaker := NewCircuitBreaker(1, time.Minute)
+			breaker.recordFailure(smServer.URL)
+			var err error
+			client, err = NewClient(context.TODO(), &ClientConfig{URL: smServer.URL, CircuitBreaker: breaker}, fakeAuthClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			var unavailableErr *ServiceManagerUnavailableError
+			Expect(errors.As(client.ShareInstance(context.TODO(), "instanceID", "test-user"), &unavailableErr)).To(BeTrue())
+			Expect(errors.As(client.UnShareInstance(context.TODO(), "instanceID", "test-user"), &unavailableErr)).To(BeTrue())
+			Expect(fakeAuthClient.requests).To(Equal(0))
+		})
+
+		It("opens the circuit when Service Manager hangs past the operation deadline", func() {
+			var err error
+			client, err = NewClient(context.TODO(), &ClientConfig{
+				URL:               smServer.URL,
+				CircuitBreaker:    NewCircuitBreaker(2, time.Minute),
+				OperationTimeouts: map[string]time.Duration{OperationGetInstance: 20 * time.Millisecond},
+			}, &hangingHTTPClient{})
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := 0; i < 2; i++ {
+				_, err = client.GetInstanceByID(context.TODO(), "instanceID", nil)
+				Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			}
+
+			_, err = client.GetInstanceByID(context.TODO(), "instanceID", nil)
+			var unavailableErr *ServiceManagerUnavailableError
+			Expect(errors.As(err, &unavailableErr)).To(BeTrue())
+		})
+
+		It("does not count calls canceled by the caller", func() {
+			var err error
+			client, err = NewClient(context.TODO(), &ClientConfig{URL: smServer.URL, CircuitBreaker: NewCircuitBreaker(1, time.Minute)}, &hangingHTTPClient{})
+			Expect(err).ToNot(HaveOccurred())
+
+			ctx, cancel := context.WithCancel(context.TODO())
+			cancel()
+			_, err = client.GetInstanceByID(ctx, "instanceID", nil)
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+
+			_, err = client.GetInstanceByID(ctx, "instanceID", nil)
+			var unavailableErr *ServiceManagerUnavailableError
+			Expect(errors.As(err, &unavailableErr)).To(BeFalse())
+		})
+	})
+})
+
+// hangingHTTPClient never answers, like a Service Manager that accepts connections but doesn't respond
+type hangingHTTPClient struct{}
+
+func (c *hangingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
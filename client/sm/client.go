@@ -28,12 +28,15 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/SAP/sap-btp-service-operator/api/common"
 	"github.com/SAP/sap-btp-service-operator/client/sm/types"
 	"github.com/SAP/sap-btp-service-operator/internal/auth"
 	"github.com/SAP/sap-btp-service-operator/internal/httputil"
 	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
@@ -42,34 +45,63 @@ const (
 	originatingIdentityHeader = "X-Originating-Identity"
 )
 
+// Operation names used to configure per-operation deadlines (see ClientConfig.OperationTimeouts)
+const (
+	OperationProvision       = "provision"
+	OperationDeprovision     = "deprovision"
+	OperationUpdateInstance  = "update_instance"
+	OperationGetInstance     = "get_instance"
+	OperationGetParameters   = "get_instance_parameters"
+	OperationListInstances   = "list_instances"
+	OperationShareInstance   = "share_instance"
+	OperationUnShareInstance = "unshare_instance"
+	OperationInstanceLabels  = "update_instance_labels"
+	OperationBind            = "bind"
+	OperationUnbind          = "unbind"
+	OperationGetBinding      = "get_binding"
+	OperationListBindings    = "list_bindings"
+	OperationRenameBinding   = "rename_binding"
+	OperationBindingLabels   = "update_binding_labels"
+	OperationListOfferings   = "list_offerings"
+	OperationListPlans       = "list_plans"
+	OperationStatus          = "status"
+)
+
 var AppVersion string
 
 // Client should be implemented by SM clients
 //
 //go:generate counterfeiter . Client
 type Client interface {
-	ListInstances(*Parameters) (*types.ServiceInstances, error)
-	GetInstanceByID(string, *Parameters) (*types.ServiceInstance, error)
-	UpdateInstance(id string, updatedInstance *types.ServiceInstance, serviceName string, planName string, q *Parameters, user string, dataCenter string) (*types.ServiceInstance, string, error)
-	Provision(instance *types.ServiceInstance, serviceName string, planName string, q *Parameters, user string, dataCenter string) (*ProvisionResponse, error)
-	Deprovision(id string, q *Parameters, user string) (string, error)
-
-	ListBindings(*Parameters) (*types.ServiceBindings, error)
-	GetBindingByID(string, *Parameters) (*types.ServiceBinding, error)
-	Bind(binding *types.ServiceBinding, q *Parameters, user string) (*types.ServiceBinding, string, error)
-	Unbind(id string, q *Parameters, user string) (string, error)
-	RenameBinding(id, newName, newK8SName string) (*types.ServiceBinding, error)
-	ShareInstance(id string, user string) error
-	UnShareInstance(id string, user string) error
-
-	ListOfferings(*Parameters) (*types.ServiceOfferings, error)
-	ListPlans(*Parameters) (*types.ServicePlans, error)
-
-	Status(string, types.OperationCategory, *Parameters) (*types.Operation, error)
+	ListInstances(ctx context.Context, q *Parameters) (*types.ServiceInstances, error)
+	GetInstanceByID(ctx context.Context, id string, q *Parameters) (*types.ServiceInstance, error)
+	GetInstanceParameters(ctx context.Context, id string, q *Parameters) (map[string]interface{}, error)
+	UpdateInstance(ctx context.Context, id string, updatedInstance *types.ServiceInstance, serviceName string, planName string, q *Parameters, user string, dataCenter string) (*types.ServiceInstance, string, error)
+	Provision(ctx context.Context, instance *types.ServiceInstance, serviceName string, planName string, q *Parameters, user string, dataCenter string) (*ProvisionResponse, error)
+	Deprovision(ctx context.Context, id string, q *Parameters, user string) (string, error)
+
+	ListBindings(ctx context.Context, q *Parameters) (*types.ServiceBindings, error)
+	GetBindingByID(ctx context.Context, id string, q *Parameters) (*types.ServiceBinding, error)
+	Bind(ctx context.Context, binding *types.ServiceBinding, q *Parameters, user string) (*types.ServiceBinding, string, error)
+	Unbind(ctx context.Context, id string, q *Parameters, user string) (string, error)
+	RenameBinding(ctx context.Context, id, newName, newK8SName string) (*types.ServiceBinding, error)
+	// UpdateBindingLabels applies the label changes to the binding, a key can't be removed and added in the same request
+	UpdateBindingLabels(ctx context.Context, id string, changes []*types.LabelChange) (*types.ServiceBinding, error)
+	ShareInstance(ctx context.Context, id string, user string) error
+	UnShareInstance(ctx context.Context, id string, user string) error
+	// UpdateInstanceLabels applies the label changes to the instance, a key can't be removed and added in the same request
+	UpdateInstanceLabels(ctx context.Context, id string, changes []*types.LabelChange) (*types.ServiceInstance, error)
+
+	ListOfferings(ctx context.Context, q *Parameters) (*types.ServiceOfferings, error)
+	ListPlans(ctx context.Context, q *Parameters) (*types.ServicePlans, error)
+	// GetPlan resolves the plan and its service offering the same way Provision and UpdateInstance do
+	GetPlan(ctx context.Context, planID string, serviceName string, planName string, dataCenter string) (*types.ServicePlan, *types.ServiceOffering, error)
+
+	Status(ctx context.Context, url string, operationType types.OperationCategory, q *Parameters) (*types.Operation, error)
 
 	// Call makes HTTP request to the Service Manager server with authentication.
 	// It should be used only in case there is no already implemented method for such an operation
-	Call(method string, smpath string, body io.Reader, q *Parameters) (*http.Response, error)
+	Call(ctx context.Context, method string, smpath string, body io.Reader, q *Parameters) (*http.Response, error)
 }
 
 type ServiceManagerError struct {
@@ -97,13 +129,13 @@ func (e *ServiceManagerError) GetStatusCode() int {
 }
 
 type serviceManagerClient struct {
-	Context    context.Context
 	Config     *ClientConfig
 	HTTPClient auth.HTTPClient
 }
 
 type planInfo struct {
 	planID          string
+	plan            *types.ServicePlan
 	serviceOffering *types.ServiceOffering
 }
 
@@ -118,7 +150,7 @@ type ProvisionResponse struct {
 // NewClient NewClientWithAuth returns new SM Client configured with the provided configuration
 func NewClient(ctx context.Context, config *ClientConfig, httpClient auth.HTTPClient) (Client, error) {
 	if httpClient != nil {
-		return &serviceManagerClient{Context: ctx, Config: config, HTTPClient: httpClient}, nil
+		return &serviceManagerClient{Config: config, HTTPClient: httpClient}, nil
 	}
 	ccConfig := &clientcredentials.Config{
 		ClientID:     config.ClientID,
@@ -137,11 +169,26 @@ func NewClient(ctx context.Context, config *ClientConfig, httpClient auth.HTTPCl
 	} else {
 		authClient = auth.NewAuthClient(ctx, ccConfig, config.SSLDisabled)
 	}
-	return &serviceManagerClient{Context: ctx, Config: config, HTTPClient: authClient}, nil
+	return &serviceManagerClient{Config: config, HTTPClient: authClient}, nil
+}
+
+// CloseIdleConnections closes idle connections kept by the underlying http transport
+func (client *serviceManagerClient) CloseIdleConnections() {
+	if closer, ok := client.HTTPClient.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// Scope identifies the credentials of the client, see ClientConfig.Scope
+func (client *serviceManagerClient) Scope() string {
+	return client.Config.Scope()
 }
 
 // Provision provisions a new service instance in service manager
-func (client *serviceManagerClient) Provision(instance *types.ServiceInstance, serviceName string, planName string, q *Parameters, user string, dataCenter string) (*ProvisionResponse, error) {
+func (client *serviceManagerClient) Provision(ctx context.Context, instance *types.ServiceInstance, serviceName string, planName string, q *Parameters, user string, dataCenter string) (*ProvisionResponse, error) {
+	ctx, cancel := client.withOperation(ctx, OperationProvision)
+	defer cancel()
+
 	var newInstance *types.ServiceInstance
 	var instanceID string
 	var subaccountID string
@@ -150,14 +197,14 @@ func (client *serviceManagerClient) Provision(instance *types.ServiceInstance, s
 		return nil, fmt.Errorf("missing field values. Specify service name and plan name for the instance '%s'", instance.Name)
 	}
 
-	planInfo, err := client.getPlanInfo(instance.ServicePlanID, serviceName, planName, dataCenter)
+	planInfo, err := client.getPlanInfo(ctx, instance.ServicePlanID, serviceName, planName, dataCenter)
 	if err != nil {
 		return nil, err
 	}
 
 	instance.ServicePlanID = planInfo.planID
 
-	location, err := client.register(instance, types.ServiceInstancesURL, q, user, &newInstance)
+	location, err := client.register(ctx, instance, types.ServiceInstancesURL, q, user, &newInstance)
 	if err != nil {
 		return nil, err
 	}
@@ -186,9 +233,12 @@ func (client *serviceManagerClient) Provision(instance *types.ServiceInstance, s
 }
 
 // Bind creates binding to an instance in service manager
-func (client *serviceManagerClient) Bind(binding *types.ServiceBinding, q *Parameters, user string) (*types.ServiceBinding, string, error) {
+func (client *serviceManagerClient) Bind(ctx context.Context, binding *types.ServiceBinding, q *Parameters, user string) (*types.ServiceBinding, string, error) {
+	ctx, cancel := client.withOperation(ctx, OperationBind)
+	defer cancel()
+
 	var newBinding *types.ServiceBinding
-	location, err := client.register(binding, types.ServiceBindingsURL, q, user, &newBinding)
+	location, err := client.register(ctx, binding, types.ServiceBindingsURL, q, user, &newBinding)
 	if err != nil {
 		return nil, "", err
 	}
@@ -196,40 +246,66 @@ func (client *serviceManagerClient) Bind(binding *types.ServiceBinding, q *Param
 }
 
 // ListInstances returns service instances registered in the Service Manager satisfying provided queries
-func (client *serviceManagerClient) ListInstances(q *Parameters) (*types.ServiceInstances, error) {
+func (client *serviceManagerClient) ListInstances(ctx context.Context, q *Parameters) (*types.ServiceInstances, error) {
+	ctx, cancel := client.withOperation(ctx, OperationListInstances)
+	defer cancel()
+
 	instances := &types.ServiceInstances{}
-	err := client.list(&instances.ServiceInstances, types.ServiceInstancesURL, q)
+	err := client.list(ctx, &instances.ServiceInstances, types.ServiceInstancesURL, q)
 
 	return instances, err
 }
 
 // GetInstanceByID returns instance registered in the Service Manager satisfying provided queries
-func (client *serviceManagerClient) GetInstanceByID(id string, q *Parameters) (*types.ServiceInstance, error) {
+func (client *serviceManagerClient) GetInstanceByID(ctx context.Context, id string, q *Parameters) (*types.ServiceInstance, error) {
+	ctx, cancel := client.withOperation(ctx, OperationGetInstance)
+	defer cancel()
+
 	instance := &types.ServiceInstance{}
-	err := client.get(instance, types.ServiceInstancesURL+"/"+id, q)
+	err := client.get(ctx, instance, types.ServiceInstancesURL+"/"+id, q)
 
 	return instance, err
 }
 
+// GetInstanceParameters returns the parameters of the service instance as provided by the service broker
+func (client *serviceManagerClient) GetInstanceParameters(ctx context.Context, id string, q *Parameters) (map[string]interface{}, error) {
+	ctx, cancel := client.withOperation(ctx, OperationGetParameters)
+	defer cancel()
+
+	parameters := map[string]interface{}{}
+	err := client.get(ctx, &parameters, types.ServiceInstancesURL+"/"+id+"/parameters", q)
+
+	return parameters, err
+}
+
 // ListBindings returns service bindings registered in the Service Manager satisfying provided queries
-func (client *serviceManagerClient) ListBindings(q *Parameters) (*types.ServiceBindings, error) {
+func (client *serviceManagerClient) ListBindings(ctx context.Context, q *Parameters) (*types.ServiceBindings, error) {
+	ctx, cancel := client.withOperation(ctx, OperationListBindings)
+	defer cancel()
+
 	bindings := &types.ServiceBindings{}
-	err := client.list(&bindings.ServiceBindings, types.ServiceBindingsURL, q)
+	err := client.list(ctx, &bindings.ServiceBindings, types.ServiceBindingsURL, q)
 
 	return bindings, err
 }
 
 // GetBindingByID returns binding registered in the Service Manager satisfying provided queries
-func (client *serviceManagerClient) GetBindingByID(id string, q *Parameters) (*types.ServiceBinding, error) {
+func (client *serviceManagerClient) GetBindingByID(ctx context.Context, id string, q *Parameters) (*types.ServiceBinding, error) {
+	ctx, cancel := client.withOperation(ctx, OperationGetBinding)
+	defer cancel()
+
 	binding := &types.ServiceBinding{}
-	err := client.get(binding, types.ServiceBindingsURL+"/"+id, q)
+	err := client.get(ctx, binding, types.ServiceBindingsURL+"/"+id, q)
 
 	return binding, err
 }
 
-func (client *serviceManagerClient) Status(url string, operationType types.OperationCategory, q *Parameters) (*types.Operation, error) {
+func (client *serviceManagerClient) Status(ctx context.Context, url string, operationType types.OperationCategory, q *Parameters) (*types.Operation, error) {
+	ctx, cancel := client.withOperation(ctx, OperationStatus)
+	defer cancel()
+
 	operation := &types.Operation{}
-	err := client.get(operation, url, q)
+	err := client.get(ctx, operation, url, q)
 
 	//when polling for delete and resource was already deleted SM returns 404 - operation completed successfully
 	if operationType == types.DELETE {
@@ -244,30 +320,51 @@ func (client *serviceManagerClient) Status(url string, operationType types.Opera
 	return operation, err
 }
 
-func (client *serviceManagerClient) Deprovision(id string, q *Parameters, user string) (string, error) {
-	return client.delete(types.ServiceInstancesURL+"/"+id, q, user)
+func (client *serviceManagerClient) Deprovision(ctx context.Context, id string, q *Parameters, user string) (string, error) {
+	ctx, cancel := client.withOperation(ctx, OperationDeprovision)
+	defer cancel()
+
+	return client.delete(ctx, types.ServiceInstancesURL+"/"+id, q, user)
 }
 
-func (client *serviceManagerClient) Unbind(id string, q *Parameters, user string) (string, error) {
-	return client.delete(types.ServiceBindingsURL+"/"+id, q, user)
+func (client *serviceManagerClient) Unbind(ctx context.Context, id string, q *Parameters, user string) (string, error) {
+	ctx, cancel := client.withOperation(ctx, OperationUnbind)
+	defer cancel()
+
+	return client.delete(ctx, types.ServiceBindingsURL+"/"+id, q, user)
 }
 
-func (client *serviceManagerClient) UpdateInstance(id string, updatedInstance *types.ServiceInstance, serviceName string, planName string, q *Parameters, user string, dataCenter string) (*types.ServiceInstance, string, error) {
+func (client *serviceManagerClient) UpdateInstance(ctx context.Context, id string, updatedInstance *types.ServiceInstance, serviceName string, planName string, q *Parameters, user string, dataCenter string) (*types.ServiceInstance, string, error) {
+	ctx, cancel := client.withOperation(ctx, OperationUpdateInstance)
+	defer cancel()
+
 	var result *types.ServiceInstance
 
-	planInfo, err := client.getPlanInfo(updatedInstance.ServicePlanID, serviceName, planName, dataCenter)
+	planInfo, err := client.getPlanInfo(ctx, updatedInstance.ServicePlanID, serviceName, planName, dataCenter)
 	if err != nil {
 		return nil, "", err
 	}
 	updatedInstance.ServicePlanID = planInfo.planID
-	location, err := client.update(updatedInstance, types.ServiceInstancesURL, id, q, user, &result)
+	location, err := client.update(ctx, updatedInstance, types.ServiceInstancesURL, id, q, user, &result)
 	if err != nil {
 		return nil, "", err
 	}
 	return result, location, nil
 }
 
-func (client *serviceManagerClient) RenameBinding(id, newName, newK8SName string) (*types.ServiceBinding, error) {
+// GetPlan returns the plan matching the given offering and plan names (and plan ID if provided) together with its service offering
+func (client *serviceManagerClient) GetPlan(ctx context.Context, planID string, serviceName string, planName string, dataCenter string) (*types.ServicePlan, *types.ServiceOffering, error) {
+	info, err := client.getPlanInfo(ctx, planID, serviceName, planName, dataCenter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return info.plan, info.serviceOffering, nil
+}
+
+func (client *serviceManagerClient) RenameBinding(ctx context.Context, id, newName, newK8SName string) (*types.ServiceBinding, error) {
+	ctx, cancel := client.withOperation(ctx, OperationRenameBinding)
+	defer cancel()
+
 	const k8sNameLabel = "_k8sname"
 	renameRequest := map[string]interface{}{
 		"name": newName,
@@ -280,7 +377,7 @@ func (client *serviceManagerClient) RenameBinding(id, newName, newK8SName string
 	}
 
 	var result *types.ServiceBinding
-	_, err := client.update(renameRequest, types.ServiceBindingsURL, id, nil, "", &result)
+	_, err := client.update(ctx, renameRequest, types.ServiceBindingsURL, id, nil, "", &result)
 	if err != nil {
 		return nil, err
 	}
@@ -296,23 +393,39 @@ func (client *serviceManagerClient) RenameBinding(id, newName, newK8SName string
 			},
 		},
 	}
-	_, err = client.update(addLabelRequest, types.ServiceBindingsURL, id, nil, "", &result)
+	_, err = client.update(ctx, addLabelRequest, types.ServiceBindingsURL, id, nil, "", &result)
 	if err != nil {
 		return nil, err
 	}
 	return result, err
 }
 
-func (client *serviceManagerClient) list(items interface{}, url string, q *Parameters) error {
+func (client *serviceManagerClient) UpdateBindingLabels(ctx context.Context, id string, changes []*types.LabelChange) (*types.ServiceBinding, error) {
+	ctx, cancel := client.withOperation(ctx, OperationBindingLabels)
+	defer cancel()
+
+	labelsRequest := map[string]interface{}{
+		"labels": changes,
+	}
+	var result *types.ServiceBinding
+	if _, err := client.update(ctx, labelsRequest, types.ServiceBindingsURL, id, nil, "", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (client *serviceManagerClient) list(ctx context.Context, items interface{}, url string, q *Parameters) error {
 	itemsType := reflect.TypeOf(items)
 	if itemsType.Kind() != reflect.Ptr || itemsType.Elem().Kind() != reflect.Slice {
 		return fmt.Errorf("items should be a pointer to a slice, but got %v", itemsType)
 	}
 
 	allItems := reflect.MakeSlice(itemsType.Elem(), 0, 0)
+	// the iterator appends the paging token to its parameters, the query of the caller, which is also the catalog
+	// cache key, must stay unchanged
 	iter := listIterator{
 		URL:    url,
-		Params: q,
+		Params: q.copy(),
 		Client: client,
 	}
 
@@ -320,7 +433,7 @@ func (client *serviceManagerClient) list(items interface{}, url string, q *Param
 	for more {
 		var err error
 		pageSlice := reflect.New(itemsType.Elem())
-		more, _, err = iter.nextPage(pageSlice.Interface(), -1)
+		more, _, err = iter.nextPage(ctx, pageSlice.Interface(), -1)
 		if err != nil {
 			return err
 		}
@@ -330,28 +443,52 @@ func (client *serviceManagerClient) list(items interface{}, url string, q *Param
 	return nil
 }
 
-func (client *serviceManagerClient) ListOfferings(q *Parameters) (*types.ServiceOfferings, error) {
+func (client *serviceManagerClient) ListOfferings(ctx context.Context, q *Parameters) (*types.ServiceOfferings, error) {
+	ctx, cancel := client.withOperation(ctx, OperationListOfferings)
+	defer cancel()
+
+	if cache := client.Config.CatalogCache; cache != nil {
+		if offerings, found := cache.getOfferings(client.Config.Scope(), q); found {
+			return offerings, nil
+		}
+	}
+
 	offerings := &types.ServiceOfferings{}
-	err := client.list(&offerings.ServiceOfferings, types.ServiceOfferingsURL, q)
+	err := client.list(ctx, &offerings.ServiceOfferings, types.ServiceOfferingsURL, q)
+	if err == nil && client.Config.CatalogCache != nil {
+		client.Config.CatalogCache.setOfferings(client.Config.Scope(), q, offerings)
+	}
 
 	return offerings, err
 }
 
-func (client *serviceManagerClient) ListPlans(q *Parameters) (*types.ServicePlans, error) {
+func (client *serviceManagerClient) ListPlans(ctx context.Context, q *Parameters) (*types.ServicePlans, error) {
+	ctx, cancel := client.withOperation(ctx, OperationListPlans)
+	defer cancel()
+
+	if cache := client.Config.CatalogCache; cache != nil {
+		if plans, found := cache.getPlans(client.Config.Scope(), q); found {
+			return plans, nil
+		}
+	}
+
 	plans := &types.ServicePlans{}
-	err := client.list(&plans.ServicePlans, types.ServicePlansURL, q)
+	err := client.list(ctx, &plans.ServicePlans, types.ServicePlansURL, q)
+	if err == nil && client.Config.CatalogCache != nil {
+		client.Config.CatalogCache.setPlans(client.Config.Scope(), q, plans)
+	}
 
 	return plans, err
 }
 
-func (client *serviceManagerClient) register(resource interface{}, url string, q *Parameters, user string, result interface{}) (string, error) {
+func (client *serviceManagerClient) register(ctx context.Context, resource interface{}, url string, q *Parameters, user string, result interface{}) (string, error) {
 	requestBody, err := json.Marshal(resource)
 	if err != nil {
 		return "", err
 	}
 
 	buffer := bytes.NewBuffer(requestBody)
-	response, err := client.callWithUser(http.MethodPost, url, buffer, q, user)
+	response, err := client.callWithUser(ctx, http.MethodPost, url, buffer, q, user)
 	if err != nil {
 		return "", err
 	}
@@ -366,8 +503,8 @@ func (client *serviceManagerClient) register(resource interface{}, url string, q
 	}
 }
 
-func (client *serviceManagerClient) delete(url string, q *Parameters, user string) (string, error) {
-	response, err := client.callWithUser(http.MethodDelete, url, nil, q, user)
+func (client *serviceManagerClient) delete(ctx context.Context, url string, q *Parameters, user string) (string, error) {
+	response, err := client.callWithUser(ctx, http.MethodDelete, url, nil, q, user)
 	if err != nil {
 		return "", err
 	}
@@ -389,8 +526,8 @@ func (client *serviceManagerClient) delete(url string, q *Parameters, user strin
 	}
 }
 
-func (client *serviceManagerClient) get(result interface{}, url string, q *Parameters) error {
-	response, err := client.Call(http.MethodGet, url, nil, q)
+func (client *serviceManagerClient) get(ctx context.Context, result interface{}, url string, q *Parameters) error {
+	response, err := client.Call(ctx, http.MethodGet, url, nil, q)
 	if err != nil {
 		return err
 	}
@@ -401,13 +538,13 @@ func (client *serviceManagerClient) get(result interface{}, url string, q *Param
 	return httputil.UnmarshalResponse(response, &result)
 }
 
-func (client *serviceManagerClient) update(resource interface{}, url string, id string, q *Parameters, user string, result interface{}) (string, error) {
+func (client *serviceManagerClient) update(ctx context.Context, resource interface{}, url string, id string, q *Parameters, user string, result interface{}) (string, error) {
 	requestBody, err := json.Marshal(resource)
 	if err != nil {
 		return "", err
 	}
 	buffer := bytes.NewBuffer(requestBody)
-	response, err := client.callWithUser(http.MethodPatch, url+"/"+id, buffer, q, user)
+	response, err := client.callWithUser(ctx, http.MethodPatch, url+"/"+id, buffer, q, user)
 	if err != nil {
 		return "", err
 	}
@@ -422,15 +559,35 @@ func (client *serviceManagerClient) update(resource interface{}, url string, id
 	}
 }
 
-func (client *serviceManagerClient) ShareInstance(id string, user string) error {
-	return client.executeShareInstanceRequest(true, id, user)
+func (client *serviceManagerClient) ShareInstance(ctx context.Context, id string, user string) error {
+	ctx, cancel := client.withOperation(ctx, OperationShareInstance)
+	defer cancel()
+
+	return client.executeShareInstanceRequest(ctx, true, id, user)
 }
 
-func (client *serviceManagerClient) UnShareInstance(id string, user string) error {
-	return client.executeShareInstanceRequest(false, id, user)
+func (client *serviceManagerClient) UnShareInstance(ctx context.Context, id string, user string) error {
+	ctx, cancel := client.withOperation(ctx, OperationUnShareInstance)
+	defer cancel()
+
+	return client.executeShareInstanceRequest(ctx, false, id, user)
 }
 
-func (client *serviceManagerClient) executeShareInstanceRequest(shouldShare bool, id string, user string) error {
+func (client *serviceManagerClient) UpdateInstanceLabels(ctx context.Context, id string, changes []*types.LabelChange) (*types.ServiceInstance, error) {
+	ctx, cancel := client.withOperation(ctx, OperationInstanceLabels)
+	defer cancel()
+
+	labelsRequest := map[string]interface{}{
+		"labels": changes,
+	}
+	var result *types.ServiceInstance
+	if _, err := client.update(ctx, labelsRequest, types.ServiceInstancesURL, id, nil, "", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (client *serviceManagerClient) executeShareInstanceRequest(ctx context.Context, shouldShare bool, id string, user string) error {
 	bodyRequest := map[string]interface{}{
 		"shared": shouldShare,
 	}
@@ -441,57 +598,161 @@ func (client *serviceManagerClient) executeShareInstanceRequest(shouldShare bool
 
 	buffer := bytes.NewBuffer(shareBody)
 
-	response, err := client.callWithUser(http.MethodPatch, types.ServiceInstancesURL+"/"+id, buffer, nil, user)
+	response, err := client.callWithUser(ctx, http.MethodPatch, types.ServiceInstancesURL+"/"+id, buffer, nil, user)
+	if err != nil {
+		return err
+	}
 	if response.StatusCode != http.StatusOK {
-		if err == nil {
-			return handleResponseError(response)
-		}
-		return httputil.UnmarshalResponse(response, err)
+		return handleResponseError(response)
 	}
 
 	return nil
 }
 
-func (client *serviceManagerClient) Call(method string, smpath string, body io.Reader, q *Parameters) (*http.Response, error) {
-	return client.callWithUser(method, smpath, body, q, "")
+func (client *serviceManagerClient) Call(ctx context.Context, method string, smpath string, body io.Reader, q *Parameters) (*http.Response, error) {
+	return client.callWithUser(ctx, method, smpath, body, q, "")
+}
+
+func (client *serviceManagerClient) callWithUser(ctx context.Context, method string, smpath string, body io.Reader, q *Parameters, user string) (*http.Response, error) {
+	ctx, span := startRequestSpan(ctx, method, smpath)
+	start := time.Now()
+	resp, err := client.callWithRetries(ctx, method, smpath, body, q, user)
+	observeRequest(ctx, resp, err, time.Since(start))
+	endRequestSpan(span, resp, err)
+	return resp, err
 }
 
-func (client *serviceManagerClient) callWithUser(method string, smpath string, body io.Reader, q *Parameters, user string) (*http.Response, error) {
+func (client *serviceManagerClient) callWithRetries(ctx context.Context, method string, smpath string, body io.Reader, q *Parameters, user string) (*http.Response, error) {
 	fullURL := httputil.NormalizeURL(client.Config.URL) + BuildURL(smpath, q)
 
-	req, err := http.NewRequest(method, fullURL, body)
+	attempts := 1
+	if method == http.MethodGet {
+		// only idempotent calls without a body are retried
+		attempts += client.Config.RetryPolicy.MaxRetries
+	}
+
+	breaker := client.Config.CircuitBreaker
+	if breaker != nil {
+		if err := breaker.Allow(client.Config.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := client.do(ctx, method, fullURL, body, user)
+		transient := isTransientFailure(ctx, resp, err)
+		if attempt >= attempts || !transient {
+			if breaker != nil {
+				client.recordOutcome(breaker, resp, err, transient)
+			}
+			return resp, err
+		}
+
+		delay := client.Config.RetryPolicy.backoff(attempt, resp, time.Now())
+		if err != nil {
+			logutils.GetLogger(ctx).Info(fmt.Sprintf("%s %s failed with %s, retrying in %s", method, smpath, err.Error(), delay))
+		} else {
+			logutils.GetLogger(ctx).Info(fmt.Sprintf("%s %s returned %d, retrying in %s", method, smpath, resp.StatusCode, delay))
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if breaker != nil {
+				client.recordOutcome(breaker, nil, ctx.Err(), false)
+			}
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// recordOutcome reports the result of a call to the circuit breaker. Calls that ran into a timeout count as failures,
+// a hanging Service Manager has to open the circuit as well. Calls that ended without a response for other reasons,
+// e.g. canceled by the caller, say nothing about the availability of Service Manager.
+func (client *serviceManagerClient) recordOutcome(breaker *CircuitBreaker, resp *http.Response, err error, transient bool) {
+	switch {
+	case transient || isTimeout(err):
+		breaker.recordFailure(client.Config.URL)
+	case resp != nil:
+		breaker.recordSuccess(client.Config.URL)
+	default:
+		breaker.releaseProbe(client.Config.URL)
+	}
+}
+
+func (client *serviceManagerClient) do(ctx context.Context, method string, fullURL string, body io.Reader, user string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("client-name", "sap-btp-service-operator")
 	req.Header.Add("client-version", AppVersion)
-	correlationID := logutils.GetCorrelationID(client.Context)
+	correlationID := logutils.GetCorrelationID(ctx)
 	if correlationID != "" {
 		req.Header.Add("X-CorrelationID", correlationID)
 	}
 	if len(user) > 0 {
 		req.Header.Add(originatingIdentityHeader, user)
 	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	if limiter := client.Config.RateLimiter; limiter != nil {
+		if err := limiter.Wait(ctx, client.Config.Scope()); err != nil {
+			return nil, err
+		}
+	}
 
 	resp, err := client.HTTPClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests && client.Config.RateLimiter != nil {
+		if retryAfter, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			client.Config.RateLimiter.Pause(client.Config.Scope(), retryAfter)
+		}
+	}
+
 	return resp, nil
 }
 
-func (client *serviceManagerClient) getPlanInfo(planID string, serviceName string, planName string, dataCenter string) (*planInfo, error) {
+// withOperation derives a context bounded by the deadline configured for the given operation and tagged with its name.
+// Calls are only bounded by the parent context when no deadline is configured.
+func (client *serviceManagerClient) withOperation(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, operationKey{}, operation)
+	timeout := client.Config.GetTimeout(operation)
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (client *serviceManagerClient) getPlanInfo(ctx context.Context, planID string, serviceName string, planName string, dataCenter string) (*planInfo, error) {
+	info, err := client.resolvePlanInfo(ctx, planID, serviceName, planName, dataCenter)
+	var lookupErr *catalogLookupError
+	if errors.As(err, &lookupErr) && client.Config.CatalogCache != nil {
+		// the cached catalog may be outdated (e.g. a newly entitled plan), retry once with fresh data
+		client.Config.CatalogCache.Invalidate(client.Config.Scope())
+		info, err = client.resolvePlanInfo(ctx, planID, serviceName, planName, dataCenter)
+	}
+	return info, err
+}
+
+func (client *serviceManagerClient) resolvePlanInfo(ctx context.Context, planID string, serviceName string, planName string, dataCenter string) (*planInfo, error) {
 
-	offerings, err := client.getServiceOfferingsByNameAndDataCenter(serviceName, dataCenter)
+	offerings, err := client.getServiceOfferingsByNameAndDataCenter(ctx, serviceName, dataCenter)
 	if err != nil {
 		return nil, err
 	}
 
 	var commaSepOfferingIDs string
 	if len(offerings.ServiceOfferings) == 0 {
-		return nil, fmt.Errorf("couldn't find the service offering '%s' on dataCenter '%s'", serviceName, dataCenter)
+		return nil, newCatalogLookupError("couldn't find the service offering '%s' on dataCenter '%s'", serviceName, dataCenter)
 	}
 
 	serviceOfferingIDs := make([]string, 0, len(offerings.ServiceOfferings))
@@ -505,46 +766,61 @@ func (client *serviceManagerClient) getPlanInfo(planID string, serviceName strin
 		FieldQuery: []string{fmt.Sprintf("catalog_name eq '%s'", planName), fmt.Sprintf("service_offering_id in (%s)", commaSepOfferingIDs)},
 	}
 
-	plans, err := client.ListPlans(query)
+	plans, err := client.ListPlans(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 	if len(plans.ServicePlans) == 0 {
-		return nil, fmt.Errorf("couldn't find the service plan '%s' for the service offering '%s'", planName, serviceName)
+		return nil, newCatalogLookupError("couldn't find the service plan '%s' for the service offering '%s'", planName, serviceName)
 	} else if len(plans.ServicePlans) == 1 && len(planID) == 0 {
 		return &planInfo{
 			planID:          plans.ServicePlans[0].ID,
+			plan:            &plans.ServicePlans[0],
 			serviceOffering: findOffering(plans.ServicePlans[0].ServiceOfferingID, offerings),
 		}, nil
 	}
-	for _, plan := range plans.ServicePlans {
+	for i, plan := range plans.ServicePlans {
 		if plan.ID == planID {
 			return &planInfo{
 				planID:          plan.ID,
+				plan:            &plans.ServicePlans[i],
 				serviceOffering: findOffering(plan.ServiceOfferingID, offerings),
 			}, nil
 		}
 	}
 
 	if len(planID) > 0 {
-		err = fmt.Errorf("the provided plan ID '%s' doesn't match the provided offering name '%s' and plan name '%s'", planID, serviceName, planName)
+		err = newCatalogLookupError("the provided plan ID '%s' doesn't match the provided offering name '%s' and plan name '%s'", planID, serviceName, planName)
 	} else {
-		err = fmt.Errorf("ambiguity error: found more than one resource that matches the provided offering name '%s' and plan name '%s'. Please provide servicePlanID", serviceName, planName)
+		err = newCatalogLookupError("ambiguity error: found more than one resource that matches the provided offering name '%s' and plan name '%s'. Please provide servicePlanID", serviceName, planName)
 	}
 	return nil, err
 }
 
-func (client *serviceManagerClient) getServiceOfferingsByNameAndDataCenter(serviceName string, dataCenter string) (*types.ServiceOfferings, error) {
+func (client *serviceManagerClient) getServiceOfferingsByNameAndDataCenter(ctx context.Context, serviceName string, dataCenter string) (*types.ServiceOfferings, error) {
 	query := &Parameters{
 		FieldQuery: []string{fmt.Sprintf("catalog_name eq '%s' and data_center eq '%s'", serviceName, dataCenter)},
 	}
-	offerings, err := client.ListOfferings(query)
+	offerings, err := client.ListOfferings(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 	return offerings, nil
 }
 
+// catalogLookupError indicates that the requested offering or plan could not be resolved from the catalog
+type catalogLookupError struct {
+	message string
+}
+
+func newCatalogLookupError(format string, args ...interface{}) error {
+	return &catalogLookupError{message: fmt.Sprintf(format, args...)}
+}
+
+func (e *catalogLookupError) Error() string {
+	return e.message
+}
+
 func findOffering(id string, offerings *types.ServiceOfferings) *types.ServiceOffering {
 	for _, off := range offerings.ServiceOfferings {
 		off := off
@@ -648,7 +924,7 @@ type listResponse struct {
 	Items    interface{} `json:"items"`
 }
 
-func (li *listIterator) nextPage(items interface{}, maxItems int) (more bool, count int64, err error) {
+func (li *listIterator) nextPage(ctx context.Context, items interface{}, maxItems int) (more bool, count int64, err error) {
 	itemsType := reflect.TypeOf(items)
 	if itemsType != nil && (itemsType.Kind() != reflect.Ptr || itemsType.Elem().Kind() != reflect.Slice) {
 		return false, -1, fmt.Errorf("items should be nil or a pointer to a slice, but got %v", itemsType)
@@ -670,9 +946,9 @@ func (li *listIterator) nextPage(items interface{}, maxItems int) (more bool, co
 
 	method := http.MethodGet
 	url := li.URL
-	response, err := li.Call(method, url, nil, li.Params)
+	response, err := li.Call(ctx, method, url, nil, li.Params)
 	if err != nil {
-		return false, -1, fmt.Errorf("error sending request %s %s: %s", method, url, err)
+		return false, -1, fmt.Errorf("error sending request %s %s: %w", method, url, err)
 	}
 	if response.Request != nil {
 		url = response.Request.URL.String() // should include also the query params
@@ -16,6 +16,8 @@
 
 package sm
 
+import "time"
+
 // ClientConfig contains the configuration of the Service Manager client
 type ClientConfig struct {
 	URL            string
@@ -26,6 +28,19 @@ type ClientConfig struct {
 	TLSCertKey     string
 	TLSPrivateKey  string
 	SSLDisabled    bool
+
+	// RequestTimeout is the default deadline of a single client operation, zero means no deadline
+	RequestTimeout time.Duration
+	// OperationTimeouts overrides RequestTimeout for specific operations, keyed by operation name (e.g. "provision")
+	OperationTimeouts map[string]time.Duration
+	// CatalogCache is used to cache offering and plan queries, nil disables caching
+	CatalogCache *CatalogCache
+	// RateLimiter throttles the calls to Service Manager, nil disables client side rate limiting
+	RateLimiter *RateLimiter
+	// RetryPolicy configures retries of idempotent calls on transient failures
+	RetryPolicy RetryPolicy
+	// CircuitBreaker suspends calls to an unavailable Service Manager, nil disables it
+	CircuitBreaker *CircuitBreaker
 }
 
 func (c ClientConfig) IsValid() bool {
@@ -38,3 +53,16 @@ func (c ClientConfig) IsValid() bool {
 
 	return true
 }
+
+// GetTimeout returns the deadline configured for the given operation
+func (c ClientConfig) GetTimeout(operation string) time.Duration {
+	if timeout, ok := c.OperationTimeouts[operation]; ok {
+		return timeout
+	}
+	return c.RequestTimeout
+}
+
+// Scope identifies the Service Manager subaccount the configuration authenticates to
+func (c ClientConfig) Scope() string {
+	return c.URL + "|" + c.ClientID
+}
@@ -3,7 +3,9 @@ package sm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/SAP/sap-btp-service-operator/client/sm/types"
 	. "github.com/onsi/ginkgo"
@@ -41,7 +43,7 @@ var _ = Describe("Client test", func() {
 		Describe("List service instances", func() {
 			Context("When there are service instances registered", func() {
 				It("should return all", func() {
-					result, err := client.ListInstances(params)
+					result, err := client.ListInstances(context.TODO(), params)
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(result.ServiceInstances).To(HaveLen(1))
 					Expect(result.ServiceInstances[0]).To(Equal(*instance))
@@ -59,7 +61,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should return an empty array", func() {
-					result, err := client.ListInstances(params)
+					result, err := client.ListInstances(context.TODO(), params)
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(result.ServiceInstances).To(HaveLen(0))
 				})
@@ -72,7 +74,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should handle status code != 200", func() {
-					_, err := client.ListInstances(params)
+					_, err := client.ListInstances(context.TODO(), params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusCreated)
 				})
 			})
@@ -84,7 +86,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should handle status code > 299", func() {
-					_, err := client.ListInstances(params)
+					_, err := client.ListInstances(context.TODO(), params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusBadRequest)
 				})
 			})
@@ -99,7 +101,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should return it", func() {
-					result, err := client.GetInstanceByID(instance.ID, params)
+					result, err := client.GetInstanceByID(context.TODO(), instance.ID, params)
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(result).To(Equal(instance))
 				})
@@ -112,7 +114,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should return 404", func() {
-					_, err := client.GetInstanceByID(instance.ID, params)
+					_, err := client.GetInstanceByID(context.TODO(), instance.ID, params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusNotFound)
 				})
 			})
@@ -124,7 +126,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should handle status code != 200", func() {
-					_, err := client.GetInstanceByID(instance.ID, params)
+					_, err := client.GetInstanceByID(context.TODO(), instance.ID, params)
 					Expect(err).Should(HaveOccurred())
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusCreated)
 				})
@@ -138,7 +140,62 @@ var _ = Describe("Client test", func() {
 				})
 
 				It("should handle status code > 299", func() {
-					_, err := client.GetInstanceByID(instance.ID, params)
+					_, err := client.GetInstanceByID(context.TODO(), instance.ID, params)
+					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusBadRequest)
+				})
+			})
+
+			Context("When the operation deadline is exceeded", func() {
+				BeforeEach(func() {
+					responseBody, _ := json.Marshal(instance)
+					handlerDetails = []HandlerDetails{
+						{Method: http.MethodGet, Path: types.ServiceInstancesURL + "/", ResponseBody: responseBody, ResponseStatusCode: http.StatusOK, Delay: 200 * time.Millisecond},
+					}
+				})
+
+				It("should fail with deadline exceeded", func() {
+					var err error
+					client, err = NewClient(context.TODO(), &ClientConfig{
+						URL:               smServer.URL,
+						OperationTimeouts: map[string]time.Duration{OperationGetInstance: 50 * time.Millisecond},
+					}, fakeAuthClient)
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = client.GetInstanceByID(context.TODO(), instance.ID, params)
+					Expect(err).To(HaveOccurred())
+					Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+				})
+
+				It("should succeed when the deadline is not configured for the operation", func() {
+					result, err := client.GetInstanceByID(context.TODO(), instance.ID, params)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(result).To(Equal(instance))
+				})
+			})
+		})
+
+		Describe("Get service instance parameters", func() {
+			Context("When the instance parameters are retrievable", func() {
+				BeforeEach(func() {
+					handlerDetails = []HandlerDetails{
+						{Method: http.MethodGet, Path: types.ServiceInstancesURL + "/" + instance.ID + "/parameters", ResponseBody: []byte(`{"key":"value","nested":{"count":1}}`), ResponseStatusCode: http.StatusOK},
+					}
+				})
+				It("should return them", func() {
+					result, err := client.GetInstanceParameters(context.TODO(), instance.ID, params)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(result).To(Equal(map[string]interface{}{"key": "value", "nested": map[string]interface{}{"count": float64(1)}}))
+				})
+			})
+
+			Context("When the instance parameters are not retrievable", func() {
+				BeforeEach(func() {
+					handlerDetails = []HandlerDetails{
+						{Method: http.MethodGet, Path: types.ServiceInstancesURL + "/" + instance.ID + "/parameters", ResponseStatusCode: http.StatusBadRequest},
+					}
+				})
+				It("should return an error", func() {
+					_, err := client.GetInstanceParameters(context.TODO(), instance.ID, params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusBadRequest)
 				})
 			})
@@ -173,9 +230,48 @@ var _ = Describe("Client test", func() {
 				}
 			})
 
+			Context("When catalog cache is configured", func() {
+				It("should resolve the plan from the cache on subsequent calls", func() {
+					var err error
+					cache := NewCatalogCache(time.Minute)
+					client, err = NewClient(context.TODO(), &ClientConfig{URL: smServer.URL, CatalogCache: cache}, fakeAuthClient)
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = client.Provision(context.TODO(), instance, serviceName, planName, params, "test-user", "")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(fakeAuthClient.requests).To(Equal(3))
+
+					_, err = client.Provision(context.TODO(), instance, serviceName, planName, params, "test-user", "")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(fakeAuthClient.requests).To(Equal(4))
+				})
+
+				It("should refresh the cache when the plan cannot be resolved from it", func() {
+					var err error
+					cache := NewCatalogCache(time.Minute)
+					client, err = NewClient(context.TODO(), &ClientConfig{URL: smServer.URL, CatalogCache: cache}, fakeAuthClient)
+					Expect(err).ToNot(HaveOccurred())
+
+					instance.ServicePlanID = "unknown-plan-id"
+					_, err = client.Provision(context.TODO(), instance, serviceName, planName, params, "test-user", "")
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("doesn't match the provided offering name"))
+					Expect(fakeAuthClient.requests).To(Equal(4))
+				})
+			})
+
+			Context("When the plan is resolved without provisioning", func() {
+				It("should return the plan and its offering", func() {
+					plan, offering, err := client.GetPlan(context.TODO(), "", serviceName, planName, "")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(plan.ID).To(Equal(planID))
+					Expect(offering.Name).To(Equal(serviceName))
+				})
+			})
+
 			Context("When valid instance is being provisioned synchronously", func() {
 				It("should provision successfully", func() {
-					res, err := client.Provision(instance, serviceName, planName, params, "test-user", "")
+					res, err := client.Provision(context.TODO(), instance, serviceName, planName, params, "test-user", "")
 
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(res.Location).Should(HaveLen(0))
@@ -204,7 +300,7 @@ var _ = Describe("Client test", func() {
 					})
 
 					It("should provision successfully", func() {
-						res, err := client.Provision(instance, "mongo", "small", params, "test-user", "")
+						res, err := client.Provision(context.TODO(), instance, "mongo", "small", params, "test-user", "")
 						Expect(err).ShouldNot(HaveOccurred())
 						Expect(res.Location).Should(HaveLen(0))
 						Expect(res.InstanceID).To(Equal(instance.ID))
@@ -214,7 +310,7 @@ var _ = Describe("Client test", func() {
 				Context("When no plan id provided", func() {
 					It("should provision successfully", func() {
 						instance.ServicePlanID = ""
-						res, err := client.Provision(instance, "mongo", "small", params, "test-user", "")
+						res, err := client.Provision(context.TODO(), instance, "mongo", "small", params, "test-user", "")
 						Expect(err).ShouldNot(HaveOccurred())
 						Expect(res.Location).Should(HaveLen(0))
 						Expect(res.InstanceID).To(Equal(instance.ID))
@@ -225,7 +321,7 @@ var _ = Describe("Client test", func() {
 			Context("When invalid instance is being provisioned synchronously", func() {
 				When("No service name", func() {
 					It("should fail to provision", func() {
-						res, err := client.Provision(instance, "", "small", params, "test-user", "")
+						res, err := client.Provision(context.TODO(), instance, "", "small", params, "test-user", "")
 						Expect(err).Should(HaveOccurred())
 						Expect(res).To(BeNil())
 					})
@@ -233,7 +329,7 @@ var _ = Describe("Client test", func() {
 
 				When("No plan name", func() {
 					It("should fail to provision", func() {
-						res, err := client.Provision(instance, "mongo", "", params, "test-user", "")
+						res, err := client.Provision(context.TODO(), instance, "mongo", "", params, "test-user", "")
 						Expect(err).Should(HaveOccurred())
 						Expect(res).To(BeNil())
 					})
@@ -242,7 +338,7 @@ var _ = Describe("Client test", func() {
 				When("Plan id not match plan name", func() {
 					It("should fail", func() {
 						instance.ServicePlanID = "some-id"
-						res, err := client.Provision(instance, "mongo", "small", params, "test-user", "")
+						res, err := client.Provision(context.TODO(), instance, "mongo", "small", params, "test-user", "")
 						Expect(err).Should(HaveOccurred())
 						Expect(res).To(BeNil())
 					})
@@ -256,7 +352,7 @@ var _ = Describe("Client test", func() {
 						handlerDetails[1] = HandlerDetails{Method: http.MethodGet, Path: types.ServiceOfferingsURL, ResponseBody: responseBody, ResponseStatusCode: http.StatusOK}
 					})
 					It("should fail", func() {
-						res, err := client.Provision(instance, "mongo2", "small", params, "test-user", "")
+						res, err := client.Provision(context.TODO(), instance, "mongo2", "small", params, "test-user", "")
 						Expect(err).Should(HaveOccurred())
 						Expect(res).To(BeNil())
 					})
@@ -270,7 +366,7 @@ var _ = Describe("Client test", func() {
 					handlerDetails[0] = HandlerDetails{Method: http.MethodPost, Path: types.ServiceInstancesURL, ResponseStatusCode: http.StatusAccepted, Headers: map[string]string{"Location": locationHeader}}
 				})
 				It("should receive operation location", func() {
-					res, err := client.Provision(instance, serviceName, planName, params, "test-user", "")
+					res, err := client.Provision(context.TODO(), instance, serviceName, planName, params, "test-user", "")
 
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(res.Location).Should(Equal(locationHeader))
@@ -289,7 +385,7 @@ var _ = Describe("Client test", func() {
 
 				})
 				It("should return error", func() {
-					res, err := client.Provision(instance, serviceName, planName, params, "test-user", "")
+					res, err := client.Provision(context.TODO(), instance, serviceName, planName, params, "test-user", "")
 
 					Expect(err).Should(HaveOccurred())
 					Expect(res).To(BeNil())
@@ -303,7 +399,7 @@ var _ = Describe("Client test", func() {
 						handlerDetails[0] = HandlerDetails{Method: http.MethodPost, Path: types.ServiceInstancesURL, ResponseBody: responseBody, ResponseStatusCode: http.StatusOK}
 					})
 					It("should return error with status code", func() {
-						res, err := client.Provision(instance, serviceName, planName, params, "test-user", "")
+						res, err := client.Provision(context.TODO(), instance, serviceName, planName, params, "test-user", "")
 						expectErrorToContainSubstringAndStatusCode(err, "", http.StatusOK)
 						Expect(res).To(BeNil())
 					})
@@ -316,7 +412,7 @@ var _ = Describe("Client test", func() {
 
 					})
 					It("should return error with url and description", func() {
-						res, err := client.Provision(instance, serviceName, planName, params, "test-user", "")
+						res, err := client.Provision(context.TODO(), instance, serviceName, planName, params, "test-user", "")
 						expectErrorToContainSubstringAndStatusCode(err, "description", http.StatusBadRequest)
 						Expect(res).To(BeNil())
 					})
@@ -329,7 +425,7 @@ var _ = Describe("Client test", func() {
 
 					})
 					It("should return error without url and description if invalid response body", func() {
-						res, err := client.Provision(instance, serviceName, planName, params, "test-user", "")
+						res, err := client.Provision(context.TODO(), instance, serviceName, planName, params, "test-user", "")
 						expectErrorToContainSubstringAndStatusCode(err, "", http.StatusBadRequest)
 						Expect(res).To(BeNil())
 					})
@@ -348,7 +444,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should be successfully removed", func() {
-					location, err := client.Deprovision(instance.ID, params, "test-user")
+					location, err := client.Deprovision(context.TODO(), instance.ID, params, "test-user")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(location).Should(BeEmpty())
 				})
@@ -363,7 +459,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should be successfully removed", func() {
-					location, err := client.Deprovision(instance.ID, params, "test-user")
+					location, err := client.Deprovision(context.TODO(), instance.ID, params, "test-user")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(location).Should(Equal(locationHeader))
 				})
@@ -378,7 +474,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should handle error", func() {
-					location, err := client.Deprovision(instance.ID, params, "test-user")
+					location, err := client.Deprovision(context.TODO(), instance.ID, params, "test-user")
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusCreated)
 					Expect(location).Should(BeEmpty())
 				})
@@ -393,7 +489,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should be considered as success", func() {
-					location, err := client.Deprovision(instance.ID, params, "test-user")
+					location, err := client.Deprovision(context.TODO(), instance.ID, params, "test-user")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(location).Should(BeEmpty())
 				})
@@ -432,7 +528,7 @@ var _ = Describe("Client test", func() {
 						HandlerDetails{Method: http.MethodPatch, Path: types.ServiceInstancesURL + "/" + instance.ID, ResponseBody: responseBody, ResponseStatusCode: http.StatusOK})
 				})
 				It("should update successfully", func() {
-					responseInstance, location, err := client.UpdateInstance(instance.ID, instance, serviceName, planName, params, "test-user", "")
+					responseInstance, location, err := client.UpdateInstance(context.TODO(), instance.ID, instance, serviceName, planName, params, "test-user", "")
 
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(location).Should(HaveLen(0))
@@ -448,7 +544,7 @@ var _ = Describe("Client test", func() {
 						HandlerDetails{Method: http.MethodPatch, Path: types.ServiceInstancesURL + "/" + instance.ID, ResponseStatusCode: http.StatusAccepted, Headers: map[string]string{"Location": locationHeader}})
 				})
 				It("should receive operation location", func() {
-					responseInstance, location, err := client.UpdateInstance(instance.ID, instance, serviceName, planName, params, "test-user", "")
+					responseInstance, location, err := client.UpdateInstance(context.TODO(), instance.ID, instance, serviceName, planName, params, "test-user", "")
 
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(location).Should(Equal(locationHeader))
@@ -467,7 +563,7 @@ var _ = Describe("Client test", func() {
 						HandlerDetails{Method: http.MethodPatch, Path: types.ServiceInstancesURL + "/" + instance.ID, ResponseBody: responseBody, ResponseStatusCode: http.StatusOK})
 				})
 				It("should return error", func() {
-					responseInstance, location, err := client.UpdateInstance(instance.ID, instance, serviceName, planName, params, "test-user", "")
+					responseInstance, location, err := client.UpdateInstance(context.TODO(), instance.ID, instance, serviceName, planName, params, "test-user", "")
 
 					Expect(err).Should(HaveOccurred())
 					Expect(location).Should(BeEmpty())
@@ -483,7 +579,7 @@ var _ = Describe("Client test", func() {
 							HandlerDetails{Method: http.MethodPatch, Path: types.ServiceInstancesURL + "/" + instance.ID, ResponseBody: responseBody, ResponseStatusCode: http.StatusTeapot})
 					})
 					It("should return error with status code", func() {
-						responseInstance, location, err := client.UpdateInstance(instance.ID, instance, serviceName, planName, params, "test-user", "")
+						responseInstance, location, err := client.UpdateInstance(context.TODO(), instance.ID, instance, serviceName, planName, params, "test-user", "")
 						expectErrorToContainSubstringAndStatusCode(err, "", http.StatusTeapot)
 						Expect(location).Should(BeEmpty())
 						Expect(responseInstance).To(BeNil())
@@ -497,7 +593,7 @@ var _ = Describe("Client test", func() {
 							HandlerDetails{Method: http.MethodPatch, Path: types.ServiceInstancesURL + "/" + instance.ID, ResponseBody: responseBody, ResponseStatusCode: http.StatusBadRequest})
 					})
 					It("should return error with url and description", func() {
-						responseInstance, location, err := client.UpdateInstance(instance.ID, instance, serviceName, planName, params, "test-user", "")
+						responseInstance, location, err := client.UpdateInstance(context.TODO(), instance.ID, instance, serviceName, planName, params, "test-user", "")
 						expectErrorToContainSubstringAndStatusCode(err, "description", http.StatusBadRequest)
 						Expect(location).Should(BeEmpty())
 						Expect(responseInstance).To(BeNil())
@@ -511,7 +607,7 @@ var _ = Describe("Client test", func() {
 							HandlerDetails{Method: http.MethodPatch, Path: types.ServiceInstancesURL + "/" + instance.ID, ResponseBody: responseBody, ResponseStatusCode: http.StatusBadRequest})
 					})
 					It("should return error without url and description if invalid response body", func() {
-						responseInstance, location, err := client.UpdateInstance(instance.ID, instance, serviceName, planName, params, "test-user", "")
+						responseInstance, location, err := client.UpdateInstance(context.TODO(), instance.ID, instance, serviceName, planName, params, "test-user", "")
 						expectErrorToContainSubstringAndStatusCode(err, "description", http.StatusBadRequest)
 						Expect(responseInstance).To(BeNil())
 						Expect(location).Should(BeEmpty())
@@ -525,7 +621,7 @@ var _ = Describe("Client test", func() {
 					var err error
 					client, err = NewClient(context.TODO(), &ClientConfig{URL: "invalidURL"}, fakeAuthClient)
 					Expect(err).ToNot(HaveOccurred())
-					_, location, err := client.UpdateInstance(instance.ID, instance, serviceName, planName, params, "test-user", "")
+					_, location, err := client.UpdateInstance(context.TODO(), instance.ID, instance, serviceName, planName, params, "test-user", "")
 
 					Expect(err).Should(HaveOccurred())
 					Expect(location).Should(BeEmpty())
@@ -542,14 +638,14 @@ var _ = Describe("Client test", func() {
 			})
 			When("When valid instance is being shared", func() {
 				It("should be shared successfully", func() {
-					err := client.ShareInstance(instance.ID, "test-user")
+					err := client.ShareInstance(context.TODO(), instance.ID, "test-user")
 					Expect(err).ShouldNot(HaveOccurred())
 				})
 			})
 
 			When("When instance is being unshared", func() {
 				It("should be unshared successfully", func() {
-					err := client.UnShareInstance(instance.ID, "test-user")
+					err := client.UnShareInstance(context.TODO(), instance.ID, "test-user")
 					Expect(err).ShouldNot(HaveOccurred())
 				})
 			})
@@ -562,11 +658,41 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("returns error", func() {
-					err := client.UnShareInstance(instance.ID, "test-user")
+					err := client.UnShareInstance(context.TODO(), instance.ID, "test-user")
 					Expect(err).Should(HaveOccurred())
 				})
 			})
 		})
+
+		Describe("Update instance labels", func() {
+			var changes []*types.LabelChange
+
+			BeforeEach(func() {
+				changes = []*types.LabelChange{{Operation: types.AddLabelOperation, Key: "_k8sname", Values: []string{"name"}}}
+				responseBody, _ := json.Marshal(instance)
+				handlerDetails = []HandlerDetails{
+					{Method: http.MethodPatch, Path: types.ServiceInstancesURL + "/" + instance.ID, ResponseBody: responseBody, ResponseStatusCode: http.StatusOK},
+				}
+			})
+
+			It("should update the labels", func() {
+				result, err := client.UpdateInstanceLabels(context.TODO(), instance.ID, changes)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).To(Equal(instance))
+			})
+
+			When("the labels can't be updated", func() {
+				BeforeEach(func() {
+					handlerDetails = []HandlerDetails{
+						{Method: http.MethodPatch, Path: types.ServiceInstancesURL + "/" + instance.ID, ResponseStatusCode: http.StatusBadRequest},
+					}
+				})
+				It("returns error", func() {
+					_, err := client.UpdateInstanceLabels(context.TODO(), instance.ID, changes)
+					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusBadRequest)
+				})
+			})
+		})
 	})
 
 	Describe("Bindings", func() {
@@ -603,7 +729,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should return all", func() {
-					result, err := client.ListBindings(params)
+					result, err := client.ListBindings(context.TODO(), params)
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(result.ServiceBindings).To(HaveLen(1))
 					Expect(result.ServiceBindings[0]).To(Equal(*binding))
@@ -621,7 +747,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should return an empty array", func() {
-					result, err := client.ListBindings(params)
+					result, err := client.ListBindings(context.TODO(), params)
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(result.ServiceBindings).To(HaveLen(0))
 				})
@@ -634,7 +760,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should handle status code != 200", func() {
-					_, err := client.ListBindings(params)
+					_, err := client.ListBindings(context.TODO(), params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusCreated)
 				})
 			})
@@ -646,7 +772,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should handle status code > 299", func() {
-					_, err := client.ListBindings(params)
+					_, err := client.ListBindings(context.TODO(), params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusBadRequest)
 				})
 			})
@@ -661,7 +787,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should return it", func() {
-					result, err := client.GetBindingByID(binding.ID, params)
+					result, err := client.GetBindingByID(context.TODO(), binding.ID, params)
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(result).To(Equal(binding))
 				})
@@ -674,7 +800,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should return 404", func() {
-					_, err := client.GetBindingByID(binding.ID, params)
+					_, err := client.GetBindingByID(context.TODO(), binding.ID, params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusNotFound)
 				})
 			})
@@ -686,7 +812,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should handle status code != 200", func() {
-					_, err := client.GetBindingByID(binding.ID, params)
+					_, err := client.GetBindingByID(context.TODO(), binding.ID, params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusCreated)
 				})
 			})
@@ -698,7 +824,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should handle status code > 299", func() {
-					_, err := client.GetBindingByID(binding.ID, params)
+					_, err := client.GetBindingByID(context.TODO(), binding.ID, params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusBadRequest)
 
 				})
@@ -714,7 +840,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should provision successfully", func() {
-					responseBinding, location, err := client.Bind(binding, params, "test-user")
+					responseBinding, location, err := client.Bind(context.TODO(), binding, params, "test-user")
 
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(location).Should(HaveLen(0))
@@ -731,7 +857,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should receive operation location", func() {
-					responseBinding, location, err := client.Bind(binding, params, "test-user")
+					responseBinding, location, err := client.Bind(context.TODO(), binding, params, "test-user")
 
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(location).Should(Equal(locationHeader))
@@ -751,7 +877,7 @@ var _ = Describe("Client test", func() {
 					}
 				})
 				It("should return error", func() {
-					responseBinding, location, err := client.Bind(binding, params, "test-user")
+					responseBinding, location, err := client.Bind(context.TODO(), binding, params, "test-user")
 
 					Expect(err).Should(HaveOccurred())
 					Expect(location).Should(BeEmpty())
@@ -768,7 +894,7 @@ var _ = Describe("Client test", func() {
 						}
 					})
 					It("should return error with status code", func() {
-						responseBinding, location, err := client.Bind(binding, params, "test-user")
+						responseBinding, location, err := client.Bind(context.TODO(), binding, params, "test-user")
 						expectErrorToContainSubstringAndStatusCode(err, "", http.StatusOK)
 						Expect(responseBinding).To(BeNil())
 						Expect(location).Should(BeEmpty())
@@ -783,7 +909,7 @@ var _ = Describe("Client test", func() {
 						}
 					})
 					It("should return error with url and description", func() {
-						responseBinding, location, err := client.Bind(binding, params, "test-user")
+						responseBinding, location, err := client.Bind(context.TODO(), binding, params, "test-user")
 						expectErrorToContainSubstringAndStatusCode(err, "description", http.StatusBadRequest)
 						Expect(responseBinding).To(BeNil())
 						Expect(location).Should(BeEmpty())
@@ -798,7 +924,7 @@ var _ = Describe("Client test", func() {
 						}
 					})
 					It("should return error without url and description if invalid response body", func() {
-						responseBinding, location, err := client.Bind(binding, params, "test-user")
+						responseBinding, location, err := client.Bind(context.TODO(), binding, params, "test-user")
 						expectErrorToContainSubstringAndStatusCode(err, "", http.StatusBadRequest)
 						Expect(responseBinding).To(BeNil())
 						Expect(location).Should(BeEmpty())
@@ -812,7 +938,7 @@ var _ = Describe("Client test", func() {
 					var err error
 					client, err = NewClient(context.TODO(), &ClientConfig{URL: "invalidURL"}, fakeAuthClient)
 					Expect(err).ToNot(HaveOccurred())
-					_, location, err := client.Bind(binding, params, "test-user")
+					_, location, err := client.Bind(context.TODO(), binding, params, "test-user")
 
 					Expect(err).Should(HaveOccurred())
 					Expect(location).Should(BeEmpty())
@@ -913,7 +1039,7 @@ TSTAhYWEQVZqRKYQMYGHpNlU
 					}
 				})
 				It("should be successfully removed", func() {
-					location, err := client.Unbind(binding.ID, params, "test-user")
+					location, err := client.Unbind(context.TODO(), binding.ID, params, "test-user")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(location).Should(BeEmpty())
 				})
@@ -928,7 +1054,7 @@ TSTAhYWEQVZqRKYQMYGHpNlU
 					}
 				})
 				It("should be successfully removed", func() {
-					location, err := client.Unbind(binding.ID, params, "test-user")
+					location, err := client.Unbind(context.TODO(), binding.ID, params, "test-user")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(location).Should(Equal(locationHeader))
 				})
@@ -943,7 +1069,7 @@ TSTAhYWEQVZqRKYQMYGHpNlU
 					}
 				})
 				It("should handle error", func() {
-					location, err := client.Unbind(binding.ID, params, "test-user")
+					location, err := client.Unbind(context.TODO(), binding.ID, params, "test-user")
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusCreated)
 					Expect(location).Should(BeEmpty())
 				})
@@ -958,7 +1084,7 @@ TSTAhYWEQVZqRKYQMYGHpNlU
 					}
 				})
 				It("should be considered as success", func() {
-					location, err := client.Unbind(binding.ID, params, "test-user")
+					location, err := client.Unbind(context.TODO(), binding.ID, params, "test-user")
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(location).Should(BeEmpty())
 				})
@@ -974,7 +1100,22 @@ TSTAhYWEQVZqRKYQMYGHpNlU
 			})
 
 			It("should rename binding", func() {
-				res, err := client.RenameBinding(binding.ID, "newname", "newk8sname")
+				res, err := client.RenameBinding(context.TODO(), binding.ID, "newname", "newk8sname")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.ID).To(Equal("bindingID"))
+			})
+		})
+
+		Describe("Update binding labels", func() {
+			BeforeEach(func() {
+				responseBody, _ := json.Marshal(binding)
+				handlerDetails = []HandlerDetails{
+					{Method: http.MethodPatch, Path: types.ServiceBindingsURL + "/" + binding.ID, ResponseBody: responseBody, ResponseStatusCode: http.StatusOK},
+				}
+			})
+
+			It("should update the labels", func() {
+				res, err := client.UpdateBindingLabels(context.TODO(), binding.ID, []*types.LabelChange{{Operation: types.RemoveLabelOperation, Key: "_clusterid"}})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(res.ID).To(Equal("bindingID"))
 			})
@@ -1002,7 +1143,7 @@ TSTAhYWEQVZqRKYQMYGHpNlU
 		})
 
 		It("should return the operation when exist", func() {
-			result, err := client.Status(types.ServiceInstancesURL+"/1234/"+operation.ID, types.CREATE, params)
+			result, err := client.Status(context.TODO(), types.ServiceInstancesURL+"/1234/"+operation.ID, types.CREATE, params)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(result).To(Equal(operation))
 		})
@@ -1015,7 +1156,7 @@ TSTAhYWEQVZqRKYQMYGHpNlU
 					}
 				})
 				It("should return succeeded operation", func() {
-					result, err := client.Status(types.ServiceInstancesURL+"/1234/"+operation.ID, types.DELETE, params)
+					result, err := client.Status(context.TODO(), types.ServiceInstancesURL+"/1234/"+operation.ID, types.DELETE, params)
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(result.State).To(Equal(types.SUCCEEDED))
 				})
@@ -1028,11 +1169,11 @@ TSTAhYWEQVZqRKYQMYGHpNlU
 					}
 				})
 				It("should fail for create", func() {
-					_, err := client.Status(types.ServiceInstancesURL+"/1234/"+operation.ID, types.CREATE, params)
+					_, err := client.Status(context.TODO(), types.ServiceInstancesURL+"/1234/"+operation.ID, types.CREATE, params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusNotFound)
 				})
 				It("should fail for update", func() {
-					_, err := client.Status(types.ServiceInstancesURL+"/1234/"+operation.ID, types.UPDATE, params)
+					_, err := client.Status(context.TODO(), types.ServiceInstancesURL+"/1234/"+operation.ID, types.UPDATE, params)
 					expectErrorToContainSubstringAndStatusCode(err, "", http.StatusNotFound)
 				})
 			})
@@ -0,0 +1,46 @@
+package sm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+)
+
+// operationCall labels calls issued through Call without a known operation
+const operationCall = "call"
+
+type operationKey struct{}
+
+func operationFromContext(ctx context.Context) string {
+	if operation, ok := ctx.Value(operationKey{}).(string); ok {
+		return operation
+	}
+	return operationCall
+}
+
+// observeRequest records the outcome of a call to Service Manager, including its retries
+func observeRequest(ctx context.Context, resp *http.Response, err error, duration time.Duration) {
+	code := "error"
+	async := "false"
+	if resp != nil {
+		code = strconv.Itoa(resp.StatusCode)
+		if resp.StatusCode == http.StatusAccepted {
+			async = "true"
+		}
+	} else {
+		var smError *ServiceManagerError
+		var unavailableErr *ServiceManagerUnavailableError
+		if errors.As(err, &smError) || errors.As(err, &unavailableErr) {
+			// the call was rejected by the client without reaching Service Manager
+			code = "rejected"
+		}
+	}
+
+	operation := operationFromContext(ctx)
+	metrics.SMRequests.WithLabelValues(operation, code, async).Inc()
+	metrics.SMRequestDuration.WithLabelValues(operation, code, async).Observe(duration.Seconds())
+}
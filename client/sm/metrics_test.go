@@ -0,0 +1,98 @@
+package sm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Metrics", func() {
+	var (
+		server *httptest.Server
+		status int
+	)
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if status == http.StatusAccepted {
+				w.Header().Set("Location", "/v1/service_instances/instanceID/operations/operationID")
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"id": "instanceID"}`))
+		}))
+		var err error
+		client, err = NewClient(context.TODO(), &ClientConfig{URL: server.URL}, http.DefaultClient)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("call succeeds", func() {
+		BeforeEach(func() {
+			status = http.StatusOK
+		})
+
+		It("records the operation and status code", func() {
+			counter := metrics.SMRequests.WithLabelValues(OperationGetInstance, "200", "false")
+			before := testutil.ToFloat64(counter)
+			_, err := client.GetInstanceByID(context.TODO(), "instanceID", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+		})
+	})
+
+	When("call is async", func() {
+		BeforeEach(func() {
+			status = http.StatusAccepted
+		})
+
+		It("records the async outcome", func() {
+			counter := metrics.SMRequests.WithLabelValues(OperationDeprovision, "202", "true")
+			before := testutil.ToFloat64(counter)
+			_, err := client.Deprovision(context.TODO(), "instanceID", nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+		})
+	})
+
+	When("call fails", func() {
+		BeforeEach(func() {
+			status = http.StatusBadRequest
+		})
+
+		It("records the failure status code", func() {
+			counter := metrics.SMRequests.WithLabelValues(OperationGetInstance, "400", "false")
+			before := testutil.ToFloat64(counter)
+			_, err := client.GetInstanceByID(context.TODO(), "instanceID", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+		})
+	})
+
+	When("call is rejected by the circuit breaker", func() {
+		BeforeEach(func() {
+			status = http.StatusOK
+		})
+
+		It("records the call as rejected", func() {
+			breaker := NewCircuitBreaker(1, time.Minute)
+			breaker.recordFailure(server.URL)
+			smClient, err := NewClient(context.TODO(), &ClientConfig{URL: server.URL, CircuitBreaker: breaker}, http.DefaultClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			counter := metrics.SMRequests.WithLabelValues(OperationGetInstance, "rejected", "false")
+			before := testutil.ToFloat64(counter)
+			_, err = smClient.GetInstanceByID(context.TODO(), "instanceID", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+		})
+	})
+})
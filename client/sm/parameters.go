@@ -17,6 +17,18 @@ const (
 	labelQuery = "labelQuery"
 )
 
+// copy returns a deep copy of the parameters, list iterators append the paging parameters to their own copy
+func (p *Parameters) copy() *Parameters {
+	if p == nil {
+		return nil
+	}
+	return &Parameters{
+		FieldQuery:    append([]string(nil), p.FieldQuery...),
+		LabelQuery:    append([]string(nil), p.LabelQuery...),
+		GeneralParams: append([]string(nil), p.GeneralParams...),
+	}
+}
+
 // Encode encodes the parameters as URL query parameters
 func (p *Parameters) Encode() string {
 	if p == nil {
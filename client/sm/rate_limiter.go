@@ -0,0 +1,95 @@
+package sm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+	"golang.org/x/time/rate"
+)
+
+const retryAfterFormat = time.DateTime + " -0700 MST"
+
+type rateLimiterBucket struct {
+	limiter     *rate.Limiter
+	pausedUntil time.Time
+}
+
+// RateLimiter throttles the calls of all clients that authenticate to the same Service Manager subaccount.
+// Every subaccount gets its own token bucket, and once Service Manager answers with 429 all calls
+// of that subaccount are rejected until the Retry-After instant.
+// A single limiter is safe to share between clients.
+type RateLimiter struct {
+	mu      sync.Mutex
+	limit   rate.Limit
+	burst   int
+	buckets map[string]*rateLimiterBucket
+	nowFunc func() time.Time
+}
+
+// NewRateLimiter creates a limiter allowing requestsPerSecond calls with the given burst per subaccount,
+// a non-positive requestsPerSecond disables the token bucket but keeps honoring 429 responses
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	limit := rate.Inf
+	if requestsPerSecond > 0 {
+		limit = rate.Limit(requestsPerSecond)
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &RateLimiter{
+		limit:   limit,
+		burst:   burst,
+		buckets: make(map[string]*rateLimiterBucket),
+		nowFunc: time.Now,
+	}
+}
+
+// Wait blocks until a call to the given scope is allowed.
+// A *ServiceManagerError with status 429 is returned while the scope is paused.
+func (l *RateLimiter) Wait(ctx context.Context, scope string) error {
+	l.mu.Lock()
+	b := l.bucket(scope)
+	pausedUntil := b.pausedUntil
+	l.mu.Unlock()
+
+	if l.nowFunc().Before(pausedUntil) {
+		metrics.SMRateLimiterRejected.Inc()
+		return &ServiceManagerError{
+			StatusCode:      http.StatusTooManyRequests,
+			Description:     fmt.Sprintf("calls to Service Manager are paused until %s due to rate limiting", pausedUntil.UTC().Format(retryAfterFormat)),
+			ResponseHeaders: http.Header{"Retry-After": []string{pausedUntil.UTC().Format(retryAfterFormat)}},
+		}
+	}
+
+	start := time.Now()
+	if err := b.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	metrics.SMRateLimiterWaitSeconds.Observe(time.Since(start).Seconds())
+	return nil
+}
+
+// Pause rejects all calls to the given scope until the given instant
+func (l *RateLimiter) Pause(scope string, until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(scope)
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+		metrics.SMRateLimiterPauses.Inc()
+	}
+}
+
+func (l *RateLimiter) bucket(scope string) *rateLimiterBucket {
+	b, ok := l.buckets[scope]
+	if !ok {
+		b = &rateLimiterBucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[scope] = b
+	}
+	return b
+}
@@ -0,0 +1,93 @@
+package sm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/client/sm/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		limiter *RateLimiter
+		now     time.Time
+		scope   string
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		limiter = NewRateLimiter(0, 0)
+		limiter.nowFunc = func() time.Time { return now }
+		scope = ClientConfig{URL: "https://sm.url", ClientID: "client-id"}.Scope()
+	})
+
+	It("allows calls when not paused", func() {
+		Expect(limiter.Wait(context.TODO(), scope)).To(Succeed())
+	})
+
+	It("rejects calls of a paused scope until the pause ends", func() {
+		limiter.Pause(scope, now.Add(time.Minute))
+
+		err := limiter.Wait(context.TODO(), scope)
+		var smError *ServiceManagerError
+		Expect(errors.As(err, &smError)).To(BeTrue())
+		Expect(smError.StatusCode).To(Equal(http.StatusTooManyRequests))
+		retryAfter, ok := ParseRetryAfter(smError.ResponseHeaders.Get("Retry-After"), now)
+		Expect(ok).To(BeTrue())
+		Expect(retryAfter.Unix()).To(Equal(now.Add(time.Minute).Unix()))
+
+		now = now.Add(time.Minute)
+		Expect(limiter.Wait(context.TODO(), scope)).To(Succeed())
+	})
+
+	It("does not pause other scopes", func() {
+		limiter.Pause(scope, now.Add(time.Minute))
+		Expect(limiter.Wait(context.TODO(), ClientConfig{URL: "https://sm.url", ClientID: "other-client-id"}.Scope())).To(Succeed())
+	})
+
+	It("does not shorten an existing pause", func() {
+		limiter.Pause(scope, now.Add(time.Minute))
+		limiter.Pause(scope, now.Add(time.Second))
+		now = now.Add(30 * time.Second)
+		Expect(limiter.Wait(context.TODO(), scope)).ToNot(Succeed())
+	})
+
+	It("limits the rate of calls", func() {
+		limiter = NewRateLimiter(1, 1)
+		Expect(limiter.Wait(context.TODO(), scope)).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+		defer cancel()
+		Expect(limiter.Wait(ctx, scope)).ToNot(Succeed())
+	})
+
+	Context("When used by the client", func() {
+		BeforeEach(func() {
+			handlerDetails = []HandlerDetails{
+				{Method: http.MethodGet, Path: types.ServiceInstancesURL, ResponseStatusCode: http.StatusTooManyRequests,
+					Headers: map[string]string{"Retry-After": time.Now().Add(time.Hour).UTC().Format(retryAfterFormat)}},
+				{Method: http.MethodGet, Path: types.ServiceBindingsURL, ResponseBody: []byte(`{"items":[]}`), ResponseStatusCode: http.StatusOK},
+			}
+		})
+
+		It("pauses all calls after a 429 response", func() {
+			var err error
+			client, err = NewClient(context.TODO(), &ClientConfig{URL: smServer.URL, RateLimiter: NewRateLimiter(0, 0)}, fakeAuthClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = client.ListInstances(context.TODO(), nil)
+			expectErrorToContainSubstringAndStatusCode(err, "", http.StatusTooManyRequests)
+			Expect(fakeAuthClient.requests).To(Equal(1))
+
+			_, err = client.ListBindings(context.TODO(), nil)
+			var smError *ServiceManagerError
+			Expect(errors.As(err, &smError)).To(BeTrue())
+			Expect(smError.StatusCode).To(Equal(http.StatusTooManyRequests))
+			Expect(fakeAuthClient.requests).To(Equal(1))
+		})
+	})
+})
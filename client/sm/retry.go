@@ -0,0 +1,106 @@
+package sm
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how idempotent calls to Service Manager are retried on transient failures
+type RetryPolicy struct {
+	// MaxRetries is the number of additional attempts, zero disables retries
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry, it doubles with every further attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff between two attempts
+	MaxDelay time.Duration
+}
+
+// ParseRetryAfter returns the instant described by a Retry-After header value.
+// Both RFC 7231 forms (delta-seconds and HTTP-date) are supported, as well as the
+// "2024-11-11 14:59:33 +0000 UTC" form returned by Service Manager.
+func ParseRetryAfter(value string, now time.Time) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return time.Time{}, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return time.Time{}, false
+		}
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+
+	if retryAfter, err := http.ParseTime(value); err == nil {
+		return retryAfter, true
+	}
+
+	if len(value) >= len(time.DateTime) {
+		if retryAfter, err := time.Parse(time.DateTime, value[:len(time.DateTime)]); err == nil {
+			return retryAfter, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// isTransientFailure reports whether the call failed because Service Manager was not reachable or temporarily unavailable,
+// such failures are retried for idempotent calls and tracked by the circuit breaker
+func isTransientFailure(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		var smError *ServiceManagerError
+		// errors produced by the client itself (e.g. rate limiting) are not network errors
+		return !errors.As(err, &smError)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return resp.Header.Get("X-Cf-RouterError") == "unknown_route"
+}
+
+// isTimeout reports whether the call failed because the deadline of the operation passed or the connection timed out
+func isTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// backoff returns the jittered delay before the given retry attempt (starting from 1),
+// a Retry-After header of the failed response is honored as long as it does not exceed MaxDelay
+func (p RetryPolicy) backoff(attempt int, resp *http.Response, now time.Time) time.Duration {
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = math.MaxInt64
+	}
+	delay := maxDelay
+	if exp := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1)); exp < float64(maxDelay) {
+		delay = time.Duration(exp)
+	}
+	if delay > 1 {
+		// full jitter on the upper half to avoid synchronized retries of concurrent reconciles
+		delay = delay/2 + rand.N(delay/2)
+	}
+
+	if resp != nil {
+		if retryAfter, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			if wait := retryAfter.Sub(now); wait > delay && wait <= maxDelay {
+				delay = wait
+			}
+		}
+	}
+	return delay
+}
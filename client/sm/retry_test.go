@@ -0,0 +1,165 @@
+package sm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retry", func() {
+	now := time.Date(2024, 11, 11, 14, 59, 0, 0, time.UTC)
+
+	Describe("ParseRetryAfter", func() {
+		It("parses delta-seconds", func() {
+			retryAfter, ok := ParseRetryAfter("120", now)
+			Expect(ok).To(BeTrue())
+			Expect(retryAfter).To(Equal(now.Add(2 * time.Minute)))
+		})
+
+		It("parses HTTP-date", func() {
+			retryAfter, ok := ParseRetryAfter("Mon, 11 Nov 2024 14:59:33 GMT", now)
+			Expect(ok).To(BeTrue())
+			Expect(retryAfter.Equal(now.Add(33 * time.Second))).To(BeTrue())
+		})
+
+		It("parses obsolete RFC 850 HTTP-date", func() {
+			retryAfter, ok := ParseRetryAfter("Monday, 11-Nov-24 14:59:33 GMT", now)
+			Expect(ok).To(BeTrue())
+			Expect(retryAfter.Equal(now.Add(33 * time.Second))).To(BeTrue())
+		})
+
+		It("parses the Service Manager format", func() {
+			retryAfter, ok := ParseRetryAfter("2024-11-11 14:59:33 +0000 UTC", now)
+			Expect(ok).To(BeTrue())
+			Expect(retryAfter.Equal(now.Add(33 * time.Second))).To(BeTrue())
+		})
+
+		It("rejects invalid values", func() {
+			for _, value := range []string{"", "-5", "soon", "2024-11-11"} {
+				_, ok := ParseRetryAfter(value, now)
+				Expect(ok).To(BeFalse(), value)
+			}
+		})
+	})
+
+	Describe("backoff", func() {
+		policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+		It("grows exponentially with jitter and is capped", func() {
+			Expect(policy.backoff(1, nil, now)).To(And(BeNumerically(">=", 500*time.Millisecond), BeNumerically("<", time.Second)))
+			Expect(policy.backoff(3, nil, now)).To(And(BeNumerically(">=", 2*time.Second), BeNumerically("<", 4*time.Second)))
+			Expect(policy.backoff(10, nil, now)).To(And(BeNumerically(">=", 5*time.Second), BeNumerically("<", 10*time.Second)))
+		})
+
+		It("honors Retry-After within the max delay", func() {
+			resp := &http.Response{Header: http.Header{"Retry-After": []string{"8"}}}
+			Expect(policy.backoff(1, resp, now)).To(Equal(8 * time.Second))
+
+			resp = &http.Response{Header: http.Header{"Retry-After": []string{"60"}}}
+			Expect(policy.backoff(1, resp, now)).To(BeNumerically("<", time.Second))
+		})
+	})
+
+	Describe("callWithUser", func() {
+		var (
+			server   *httptest.Server
+			requests int32
+			failures int32
+			header   http.Header
+			status   int
+		)
+
+		BeforeEach(func() {
+			requests = 0
+			failures = 2
+			status = http.StatusServiceUnavailable
+			header = http.Header{}
+		})
+
+		JustBeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) <= failures {
+					for key, values := range header {
+						w.Header()[key] = values
+					}
+					w.WriteHeader(status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"id": "instanceID"}`))
+			}))
+			var err error
+			client, err = NewClient(context.TODO(), &ClientConfig{
+				URL:         server.URL,
+				RetryPolicy: RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond},
+			}, http.DefaultClient)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("retries GET requests on 503", func() {
+			instance, err := client.GetInstanceByID(context.TODO(), "instanceID", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance.ID).To(Equal("instanceID"))
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(3)))
+		})
+
+		When("router does not know the route", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+				header.Set("X-Cf-RouterError", "unknown_route")
+			})
+
+			It("retries GET requests", func() {
+				_, err := client.GetInstanceByID(context.TODO(), "instanceID", nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(atomic.LoadInt32(&requests)).To(Equal(int32(3)))
+			})
+		})
+
+		When("failures exceed the max retries", func() {
+			BeforeEach(func() {
+				failures = 10
+			})
+
+			It("returns the last error", func() {
+				_, err := client.GetInstanceByID(context.TODO(), "instanceID", nil)
+				expectErrorToContainSubstringAndStatusCode(err, "", http.StatusServiceUnavailable)
+				Expect(atomic.LoadInt32(&requests)).To(Equal(int32(4)))
+			})
+		})
+
+		When("status is not transient", func() {
+			BeforeEach(func() {
+				status = http.StatusInternalServerError
+			})
+
+			It("does not retry", func() {
+				_, err := client.GetInstanceByID(context.TODO(), "instanceID", nil)
+				expectErrorToContainSubstringAndStatusCode(err, "", http.StatusInternalServerError)
+				Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+			})
+		})
+
+		It("does not retry non idempotent requests", func() {
+			_, err := client.Deprovision(context.TODO(), "instanceID", nil, "")
+			expectErrorToContainSubstringAndStatusCode(err, "", http.StatusServiceUnavailable)
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+		})
+
+		It("retries list requests", func() {
+			failures = 1
+			_, err := client.ListPlans(context.TODO(), &Parameters{FieldQuery: []string{"id eq 'plan'"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(2)))
+		})
+	})
+})
@@ -2,6 +2,7 @@
 package smfakes
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"sync"
@@ -11,12 +12,13 @@ import (
 )
 
 type FakeClient struct {
-	BindStub        func(*types.ServiceBinding, *sm.Parameters, string) (*types.ServiceBinding, string, error)
+	BindStub        func(context.Context, *types.ServiceBinding, *sm.Parameters, string) (*types.ServiceBinding, string, error)
 	bindMutex       sync.RWMutex
 	bindArgsForCall []struct {
-		arg1 *types.ServiceBinding
-		arg2 *sm.Parameters
-		arg3 string
+		arg1 context.Context
+		arg2 *types.ServiceBinding
+		arg3 *sm.Parameters
+		arg4 string
 	}
 	bindReturns struct {
 		result1 *types.ServiceBinding
@@ -28,13 +30,14 @@ type FakeClient struct {
 		result2 string
 		result3 error
 	}
-	CallStub        func(string, string, io.Reader, *sm.Parameters) (*http.Response, error)
+	CallStub        func(context.Context, string, string, io.Reader, *sm.Parameters) (*http.Response, error)
 	callMutex       sync.RWMutex
 	callArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 io.Reader
-		arg4 *sm.Parameters
+		arg3 string
+		arg4 io.Reader
+		arg5 *sm.Parameters
 	}
 	callReturns struct {
 		result1 *http.Response
@@ -44,12 +47,13 @@ type FakeClient struct {
 		result1 *http.Response
 		result2 error
 	}
-	DeprovisionStub        func(string, *sm.Parameters, string) (string, error)
+	DeprovisionStub        func(context.Context, string, *sm.Parameters, string) (string, error)
 	deprovisionMutex       sync.RWMutex
 	deprovisionArgsForCall []struct {
-		arg1 string
-		arg2 *sm.Parameters
-		arg3 string
+		arg1 context.Context
+		arg2 string
+		arg3 *sm.Parameters
+		arg4 string
 	}
 	deprovisionReturns struct {
 		result1 string
@@ -59,11 +63,12 @@ type FakeClient struct {
 		result1 string
 		result2 error
 	}
-	GetBindingByIDStub        func(string, *sm.Parameters) (*types.ServiceBinding, error)
+	GetBindingByIDStub        func(context.Context, string, *sm.Parameters) (*types.ServiceBinding, error)
 	getBindingByIDMutex       sync.RWMutex
 	getBindingByIDArgsForCall []struct {
-		arg1 string
-		arg2 *sm.Parameters
+		arg1 context.Context
+		arg2 string
+		arg3 *sm.Parameters
 	}
 	getBindingByIDReturns struct {
 		result1 *types.ServiceBinding
@@ -73,11 +78,12 @@ type FakeClient struct {
 		result1 *types.ServiceBinding
 		result2 error
 	}
-	GetInstanceByIDStub        func(string, *sm.Parameters) (*types.ServiceInstance, error)
+	GetInstanceByIDStub        func(context.Context, string, *sm.Parameters) (*types.ServiceInstance, error)
 	getInstanceByIDMutex       sync.RWMutex
 	getInstanceByIDArgsForCall []struct {
-		arg1 string
-		arg2 *sm.Parameters
+		arg1 context.Context
+		arg2 string
+		arg3 *sm.Parameters
 	}
 	getInstanceByIDReturns struct {
 		result1 *types.ServiceInstance
@@ -87,10 +93,45 @@ type FakeClient struct {
 		result1 *types.ServiceInstance
 		result2 error
 	}
-	ListBindingsStub        func(*sm.Parameters) (*types.ServiceBindings, error)
+	GetInstanceParametersStub        func(context.Context, string, *sm.Parameters) (map[string]interface{}, error)
+	getInstanceParametersMutex       sync.RWMutex
+	getInstanceParametersArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *sm.Parameters
+	}
+	getInstanceParametersReturns struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	getInstanceParametersReturnsOnCall map[int]struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	GetPlanStub        func(context.Context, string, string, string, string) (*types.ServicePlan, *types.ServiceOffering, error)
+	getPlanMutex       sync.RWMutex
+	getPlanArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	getPlanReturns struct {
+		result1 *types.ServicePlan
+		result2 *types.ServiceOffering
+		result3 error
+	}
+	getPlanReturnsOnCall map[int]struct {
+		result1 *types.ServicePlan
+		result2 *types.ServiceOffering
+		result3 error
+	}
+	ListBindingsStub        func(context.Context, *sm.Parameters) (*types.ServiceBindings, error)
 	listBindingsMutex       sync.RWMutex
 	listBindingsArgsForCall []struct {
-		arg1 *sm.Parameters
+		arg1 context.Context
+		arg2 *sm.Parameters
 	}
 	listBindingsReturns struct {
 		result1 *types.ServiceBindings
@@ -100,10 +141,11 @@ type FakeClient struct {
 		result1 *types.ServiceBindings
 		result2 error
 	}
-	ListInstancesStub        func(*sm.Parameters) (*types.ServiceInstances, error)
+	ListInstancesStub        func(context.Context, *sm.Parameters) (*types.ServiceInstances, error)
 	listInstancesMutex       sync.RWMutex
 	listInstancesArgsForCall []struct {
-		arg1 *sm.Parameters
+		arg1 context.Context
+		arg2 *sm.Parameters
 	}
 	listInstancesReturns struct {
 		result1 *types.ServiceInstances
@@ -113,10 +155,11 @@ type FakeClient struct {
 		result1 *types.ServiceInstances
 		result2 error
 	}
-	ListOfferingsStub        func(*sm.Parameters) (*types.ServiceOfferings, error)
+	ListOfferingsStub        func(context.Context, *sm.Parameters) (*types.ServiceOfferings, error)
 	listOfferingsMutex       sync.RWMutex
 	listOfferingsArgsForCall []struct {
-		arg1 *sm.Parameters
+		arg1 context.Context
+		arg2 *sm.Parameters
 	}
 	listOfferingsReturns struct {
 		result1 *types.ServiceOfferings
@@ -126,10 +169,11 @@ type FakeClient struct {
 		result1 *types.ServiceOfferings
 		result2 error
 	}
-	ListPlansStub        func(*sm.Parameters) (*types.ServicePlans, error)
+	ListPlansStub        func(context.Context, *sm.Parameters) (*types.ServicePlans, error)
 	listPlansMutex       sync.RWMutex
 	listPlansArgsForCall []struct {
-		arg1 *sm.Parameters
+		arg1 context.Context
+		arg2 *sm.Parameters
 	}
 	listPlansReturns struct {
 		result1 *types.ServicePlans
@@ -139,15 +183,16 @@ type FakeClient struct {
 		result1 *types.ServicePlans
 		result2 error
 	}
-	ProvisionStub        func(*types.ServiceInstance, string, string, *sm.Parameters, string, string) (*sm.ProvisionResponse, error)
+	ProvisionStub        func(context.Context, *types.ServiceInstance, string, string, *sm.Parameters, string, string) (*sm.ProvisionResponse, error)
 	provisionMutex       sync.RWMutex
 	provisionArgsForCall []struct {
-		arg1 *types.ServiceInstance
-		arg2 string
+		arg1 context.Context
+		arg2 *types.ServiceInstance
 		arg3 string
-		arg4 *sm.Parameters
-		arg5 string
+		arg4 string
+		arg5 *sm.Parameters
 		arg6 string
+		arg7 string
 	}
 	provisionReturns struct {
 		result1 *sm.ProvisionResponse
@@ -157,12 +202,13 @@ type FakeClient struct {
 		result1 *sm.ProvisionResponse
 		result2 error
 	}
-	RenameBindingStub        func(string, string, string) (*types.ServiceBinding, error)
+	RenameBindingStub        func(context.Context, string, string, string) (*types.ServiceBinding, error)
 	renameBindingMutex       sync.RWMutex
 	renameBindingArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
+		arg4 string
 	}
 	renameBindingReturns struct {
 		result1 *types.ServiceBinding
@@ -172,11 +218,12 @@ type FakeClient struct {
 		result1 *types.ServiceBinding
 		result2 error
 	}
-	ShareInstanceStub        func(string, string) error
+	ShareInstanceStub        func(context.Context, string, string) error
 	shareInstanceMutex       sync.RWMutex
 	shareInstanceArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	shareInstanceReturns struct {
 		result1 error
@@ -184,12 +231,13 @@ type FakeClient struct {
 	shareInstanceReturnsOnCall map[int]struct {
 		result1 error
 	}
-	StatusStub        func(string, types.OperationCategory, *sm.Parameters) (*types.Operation, error)
+	StatusStub        func(context.Context, string, types.OperationCategory, *sm.Parameters) (*types.Operation, error)
 	statusMutex       sync.RWMutex
 	statusArgsForCall []struct {
-		arg1 string
-		arg2 types.OperationCategory
-		arg3 *sm.Parameters
+		arg1 context.Context
+		arg2 string
+		arg3 types.OperationCategory
+		arg4 *sm.Parameters
 	}
 	statusReturns struct {
 		result1 *types.Operation
@@ -199,11 +247,12 @@ type FakeClient struct {
 		result1 *types.Operation
 		result2 error
 	}
-	UnShareInstanceStub        func(string, string) error
+	UnShareInstanceStub        func(context.Context, string, string) error
 	unShareInstanceMutex       sync.RWMutex
 	unShareInstanceArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	unShareInstanceReturns struct {
 		result1 error
@@ -211,12 +260,13 @@ type FakeClient struct {
 	unShareInstanceReturnsOnCall map[int]struct {
 		result1 error
 	}
-	UnbindStub        func(string, *sm.Parameters, string) (string, error)
+	UnbindStub        func(context.Context, string, *sm.Parameters, string) (string, error)
 	unbindMutex       sync.RWMutex
 	unbindArgsForCall []struct {
-		arg1 string
-		arg2 *sm.Parameters
-		arg3 string
+		arg1 context.Context
+		arg2 string
+		arg3 *sm.Parameters
+		arg4 string
 	}
 	unbindReturns struct {
 		result1 string
@@ -226,16 +276,32 @@ type FakeClient struct {
 		result1 string
 		result2 error
 	}
-	UpdateInstanceStub        func(string, *types.ServiceInstance, string, string, *sm.Parameters, string, string) (*types.ServiceInstance, string, error)
+	UpdateBindingLabelsStub        func(context.Context, string, []*types.LabelChange) (*types.ServiceBinding, error)
+	updateBindingLabelsMutex       sync.RWMutex
+	updateBindingLabelsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []*types.LabelChange
+	}
+	updateBindingLabelsReturns struct {
+		result1 *types.ServiceBinding
+		result2 error
+	}
+	updateBindingLabelsReturnsOnCall map[int]struct {
+		result1 *types.ServiceBinding
+		result2 error
+	}
+	UpdateInstanceStub        func(context.Context, string, *types.ServiceInstance, string, string, *sm.Parameters, string, string) (*types.ServiceInstance, string, error)
 	updateInstanceMutex       sync.RWMutex
 	updateInstanceArgsForCall []struct {
-		arg1 string
-		arg2 *types.ServiceInstance
-		arg3 string
+		arg1 context.Context
+		arg2 string
+		arg3 *types.ServiceInstance
 		arg4 string
-		arg5 *sm.Parameters
-		arg6 string
+		arg5 string
+		arg6 *sm.Parameters
 		arg7 string
+		arg8 string
 	}
 	updateInstanceReturns struct {
 		result1 *types.ServiceInstance
@@ -247,24 +313,40 @@ type FakeClient struct {
 		result2 string
 		result3 error
 	}
+	UpdateInstanceLabelsStub        func(context.Context, string, []*types.LabelChange) (*types.ServiceInstance, error)
+	updateInstanceLabelsMutex       sync.RWMutex
+	updateInstanceLabelsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []*types.LabelChange
+	}
+	updateInstanceLabelsReturns struct {
+		result1 *types.ServiceInstance
+		result2 error
+	}
+	updateInstanceLabelsReturnsOnCall map[int]struct {
+		result1 *types.ServiceInstance
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeClient) Bind(arg1 *types.ServiceBinding, arg2 *sm.Parameters, arg3 string) (*types.ServiceBinding, string, error) {
+func (fake *FakeClient) Bind(arg1 context.Context, arg2 *types.ServiceBinding, arg3 *sm.Parameters, arg4 string) (*types.ServiceBinding, string, error) {
 	fake.bindMutex.Lock()
 	ret, specificReturn := fake.bindReturnsOnCall[len(fake.bindArgsForCall)]
 	fake.bindArgsForCall = append(fake.bindArgsForCall, struct {
-		arg1 *types.ServiceBinding
-		arg2 *sm.Parameters
-		arg3 string
-	}{arg1, arg2, arg3})
+		arg1 context.Context
+		arg2 *types.ServiceBinding
+		arg3 *sm.Parameters
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.BindStub
 	fakeReturns := fake.bindReturns
-	fake.recordInvocation("Bind", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("Bind", []interface{}{arg1, arg2, arg3, arg4})
 	fake.bindMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -278,17 +360,17 @@ func (fake *FakeClient) BindCallCount() int {
 	return len(fake.bindArgsForCall)
 }
 
-func (fake *FakeClient) BindCalls(stub func(*types.ServiceBinding, *sm.Parameters, string) (*types.ServiceBinding, string, error)) {
+func (fake *FakeClient) BindCalls(stub func(context.Context, *types.ServiceBinding, *sm.Parameters, string) (*types.ServiceBinding, string, error)) {
 	fake.bindMutex.Lock()
 	defer fake.bindMutex.Unlock()
 	fake.BindStub = stub
 }
 
-func (fake *FakeClient) BindArgsForCall(i int) (*types.ServiceBinding, *sm.Parameters, string) {
+func (fake *FakeClient) BindArgsForCall(i int) (context.Context, *types.ServiceBinding, *sm.Parameters, string) {
 	fake.bindMutex.RLock()
 	defer fake.bindMutex.RUnlock()
 	argsForCall := fake.bindArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeClient) BindReturns(result1 *types.ServiceBinding, result2 string, result3 error) {
@@ -320,21 +402,22 @@ func (fake *FakeClient) BindReturnsOnCall(i int, result1 *types.ServiceBinding,
 	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) Call(arg1 string, arg2 string, arg3 io.Reader, arg4 *sm.Parameters) (*http.Response, error) {
+func (fake *FakeClient) Call(arg1 context.Context, arg2 string, arg3 string, arg4 io.Reader, arg5 *sm.Parameters) (*http.Response, error) {
 	fake.callMutex.Lock()
 	ret, specificReturn := fake.callReturnsOnCall[len(fake.callArgsForCall)]
 	fake.callArgsForCall = append(fake.callArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 io.Reader
-		arg4 *sm.Parameters
-	}{arg1, arg2, arg3, arg4})
+		arg3 string
+		arg4 io.Reader
+		arg5 *sm.Parameters
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.CallStub
 	fakeReturns := fake.callReturns
-	fake.recordInvocation("Call", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("Call", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.callMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -348,17 +431,17 @@ func (fake *FakeClient) CallCallCount() int {
 	return len(fake.callArgsForCall)
 }
 
-func (fake *FakeClient) CallCalls(stub func(string, string, io.Reader, *sm.Parameters) (*http.Response, error)) {
+func (fake *FakeClient) CallCalls(stub func(context.Context, string, string, io.Reader, *sm.Parameters) (*http.Response, error)) {
 	fake.callMutex.Lock()
 	defer fake.callMutex.Unlock()
 	fake.CallStub = stub
 }
 
-func (fake *FakeClient) CallArgsForCall(i int) (string, string, io.Reader, *sm.Parameters) {
+func (fake *FakeClient) CallArgsForCall(i int) (context.Context, string, string, io.Reader, *sm.Parameters) {
 	fake.callMutex.RLock()
 	defer fake.callMutex.RUnlock()
 	argsForCall := fake.callArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeClient) CallReturns(result1 *http.Response, result2 error) {
@@ -387,20 +470,21 @@ func (fake *FakeClient) CallReturnsOnCall(i int, result1 *http.Response, result2
 	}{result1, result2}
 }
 
-func (fake *FakeClient) Deprovision(arg1 string, arg2 *sm.Parameters, arg3 string) (string, error) {
+func (fake *FakeClient) Deprovision(arg1 context.Context, arg2 string, arg3 *sm.Parameters, arg4 string) (string, error) {
 	fake.deprovisionMutex.Lock()
 	ret, specificReturn := fake.deprovisionReturnsOnCall[len(fake.deprovisionArgsForCall)]
 	fake.deprovisionArgsForCall = append(fake.deprovisionArgsForCall, struct {
-		arg1 string
-		arg2 *sm.Parameters
-		arg3 string
-	}{arg1, arg2, arg3})
+		arg1 context.Context
+		arg2 string
+		arg3 *sm.Parameters
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.DeprovisionStub
 	fakeReturns := fake.deprovisionReturns
-	fake.recordInvocation("Deprovision", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("Deprovision", []interface{}{arg1, arg2, arg3, arg4})
 	fake.deprovisionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -414,17 +498,17 @@ func (fake *FakeClient) DeprovisionCallCount() int {
 	return len(fake.deprovisionArgsForCall)
 }
 
-func (fake *FakeClient) DeprovisionCalls(stub func(string, *sm.Parameters, string) (string, error)) {
+func (fake *FakeClient) DeprovisionCalls(stub func(context.Context, string, *sm.Parameters, string) (string, error)) {
 	fake.deprovisionMutex.Lock()
 	defer fake.deprovisionMutex.Unlock()
 	fake.DeprovisionStub = stub
 }
 
-func (fake *FakeClient) DeprovisionArgsForCall(i int) (string, *sm.Parameters, string) {
+func (fake *FakeClient) DeprovisionArgsForCall(i int) (context.Context, string, *sm.Parameters, string) {
 	fake.deprovisionMutex.RLock()
 	defer fake.deprovisionMutex.RUnlock()
 	argsForCall := fake.deprovisionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeClient) DeprovisionReturns(result1 string, result2 error) {
@@ -453,19 +537,20 @@ func (fake *FakeClient) DeprovisionReturnsOnCall(i int, result1 string, result2
 	}{result1, result2}
 }
 
-func (fake *FakeClient) GetBindingByID(arg1 string, arg2 *sm.Parameters) (*types.ServiceBinding, error) {
+func (fake *FakeClient) GetBindingByID(arg1 context.Context, arg2 string, arg3 *sm.Parameters) (*types.ServiceBinding, error) {
 	fake.getBindingByIDMutex.Lock()
 	ret, specificReturn := fake.getBindingByIDReturnsOnCall[len(fake.getBindingByIDArgsForCall)]
 	fake.getBindingByIDArgsForCall = append(fake.getBindingByIDArgsForCall, struct {
-		arg1 string
-		arg2 *sm.Parameters
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 *sm.Parameters
+	}{arg1, arg2, arg3})
 	stub := fake.GetBindingByIDStub
 	fakeReturns := fake.getBindingByIDReturns
-	fake.recordInvocation("GetBindingByID", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetBindingByID", []interface{}{arg1, arg2, arg3})
 	fake.getBindingByIDMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -479,17 +564,17 @@ func (fake *FakeClient) GetBindingByIDCallCount() int {
 	return len(fake.getBindingByIDArgsForCall)
 }
 
-func (fake *FakeClient) GetBindingByIDCalls(stub func(string, *sm.Parameters) (*types.ServiceBinding, error)) {
+func (fake *FakeClient) GetBindingByIDCalls(stub func(context.Context, string, *sm.Parameters) (*types.ServiceBinding, error)) {
 	fake.getBindingByIDMutex.Lock()
 	defer fake.getBindingByIDMutex.Unlock()
 	fake.GetBindingByIDStub = stub
 }
 
-func (fake *FakeClient) GetBindingByIDArgsForCall(i int) (string, *sm.Parameters) {
+func (fake *FakeClient) GetBindingByIDArgsForCall(i int) (context.Context, string, *sm.Parameters) {
 	fake.getBindingByIDMutex.RLock()
 	defer fake.getBindingByIDMutex.RUnlock()
 	argsForCall := fake.getBindingByIDArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeClient) GetBindingByIDReturns(result1 *types.ServiceBinding, result2 error) {
@@ -518,19 +603,20 @@ func (fake *FakeClient) GetBindingByIDReturnsOnCall(i int, result1 *types.Servic
 	}{result1, result2}
 }
 
-func (fake *FakeClient) GetInstanceByID(arg1 string, arg2 *sm.Parameters) (*types.ServiceInstance, error) {
+func (fake *FakeClient) GetInstanceByID(arg1 context.Context, arg2 string, arg3 *sm.Parameters) (*types.ServiceInstance, error) {
 	fake.getInstanceByIDMutex.Lock()
 	ret, specificReturn := fake.getInstanceByIDReturnsOnCall[len(fake.getInstanceByIDArgsForCall)]
 	fake.getInstanceByIDArgsForCall = append(fake.getInstanceByIDArgsForCall, struct {
-		arg1 string
-		arg2 *sm.Parameters
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 *sm.Parameters
+	}{arg1, arg2, arg3})
 	stub := fake.GetInstanceByIDStub
 	fakeReturns := fake.getInstanceByIDReturns
-	fake.recordInvocation("GetInstanceByID", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetInstanceByID", []interface{}{arg1, arg2, arg3})
 	fake.getInstanceByIDMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -544,17 +630,17 @@ func (fake *FakeClient) GetInstanceByIDCallCount() int {
 	return len(fake.getInstanceByIDArgsForCall)
 }
 
-func (fake *FakeClient) GetInstanceByIDCalls(stub func(string, *sm.Parameters) (*types.ServiceInstance, error)) {
+func (fake *FakeClient) GetInstanceByIDCalls(stub func(context.Context, string, *sm.Parameters) (*types.ServiceInstance, error)) {
 	fake.getInstanceByIDMutex.Lock()
 	defer fake.getInstanceByIDMutex.Unlock()
 	fake.GetInstanceByIDStub = stub
 }
 
-func (fake *FakeClient) GetInstanceByIDArgsForCall(i int) (string, *sm.Parameters) {
+func (fake *FakeClient) GetInstanceByIDArgsForCall(i int) (context.Context, string, *sm.Parameters) {
 	fake.getInstanceByIDMutex.RLock()
 	defer fake.getInstanceByIDMutex.RUnlock()
 	argsForCall := fake.getInstanceByIDArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeClient) GetInstanceByIDReturns(result1 *types.ServiceInstance, result2 error) {
@@ -583,18 +669,156 @@ func (fake *FakeClient) GetInstanceByIDReturnsOnCall(i int, result1 *types.Servi
 	}{result1, result2}
 }
 
-func (fake *FakeClient) ListBindings(arg1 *sm.Parameters) (*types.ServiceBindings, error) {
+func (fake *FakeClient) GetInstanceParameters(arg1 context.Context, arg2 string, arg3 *sm.Parameters) (map[string]interface{}, error) {
+	fake.getInstanceParametersMutex.Lock()
+	ret, specificReturn := fake.getInstanceParametersReturnsOnCall[len(fake.getInstanceParametersArgsForCall)]
+	fake.getInstanceParametersArgsForCall = append(fake.getInstanceParametersArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *sm.Parameters
+	}{arg1, arg2, arg3})
+	stub := fake.GetInstanceParametersStub
+	fakeReturns := fake.getInstanceParametersReturns
+	fake.recordInvocation("GetInstanceParameters", []interface{}{arg1, arg2, arg3})
+	fake.getInstanceParametersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetInstanceParametersCallCount() int {
+	fake.getInstanceParametersMutex.RLock()
+	defer fake.getInstanceParametersMutex.RUnlock()
+	return len(fake.getInstanceParametersArgsForCall)
+}
+
+func (fake *FakeClient) GetInstanceParametersCalls(stub func(context.Context, string, *sm.Parameters) (map[string]interface{}, error)) {
+	fake.getInstanceParametersMutex.Lock()
+	defer fake.getInstanceParametersMutex.Unlock()
+	fake.GetInstanceParametersStub = stub
+}
+
+func (fake *FakeClient) GetInstanceParametersArgsForCall(i int) (context.Context, string, *sm.Parameters) {
+	fake.getInstanceParametersMutex.RLock()
+	defer fake.getInstanceParametersMutex.RUnlock()
+	argsForCall := fake.getInstanceParametersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) GetInstanceParametersReturns(result1 map[string]interface{}, result2 error) {
+	fake.getInstanceParametersMutex.Lock()
+	defer fake.getInstanceParametersMutex.Unlock()
+	fake.GetInstanceParametersStub = nil
+	fake.getInstanceParametersReturns = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetInstanceParametersReturnsOnCall(i int, result1 map[string]interface{}, result2 error) {
+	fake.getInstanceParametersMutex.Lock()
+	defer fake.getInstanceParametersMutex.Unlock()
+	fake.GetInstanceParametersStub = nil
+	if fake.getInstanceParametersReturnsOnCall == nil {
+		fake.getInstanceParametersReturnsOnCall = make(map[int]struct {
+			result1 map[string]interface{}
+			result2 error
+		})
+	}
+	fake.getInstanceParametersReturnsOnCall[i] = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetPlan(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) (*types.ServicePlan, *types.ServiceOffering, error) {
+	fake.getPlanMutex.Lock()
+	ret, specificReturn := fake.getPlanReturnsOnCall[len(fake.getPlanArgsForCall)]
+	fake.getPlanArgsForCall = append(fake.getPlanArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetPlanStub
+	fakeReturns := fake.getPlanReturns
+	fake.recordInvocation("GetPlan", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getPlanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) GetPlanCallCount() int {
+	fake.getPlanMutex.RLock()
+	defer fake.getPlanMutex.RUnlock()
+	return len(fake.getPlanArgsForCall)
+}
+
+func (fake *FakeClient) GetPlanCalls(stub func(context.Context, string, string, string, string) (*types.ServicePlan, *types.ServiceOffering, error)) {
+	fake.getPlanMutex.Lock()
+	defer fake.getPlanMutex.Unlock()
+	fake.GetPlanStub = stub
+}
+
+func (fake *FakeClient) GetPlanArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.getPlanMutex.RLock()
+	defer fake.getPlanMutex.RUnlock()
+	argsForCall := fake.getPlanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeClient) GetPlanReturns(result1 *types.ServicePlan, result2 *types.ServiceOffering, result3 error) {
+	fake.getPlanMutex.Lock()
+	defer fake.getPlanMutex.Unlock()
+	fake.GetPlanStub = nil
+	fake.getPlanReturns = struct {
+		result1 *types.ServicePlan
+		result2 *types.ServiceOffering
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetPlanReturnsOnCall(i int, result1 *types.ServicePlan, result2 *types.ServiceOffering, result3 error) {
+	fake.getPlanMutex.Lock()
+	defer fake.getPlanMutex.Unlock()
+	fake.GetPlanStub = nil
+	if fake.getPlanReturnsOnCall == nil {
+		fake.getPlanReturnsOnCall = make(map[int]struct {
+			result1 *types.ServicePlan
+			result2 *types.ServiceOffering
+			result3 error
+		})
+	}
+	fake.getPlanReturnsOnCall[i] = struct {
+		result1 *types.ServicePlan
+		result2 *types.ServiceOffering
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) ListBindings(arg1 context.Context, arg2 *sm.Parameters) (*types.ServiceBindings, error) {
 	fake.listBindingsMutex.Lock()
 	ret, specificReturn := fake.listBindingsReturnsOnCall[len(fake.listBindingsArgsForCall)]
 	fake.listBindingsArgsForCall = append(fake.listBindingsArgsForCall, struct {
-		arg1 *sm.Parameters
-	}{arg1})
+		arg1 context.Context
+		arg2 *sm.Parameters
+	}{arg1, arg2})
 	stub := fake.ListBindingsStub
 	fakeReturns := fake.listBindingsReturns
-	fake.recordInvocation("ListBindings", []interface{}{arg1})
+	fake.recordInvocation("ListBindings", []interface{}{arg1, arg2})
 	fake.listBindingsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -608,17 +832,17 @@ func (fake *FakeClient) ListBindingsCallCount() int {
 	return len(fake.listBindingsArgsForCall)
 }
 
-func (fake *FakeClient) ListBindingsCalls(stub func(*sm.Parameters) (*types.ServiceBindings, error)) {
+func (fake *FakeClient) ListBindingsCalls(stub func(context.Context, *sm.Parameters) (*types.ServiceBindings, error)) {
 	fake.listBindingsMutex.Lock()
 	defer fake.listBindingsMutex.Unlock()
 	fake.ListBindingsStub = stub
 }
 
-func (fake *FakeClient) ListBindingsArgsForCall(i int) *sm.Parameters {
+func (fake *FakeClient) ListBindingsArgsForCall(i int) (context.Context, *sm.Parameters) {
 	fake.listBindingsMutex.RLock()
 	defer fake.listBindingsMutex.RUnlock()
 	argsForCall := fake.listBindingsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) ListBindingsReturns(result1 *types.ServiceBindings, result2 error) {
@@ -647,18 +871,19 @@ func (fake *FakeClient) ListBindingsReturnsOnCall(i int, result1 *types.ServiceB
 	}{result1, result2}
 }
 
-func (fake *FakeClient) ListInstances(arg1 *sm.Parameters) (*types.ServiceInstances, error) {
+func (fake *FakeClient) ListInstances(arg1 context.Context, arg2 *sm.Parameters) (*types.ServiceInstances, error) {
 	fake.listInstancesMutex.Lock()
 	ret, specificReturn := fake.listInstancesReturnsOnCall[len(fake.listInstancesArgsForCall)]
 	fake.listInstancesArgsForCall = append(fake.listInstancesArgsForCall, struct {
-		arg1 *sm.Parameters
-	}{arg1})
+		arg1 context.Context
+		arg2 *sm.Parameters
+	}{arg1, arg2})
 	stub := fake.ListInstancesStub
 	fakeReturns := fake.listInstancesReturns
-	fake.recordInvocation("ListInstances", []interface{}{arg1})
+	fake.recordInvocation("ListInstances", []interface{}{arg1, arg2})
 	fake.listInstancesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -672,17 +897,17 @@ func (fake *FakeClient) ListInstancesCallCount() int {
 	return len(fake.listInstancesArgsForCall)
 }
 
-func (fake *FakeClient) ListInstancesCalls(stub func(*sm.Parameters) (*types.ServiceInstances, error)) {
+func (fake *FakeClient) ListInstancesCalls(stub func(context.Context, *sm.Parameters) (*types.ServiceInstances, error)) {
 	fake.listInstancesMutex.Lock()
 	defer fake.listInstancesMutex.Unlock()
 	fake.ListInstancesStub = stub
 }
 
-func (fake *FakeClient) ListInstancesArgsForCall(i int) *sm.Parameters {
+func (fake *FakeClient) ListInstancesArgsForCall(i int) (context.Context, *sm.Parameters) {
 	fake.listInstancesMutex.RLock()
 	defer fake.listInstancesMutex.RUnlock()
 	argsForCall := fake.listInstancesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) ListInstancesReturns(result1 *types.ServiceInstances, result2 error) {
@@ -711,18 +936,19 @@ func (fake *FakeClient) ListInstancesReturnsOnCall(i int, result1 *types.Service
 	}{result1, result2}
 }
 
-func (fake *FakeClient) ListOfferings(arg1 *sm.Parameters) (*types.ServiceOfferings, error) {
+func (fake *FakeClient) ListOfferings(arg1 context.Context, arg2 *sm.Parameters) (*types.ServiceOfferings, error) {
 	fake.listOfferingsMutex.Lock()
 	ret, specificReturn := fake.listOfferingsReturnsOnCall[len(fake.listOfferingsArgsForCall)]
 	fake.listOfferingsArgsForCall = append(fake.listOfferingsArgsForCall, struct {
-		arg1 *sm.Parameters
-	}{arg1})
+		arg1 context.Context
+		arg2 *sm.Parameters
+	}{arg1, arg2})
 	stub := fake.ListOfferingsStub
 	fakeReturns := fake.listOfferingsReturns
-	fake.recordInvocation("ListOfferings", []interface{}{arg1})
+	fake.recordInvocation("ListOfferings", []interface{}{arg1, arg2})
 	fake.listOfferingsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -736,17 +962,17 @@ func (fake *FakeClient) ListOfferingsCallCount() int {
 	return len(fake.listOfferingsArgsForCall)
 }
 
-func (fake *FakeClient) ListOfferingsCalls(stub func(*sm.Parameters) (*types.ServiceOfferings, error)) {
+func (fake *FakeClient) ListOfferingsCalls(stub func(context.Context, *sm.Parameters) (*types.ServiceOfferings, error)) {
 	fake.listOfferingsMutex.Lock()
 	defer fake.listOfferingsMutex.Unlock()
 	fake.ListOfferingsStub = stub
 }
 
-func (fake *FakeClient) ListOfferingsArgsForCall(i int) *sm.Parameters {
+func (fake *FakeClient) ListOfferingsArgsForCall(i int) (context.Context, *sm.Parameters) {
 	fake.listOfferingsMutex.RLock()
 	defer fake.listOfferingsMutex.RUnlock()
 	argsForCall := fake.listOfferingsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) ListOfferingsReturns(result1 *types.ServiceOfferings, result2 error) {
@@ -775,18 +1001,19 @@ func (fake *FakeClient) ListOfferingsReturnsOnCall(i int, result1 *types.Service
 	}{result1, result2}
 }
 
-func (fake *FakeClient) ListPlans(arg1 *sm.Parameters) (*types.ServicePlans, error) {
+func (fake *FakeClient) ListPlans(arg1 context.Context, arg2 *sm.Parameters) (*types.ServicePlans, error) {
 	fake.listPlansMutex.Lock()
 	ret, specificReturn := fake.listPlansReturnsOnCall[len(fake.listPlansArgsForCall)]
 	fake.listPlansArgsForCall = append(fake.listPlansArgsForCall, struct {
-		arg1 *sm.Parameters
-	}{arg1})
+		arg1 context.Context
+		arg2 *sm.Parameters
+	}{arg1, arg2})
 	stub := fake.ListPlansStub
 	fakeReturns := fake.listPlansReturns
-	fake.recordInvocation("ListPlans", []interface{}{arg1})
+	fake.recordInvocation("ListPlans", []interface{}{arg1, arg2})
 	fake.listPlansMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -800,17 +1027,17 @@ func (fake *FakeClient) ListPlansCallCount() int {
 	return len(fake.listPlansArgsForCall)
 }
 
-func (fake *FakeClient) ListPlansCalls(stub func(*sm.Parameters) (*types.ServicePlans, error)) {
+func (fake *FakeClient) ListPlansCalls(stub func(context.Context, *sm.Parameters) (*types.ServicePlans, error)) {
 	fake.listPlansMutex.Lock()
 	defer fake.listPlansMutex.Unlock()
 	fake.ListPlansStub = stub
 }
 
-func (fake *FakeClient) ListPlansArgsForCall(i int) *sm.Parameters {
+func (fake *FakeClient) ListPlansArgsForCall(i int) (context.Context, *sm.Parameters) {
 	fake.listPlansMutex.RLock()
 	defer fake.listPlansMutex.RUnlock()
 	argsForCall := fake.listPlansArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) ListPlansReturns(result1 *types.ServicePlans, result2 error) {
@@ -839,23 +1066,24 @@ func (fake *FakeClient) ListPlansReturnsOnCall(i int, result1 *types.ServicePlan
 	}{result1, result2}
 }
 
-func (fake *FakeClient) Provision(arg1 *types.ServiceInstance, arg2 string, arg3 string, arg4 *sm.Parameters, arg5 string, arg6 string) (*sm.ProvisionResponse, error) {
+func (fake *FakeClient) Provision(arg1 context.Context, arg2 *types.ServiceInstance, arg3 string, arg4 string, arg5 *sm.Parameters, arg6 string, arg7 string) (*sm.ProvisionResponse, error) {
 	fake.provisionMutex.Lock()
 	ret, specificReturn := fake.provisionReturnsOnCall[len(fake.provisionArgsForCall)]
 	fake.provisionArgsForCall = append(fake.provisionArgsForCall, struct {
-		arg1 *types.ServiceInstance
-		arg2 string
+		arg1 context.Context
+		arg2 *types.ServiceInstance
 		arg3 string
-		arg4 *sm.Parameters
-		arg5 string
+		arg4 string
+		arg5 *sm.Parameters
 		arg6 string
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
+		arg7 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	stub := fake.ProvisionStub
 	fakeReturns := fake.provisionReturns
-	fake.recordInvocation("Provision", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("Provision", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	fake.provisionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -869,17 +1097,17 @@ func (fake *FakeClient) ProvisionCallCount() int {
 	return len(fake.provisionArgsForCall)
 }
 
-func (fake *FakeClient) ProvisionCalls(stub func(*types.ServiceInstance, string, string, *sm.Parameters, string, string) (*sm.ProvisionResponse, error)) {
+func (fake *FakeClient) ProvisionCalls(stub func(context.Context, *types.ServiceInstance, string, string, *sm.Parameters, string, string) (*sm.ProvisionResponse, error)) {
 	fake.provisionMutex.Lock()
 	defer fake.provisionMutex.Unlock()
 	fake.ProvisionStub = stub
 }
 
-func (fake *FakeClient) ProvisionArgsForCall(i int) (*types.ServiceInstance, string, string, *sm.Parameters, string, string) {
+func (fake *FakeClient) ProvisionArgsForCall(i int) (context.Context, *types.ServiceInstance, string, string, *sm.Parameters, string, string) {
 	fake.provisionMutex.RLock()
 	defer fake.provisionMutex.RUnlock()
 	argsForCall := fake.provisionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
 }
 
 func (fake *FakeClient) ProvisionReturns(result1 *sm.ProvisionResponse, result2 error) {
@@ -908,20 +1136,21 @@ func (fake *FakeClient) ProvisionReturnsOnCall(i int, result1 *sm.ProvisionRespo
 	}{result1, result2}
 }
 
-func (fake *FakeClient) RenameBinding(arg1 string, arg2 string, arg3 string) (*types.ServiceBinding, error) {
+func (fake *FakeClient) RenameBinding(arg1 context.Context, arg2 string, arg3 string, arg4 string) (*types.ServiceBinding, error) {
 	fake.renameBindingMutex.Lock()
 	ret, specificReturn := fake.renameBindingReturnsOnCall[len(fake.renameBindingArgsForCall)]
 	fake.renameBindingArgsForCall = append(fake.renameBindingArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.RenameBindingStub
 	fakeReturns := fake.renameBindingReturns
-	fake.recordInvocation("RenameBinding", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("RenameBinding", []interface{}{arg1, arg2, arg3, arg4})
 	fake.renameBindingMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -935,17 +1164,17 @@ func (fake *FakeClient) RenameBindingCallCount() int {
 	return len(fake.renameBindingArgsForCall)
 }
 
-func (fake *FakeClient) RenameBindingCalls(stub func(string, string, string) (*types.ServiceBinding, error)) {
+func (fake *FakeClient) RenameBindingCalls(stub func(context.Context, string, string, string) (*types.ServiceBinding, error)) {
 	fake.renameBindingMutex.Lock()
 	defer fake.renameBindingMutex.Unlock()
 	fake.RenameBindingStub = stub
 }
 
-func (fake *FakeClient) RenameBindingArgsForCall(i int) (string, string, string) {
+func (fake *FakeClient) RenameBindingArgsForCall(i int) (context.Context, string, string, string) {
 	fake.renameBindingMutex.RLock()
 	defer fake.renameBindingMutex.RUnlock()
 	argsForCall := fake.renameBindingArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeClient) RenameBindingReturns(result1 *types.ServiceBinding, result2 error) {
@@ -974,19 +1203,20 @@ func (fake *FakeClient) RenameBindingReturnsOnCall(i int, result1 *types.Service
 	}{result1, result2}
 }
 
-func (fake *FakeClient) ShareInstance(arg1 string, arg2 string) error {
+func (fake *FakeClient) ShareInstance(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.shareInstanceMutex.Lock()
 	ret, specificReturn := fake.shareInstanceReturnsOnCall[len(fake.shareInstanceArgsForCall)]
 	fake.shareInstanceArgsForCall = append(fake.shareInstanceArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.ShareInstanceStub
 	fakeReturns := fake.shareInstanceReturns
-	fake.recordInvocation("ShareInstance", []interface{}{arg1, arg2})
+	fake.recordInvocation("ShareInstance", []interface{}{arg1, arg2, arg3})
 	fake.shareInstanceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1000,17 +1230,17 @@ func (fake *FakeClient) ShareInstanceCallCount() int {
 	return len(fake.shareInstanceArgsForCall)
 }
 
-func (fake *FakeClient) ShareInstanceCalls(stub func(string, string) error) {
+func (fake *FakeClient) ShareInstanceCalls(stub func(context.Context, string, string) error) {
 	fake.shareInstanceMutex.Lock()
 	defer fake.shareInstanceMutex.Unlock()
 	fake.ShareInstanceStub = stub
 }
 
-func (fake *FakeClient) ShareInstanceArgsForCall(i int) (string, string) {
+func (fake *FakeClient) ShareInstanceArgsForCall(i int) (context.Context, string, string) {
 	fake.shareInstanceMutex.RLock()
 	defer fake.shareInstanceMutex.RUnlock()
 	argsForCall := fake.shareInstanceArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeClient) ShareInstanceReturns(result1 error) {
@@ -1036,20 +1266,21 @@ func (fake *FakeClient) ShareInstanceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeClient) Status(arg1 string, arg2 types.OperationCategory, arg3 *sm.Parameters) (*types.Operation, error) {
+func (fake *FakeClient) Status(arg1 context.Context, arg2 string, arg3 types.OperationCategory, arg4 *sm.Parameters) (*types.Operation, error) {
 	fake.statusMutex.Lock()
 	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
 	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
-		arg1 string
-		arg2 types.OperationCategory
-		arg3 *sm.Parameters
-	}{arg1, arg2, arg3})
+		arg1 context.Context
+		arg2 string
+		arg3 types.OperationCategory
+		arg4 *sm.Parameters
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.StatusStub
 	fakeReturns := fake.statusReturns
-	fake.recordInvocation("Status", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("Status", []interface{}{arg1, arg2, arg3, arg4})
 	fake.statusMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1063,17 +1294,17 @@ func (fake *FakeClient) StatusCallCount() int {
 	return len(fake.statusArgsForCall)
 }
 
-func (fake *FakeClient) StatusCalls(stub func(string, types.OperationCategory, *sm.Parameters) (*types.Operation, error)) {
+func (fake *FakeClient) StatusCalls(stub func(context.Context, string, types.OperationCategory, *sm.Parameters) (*types.Operation, error)) {
 	fake.statusMutex.Lock()
 	defer fake.statusMutex.Unlock()
 	fake.StatusStub = stub
 }
 
-func (fake *FakeClient) StatusArgsForCall(i int) (string, types.OperationCategory, *sm.Parameters) {
+func (fake *FakeClient) StatusArgsForCall(i int) (context.Context, string, types.OperationCategory, *sm.Parameters) {
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
 	argsForCall := fake.statusArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeClient) StatusReturns(result1 *types.Operation, result2 error) {
@@ -1102,19 +1333,20 @@ func (fake *FakeClient) StatusReturnsOnCall(i int, result1 *types.Operation, res
 	}{result1, result2}
 }
 
-func (fake *FakeClient) UnShareInstance(arg1 string, arg2 string) error {
+func (fake *FakeClient) UnShareInstance(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.unShareInstanceMutex.Lock()
 	ret, specificReturn := fake.unShareInstanceReturnsOnCall[len(fake.unShareInstanceArgsForCall)]
 	fake.unShareInstanceArgsForCall = append(fake.unShareInstanceArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.UnShareInstanceStub
 	fakeReturns := fake.unShareInstanceReturns
-	fake.recordInvocation("UnShareInstance", []interface{}{arg1, arg2})
+	fake.recordInvocation("UnShareInstance", []interface{}{arg1, arg2, arg3})
 	fake.unShareInstanceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1128,17 +1360,17 @@ func (fake *FakeClient) UnShareInstanceCallCount() int {
 	return len(fake.unShareInstanceArgsForCall)
 }
 
-func (fake *FakeClient) UnShareInstanceCalls(stub func(string, string) error) {
+func (fake *FakeClient) UnShareInstanceCalls(stub func(context.Context, string, string) error) {
 	fake.unShareInstanceMutex.Lock()
 	defer fake.unShareInstanceMutex.Unlock()
 	fake.UnShareInstanceStub = stub
 }
 
-func (fake *FakeClient) UnShareInstanceArgsForCall(i int) (string, string) {
+func (fake *FakeClient) UnShareInstanceArgsForCall(i int) (context.Context, string, string) {
 	fake.unShareInstanceMutex.RLock()
 	defer fake.unShareInstanceMutex.RUnlock()
 	argsForCall := fake.unShareInstanceArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeClient) UnShareInstanceReturns(result1 error) {
@@ -1164,20 +1396,21 @@ func (fake *FakeClient) UnShareInstanceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeClient) Unbind(arg1 string, arg2 *sm.Parameters, arg3 string) (string, error) {
+func (fake *FakeClient) Unbind(arg1 context.Context, arg2 string, arg3 *sm.Parameters, arg4 string) (string, error) {
 	fake.unbindMutex.Lock()
 	ret, specificReturn := fake.unbindReturnsOnCall[len(fake.unbindArgsForCall)]
 	fake.unbindArgsForCall = append(fake.unbindArgsForCall, struct {
-		arg1 string
-		arg2 *sm.Parameters
-		arg3 string
-	}{arg1, arg2, arg3})
+		arg1 context.Context
+		arg2 string
+		arg3 *sm.Parameters
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.UnbindStub
 	fakeReturns := fake.unbindReturns
-	fake.recordInvocation("Unbind", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("Unbind", []interface{}{arg1, arg2, arg3, arg4})
 	fake.unbindMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1191,17 +1424,17 @@ func (fake *FakeClient) UnbindCallCount() int {
 	return len(fake.unbindArgsForCall)
 }
 
-func (fake *FakeClient) UnbindCalls(stub func(string, *sm.Parameters, string) (string, error)) {
+func (fake *FakeClient) UnbindCalls(stub func(context.Context, string, *sm.Parameters, string) (string, error)) {
 	fake.unbindMutex.Lock()
 	defer fake.unbindMutex.Unlock()
 	fake.UnbindStub = stub
 }
 
-func (fake *FakeClient) UnbindArgsForCall(i int) (string, *sm.Parameters, string) {
+func (fake *FakeClient) UnbindArgsForCall(i int) (context.Context, string, *sm.Parameters, string) {
 	fake.unbindMutex.RLock()
 	defer fake.unbindMutex.RUnlock()
 	argsForCall := fake.unbindArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeClient) UnbindReturns(result1 string, result2 error) {
@@ -1230,24 +1463,96 @@ func (fake *FakeClient) UnbindReturnsOnCall(i int, result1 string, result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) UpdateInstance(arg1 string, arg2 *types.ServiceInstance, arg3 string, arg4 string, arg5 *sm.Parameters, arg6 string, arg7 string) (*types.ServiceInstance, string, error) {
+func (fake *FakeClient) UpdateBindingLabels(arg1 context.Context, arg2 string, arg3 []*types.LabelChange) (*types.ServiceBinding, error) {
+	var arg3Copy []*types.LabelChange
+	if arg3 != nil {
+		arg3Copy = make([]*types.LabelChange, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.updateBindingLabelsMutex.Lock()
+	ret, specificReturn := fake.updateBindingLabelsReturnsOnCall[len(fake.updateBindingLabelsArgsForCall)]
+	fake.updateBindingLabelsArgsForCall = append(fake.updateBindingLabelsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []*types.LabelChange
+	}{arg1, arg2, arg3Copy})
+	stub := fake.UpdateBindingLabelsStub
+	fakeReturns := fake.updateBindingLabelsReturns
+	fake.recordInvocation("UpdateBindingLabels", []interface{}{arg1, arg2, arg3Copy})
+	fake.updateBindingLabelsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) UpdateBindingLabelsCallCount() int {
+	fake.updateBindingLabelsMutex.RLock()
+	defer fake.updateBindingLabelsMutex.RUnlock()
+	return len(fake.updateBindingLabelsArgsForCall)
+}
+
+func (fake *FakeClient) UpdateBindingLabelsCalls(stub func(context.Context, string, []*types.LabelChange) (*types.ServiceBinding, error)) {
+	fake.updateBindingLabelsMutex.Lock()
+	defer fake.updateBindingLabelsMutex.Unlock()
+	fake.UpdateBindingLabelsStub = stub
+}
+
+func (fake *FakeClient) UpdateBindingLabelsArgsForCall(i int) (context.Context, string, []*types.LabelChange) {
+	fake.updateBindingLabelsMutex.RLock()
+	defer fake.updateBindingLabelsMutex.RUnlock()
+	argsForCall := fake.updateBindingLabelsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) UpdateBindingLabelsReturns(result1 *types.ServiceBinding, result2 error) {
+	fake.updateBindingLabelsMutex.Lock()
+	defer fake.updateBindingLabelsMutex.Unlock()
+	fake.UpdateBindingLabelsStub = nil
+	fake.updateBindingLabelsReturns = struct {
+		result1 *types.ServiceBinding
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UpdateBindingLabelsReturnsOnCall(i int, result1 *types.ServiceBinding, result2 error) {
+	fake.updateBindingLabelsMutex.Lock()
+	defer fake.updateBindingLabelsMutex.Unlock()
+	fake.UpdateBindingLabelsStub = nil
+	if fake.updateBindingLabelsReturnsOnCall == nil {
+		fake.updateBindingLabelsReturnsOnCall = make(map[int]struct {
+			result1 *types.ServiceBinding
+			result2 error
+		})
+	}
+	fake.updateBindingLabelsReturnsOnCall[i] = struct {
+		result1 *types.ServiceBinding
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UpdateInstance(arg1 context.Context, arg2 string, arg3 *types.ServiceInstance, arg4 string, arg5 string, arg6 *sm.Parameters, arg7 string, arg8 string) (*types.ServiceInstance, string, error) {
 	fake.updateInstanceMutex.Lock()
 	ret, specificReturn := fake.updateInstanceReturnsOnCall[len(fake.updateInstanceArgsForCall)]
 	fake.updateInstanceArgsForCall = append(fake.updateInstanceArgsForCall, struct {
-		arg1 string
-		arg2 *types.ServiceInstance
-		arg3 string
+		arg1 context.Context
+		arg2 string
+		arg3 *types.ServiceInstance
 		arg4 string
-		arg5 *sm.Parameters
-		arg6 string
+		arg5 string
+		arg6 *sm.Parameters
 		arg7 string
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+		arg8 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
 	stub := fake.UpdateInstanceStub
 	fakeReturns := fake.updateInstanceReturns
-	fake.recordInvocation("UpdateInstance", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("UpdateInstance", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
 	fake.updateInstanceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -1261,17 +1566,17 @@ func (fake *FakeClient) UpdateInstanceCallCount() int {
 	return len(fake.updateInstanceArgsForCall)
 }
 
-func (fake *FakeClient) UpdateInstanceCalls(stub func(string, *types.ServiceInstance, string, string, *sm.Parameters, string, string) (*types.ServiceInstance, string, error)) {
+func (fake *FakeClient) UpdateInstanceCalls(stub func(context.Context, string, *types.ServiceInstance, string, string, *sm.Parameters, string, string) (*types.ServiceInstance, string, error)) {
 	fake.updateInstanceMutex.Lock()
 	defer fake.updateInstanceMutex.Unlock()
 	fake.UpdateInstanceStub = stub
 }
 
-func (fake *FakeClient) UpdateInstanceArgsForCall(i int) (string, *types.ServiceInstance, string, string, *sm.Parameters, string, string) {
+func (fake *FakeClient) UpdateInstanceArgsForCall(i int) (context.Context, string, *types.ServiceInstance, string, string, *sm.Parameters, string, string) {
 	fake.updateInstanceMutex.RLock()
 	defer fake.updateInstanceMutex.RUnlock()
 	argsForCall := fake.updateInstanceArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8
 }
 
 func (fake *FakeClient) UpdateInstanceReturns(result1 *types.ServiceInstance, result2 string, result3 error) {
@@ -1303,9 +1608,120 @@ func (fake *FakeClient) UpdateInstanceReturnsOnCall(i int, result1 *types.Servic
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) UpdateInstanceLabels(arg1 context.Context, arg2 string, arg3 []*types.LabelChange) (*types.ServiceInstance, error) {
+	var arg3Copy []*types.LabelChange
+	if arg3 != nil {
+		arg3Copy = make([]*types.LabelChange, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.updateInstanceLabelsMutex.Lock()
+	ret, specificReturn := fake.updateInstanceLabelsReturnsOnCall[len(fake.updateInstanceLabelsArgsForCall)]
+	fake.updateInstanceLabelsArgsForCall = append(fake.updateInstanceLabelsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []*types.LabelChange
+	}{arg1, arg2, arg3Copy})
+	stub := fake.UpdateInstanceLabelsStub
+	fakeReturns := fake.updateInstanceLabelsReturns
+	fake.recordInvocation("UpdateInstanceLabels", []interface{}{arg1, arg2, arg3Copy})
+	fake.updateInstanceLabelsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) UpdateInstanceLabelsCallCount() int {
+	fake.updateInstanceLabelsMutex.RLock()
+	defer fake.updateInstanceLabelsMutex.RUnlock()
+	return len(fake.updateInstanceLabelsArgsForCall)
+}
+
+func (fake *FakeClient) UpdateInstanceLabelsCalls(stub func(context.Context, string, []*types.LabelChange) (*types.ServiceInstance, error)) {
+	fake.updateInstanceLabelsMutex.Lock()
+	defer fake.updateInstanceLabelsMutex.Unlock()
+	fake.UpdateInstanceLabelsStub = stub
+}
+
+func (fake *FakeClient) UpdateInstanceLabelsArgsForCall(i int) (context.Context, string, []*types.LabelChange) {
+	fake.updateInstanceLabelsMutex.RLock()
+	defer fake.updateInstanceLabelsMutex.RUnlock()
+	argsForCall := fake.updateInstanceLabelsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) UpdateInstanceLabelsReturns(result1 *types.ServiceInstance, result2 error) {
+	fake.updateInstanceLabelsMutex.Lock()
+	defer fake.updateInstanceLabelsMutex.Unlock()
+	fake.UpdateInstanceLabelsStub = nil
+	fake.updateInstanceLabelsReturns = struct {
+		result1 *types.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UpdateInstanceLabelsReturnsOnCall(i int, result1 *types.ServiceInstance, result2 error) {
+	fake.updateInstanceLabelsMutex.Lock()
+	defer fake.updateInstanceLabelsMutex.Unlock()
+	fake.UpdateInstanceLabelsStub = nil
+	if fake.updateInstanceLabelsReturnsOnCall == nil {
+		fake.updateInstanceLabelsReturnsOnCall = make(map[int]struct {
+			result1 *types.ServiceInstance
+			result2 error
+		})
+	}
+	fake.updateInstanceLabelsReturnsOnCall[i] = struct {
+		result1 *types.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.bindMutex.RLock()
+	defer fake.bindMutex.RUnlock()
+	fake.callMutex.RLock()
+	defer fake.callMutex.RUnlock()
+	fake.deprovisionMutex.RLock()
+	defer fake.deprovisionMutex.RUnlock()
+	fake.getBindingByIDMutex.RLock()
+	defer fake.getBindingByIDMutex.RUnlock()
+	fake.getInstanceByIDMutex.RLock()
+	defer fake.getInstanceByIDMutex.RUnlock()
+	fake.getInstanceParametersMutex.RLock()
+	defer fake.getInstanceParametersMutex.RUnlock()
+	fake.getPlanMutex.RLock()
+	defer fake.getPlanMutex.RUnlock()
+	fake.listBindingsMutex.RLock()
+	defer fake.listBindingsMutex.RUnlock()
+	fake.listInstancesMutex.RLock()
+	defer fake.listInstancesMutex.RUnlock()
+	fake.listOfferingsMutex.RLock()
+	defer fake.listOfferingsMutex.RUnlock()
+	fake.listPlansMutex.RLock()
+	defer fake.listPlansMutex.RUnlock()
+	fake.provisionMutex.RLock()
+	defer fake.provisionMutex.RUnlock()
+	fake.renameBindingMutex.RLock()
+	defer fake.renameBindingMutex.RUnlock()
+	fake.shareInstanceMutex.RLock()
+	defer fake.shareInstanceMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	fake.unShareInstanceMutex.RLock()
+	defer fake.unShareInstanceMutex.RUnlock()
+	fake.unbindMutex.RLock()
+	defer fake.unbindMutex.RUnlock()
+	fake.updateBindingLabelsMutex.RLock()
+	defer fake.updateBindingLabelsMutex.RUnlock()
+	fake.updateInstanceMutex.RLock()
+	defer fake.updateInstanceMutex.RUnlock()
+	fake.updateInstanceLabelsMutex.RLock()
+	defer fake.updateInstanceLabelsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
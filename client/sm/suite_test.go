@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -13,11 +14,13 @@ import (
 type FakeAuthClient struct {
 	AccessToken string
 	requestURI  string
+	requests    int
 }
 
 func (c *FakeAuthClient) Do(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
 	c.requestURI = req.URL.RequestURI()
+	c.requests++
 	return http.DefaultClient.Do(req)
 }
 
@@ -27,6 +30,7 @@ type HandlerDetails struct {
 	ResponseBody       []byte
 	ResponseStatusCode int
 	Headers            map[string]string
+	Delay              time.Duration
 }
 
 func TestSMClient(t *testing.T) {
@@ -71,6 +75,9 @@ func createSMHandler() http.Handler {
 				response.Write([]byte(""))
 				return
 			}
+			if v.Delay > 0 {
+				time.Sleep(v.Delay)
+			}
 			response.WriteHeader(v.ResponseStatusCode)
 			response.Write(v.ResponseBody)
 		})
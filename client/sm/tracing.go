@@ -0,0 +1,31 @@
+package sm
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SAP/sap-btp-service-operator/internal/tracing"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startRequestSpan starts a client span for a call to Service Manager, covering all of its attempts
+func startRequestSpan(ctx context.Context, method, smpath string) (context.Context, trace.Span) {
+	ctx, span := tracing.StartSpan(ctx, "sm "+operationFromContext(ctx),
+		attribute.String("http.request.method", method),
+		attribute.String("url.path", smpath),
+		tracing.CorrelationIDKey.String(logutils.GetCorrelationID(ctx)))
+	return ctx, span
+}
+
+func endRequestSpan(span trace.Span, resp *http.Response, err error) {
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	tracing.End(span, err)
+}
@@ -0,0 +1,69 @@
+package sm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/SAP/sap-btp-service-operator/internal/tracing"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		server         *httptest.Server
+		traceparent    string
+		exporter       *tracetest.InMemoryExporter
+		previous       trace.TracerProvider
+		prevPropagator propagation.TextMapPropagator
+	)
+
+	BeforeEach(func() {
+		previous = otel.GetTracerProvider()
+		prevPropagator = otel.GetTextMapPropagator()
+		exporter = tracetest.NewInMemoryExporter()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "instanceID"}`))
+		}))
+		var err error
+		client, err = NewClient(context.TODO(), &ClientConfig{URL: server.URL}, http.DefaultClient)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	It("creates a child span per request and propagates it to Service Manager", func() {
+		ctx := context.WithValue(context.TODO(), logutils.CorrelationIDKey, "correlation-id")
+		ctx, parent := tracing.StartSpan(ctx, "parent")
+		_, err := client.GetInstanceByID(ctx, "instanceID", nil)
+		Expect(err).ToNot(HaveOccurred())
+		parent.End()
+
+		spans := exporter.GetSpans()
+		Expect(spans).To(HaveLen(2))
+		requestSpan := spans[0]
+		Expect(requestSpan.Name).To(Equal("sm " + OperationGetInstance))
+		Expect(requestSpan.Parent.SpanID()).To(Equal(parent.SpanContext().SpanID()))
+		Expect(requestSpan.Attributes).To(ContainElement(tracing.CorrelationIDKey.String("correlation-id")))
+		Expect(traceparent).To(ContainSubstring(requestSpan.SpanContext.SpanID().String()))
+		Expect(traceparent).To(ContainSubstring(parent.SpanContext().TraceID().String()))
+	})
+})
@@ -30,11 +30,12 @@ type ServicePlan struct {
 	CreatedAt   string `json:"created_at,omitempty" yaml:"created_at,omitempty"`
 	UpdatedAt   string `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
 
-	CatalogID     string `json:"catalog_id,omitempty" yaml:"catalog_id,omitempty"`
-	CatalogName   string `json:"catalog_name,omitempty" yaml:"catalog_name,omitempty"`
-	Free          bool   `json:"free,omitempty" yaml:"free,omitempty"`
-	Bindable      bool   `json:"bindable,omitempty" yaml:"bindable,omitempty"`
-	PlanUpdatable bool   `json:"plan_updateable,omitempty" yaml:"plan_updateable,omitempty"`
+	CatalogID   string `json:"catalog_id,omitempty" yaml:"catalog_id,omitempty"`
+	CatalogName string `json:"catalog_name,omitempty" yaml:"catalog_name,omitempty"`
+	Free        bool   `json:"free,omitempty" yaml:"free,omitempty"`
+	Bindable    bool   `json:"bindable,omitempty" yaml:"bindable,omitempty"`
+	// PlanUpdatable overrides the plan_updateable value of the service offering when set
+	PlanUpdatable *bool `json:"plan_updateable,omitempty" yaml:"plan_updateable,omitempty"`
 
 	Metadata json.RawMessage `json:"metadata,omitempty" yaml:"-"`
 	Schemas  json.RawMessage `json:"schemas,omitempty" yaml:"-"`
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// adopt brings the SM instance referenced by spec.instanceID under the management of the k8s instance instead of provisioning a new one.
+// The instance is labeled like the instances the operator provisions, instances owned by another cluster are not adopted.
+func (r *ServiceInstanceReconciler) adopt(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	instanceID := serviceInstance.Spec.InstanceID
+
+	log.Info(fmt.Sprintf("adopting instance %s from SM", instanceID))
+	smInstance, err := smClient.GetInstanceByID(ctx, instanceID, &sm.Parameters{GeneralParams: []string{"attach_last_operations=true"}})
+	if err != nil {
+		log.Error(err, fmt.Sprintf("failed to get instance %s from SM", instanceID))
+		return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.CREATE, err, true)
+	}
+
+	refusal, err := r.verifyAdoption(ctx, serviceInstance, smInstance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(refusal) > 0 {
+		log.Info(refusal)
+		r.Recorder.Eventf(serviceInstance, nil, corev1.EventTypeWarning, common.AdoptionRefused, actionAdopt, "%s", refusal)
+		utils.SetAdoptionRefusedCondition(refusal, serviceInstance)
+		return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
+	}
+
+	desiredLabels := utils.OwnerLabels(serviceInstance.Namespace, serviceInstance.Name, r.Config.ClusterID)
+	if err := relabelInstance(ctx, smClient, smInstance, desiredLabels); err != nil {
+		log.Error(err, fmt.Sprintf("failed to label instance %s in SM", instanceID))
+		return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.CREATE, err, true)
+	}
+
+	r.Recorder.Eventf(serviceInstance, nil, corev1.EventTypeNormal, common.Adopted, actionAdopt, "adopted instance %s from Service Manager", instanceID)
+	return r.importInstance(ctx, smClient, serviceInstance, smInstance)
+}
+
+// verifyAdoption returns the reason the SM instance can't be adopted by the k8s instance, the SM instance may be managed by
+// another cluster or by another k8s instance in this cluster. Deleting the other k8s instance would deprovision it.
+func (r *ServiceInstanceReconciler) verifyAdoption(ctx context.Context, serviceInstance *v1.ServiceInstance, smInstance *smClientTypes.ServiceInstance) (string, error) {
+	owner := utils.OwnerClusterID(smInstance.Labels)
+	if len(owner) > 0 && owner != r.Config.ClusterID {
+		return fmt.Sprintf("instance %s is managed by cluster %s and can't be adopted", serviceInstance.Spec.InstanceID, owner), nil
+	}
+
+	if len(smInstance.Labels[common.NamespaceLabel]) == 0 || len(smInstance.Labels[common.K8sNameLabel]) == 0 {
+		return "", nil
+	}
+	key := types.NamespacedName{Namespace: smInstance.Labels[common.NamespaceLabel][0], Name: smInstance.Labels[common.K8sNameLabel][0]}
+	if key.Namespace == serviceInstance.Namespace && key.Name == serviceInstance.Name {
+		return "", nil
+	}
+	managingInstance := &v1.ServiceInstance{}
+	if err := r.Client.Get(ctx, key, managingInstance); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	if managingInstance.Status.InstanceID == smInstance.ID || managingInstance.Spec.InstanceID == smInstance.ID {
+		return fmt.Sprintf("instance %s is managed by ServiceInstance %s and can't be adopted", serviceInstance.Spec.InstanceID, key), nil
+	}
+	return "", nil
+}
+
+// relabelInstance sets the desired labels on the SM instance
+func relabelInstance(ctx context.Context, smClient sm.Client, smInstance *smClientTypes.ServiceInstance, desiredLabels smClientTypes.Labels) error {
+	return utils.ApplyLabelChanges(smInstance.Labels, desiredLabels, func(changes []*smClientTypes.LabelChange) error {
+		_, err := smClient.UpdateInstanceLabels(ctx, smInstance.ID, changes)
+		return err
+	})
+}
+
+// relabelBinding sets the desired labels on the SM binding the same way relabelInstance does
+func relabelBinding(ctx context.Context, smClient sm.Client, smBinding *smClientTypes.ServiceBinding, desiredLabels smClientTypes.Labels) error {
+	return utils.ApplyLabelChanges(smBinding.Labels, desiredLabels, func(changes []*smClientTypes.LabelChange) error {
+		_, err := smClient.UpdateBindingLabels(ctx, smBinding.ID, changes)
+		return err
+	})
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ConfigMapReconciler wakes up service instances when a config map they take parameters from changes
+type ConfigMapReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;update;patch
+
+func (r *ConfigMapReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	correlationID := uuid.New().String()
+	log := r.Log.WithValues("configmap", req.NamespacedName).WithValues("correlation_id", correlationID)
+	ctx = context.WithValue(ctx, logutils.LogKey, log)
+	ctx = context.WithValue(ctx, logutils.CorrelationIDKey, correlationID)
+	log.Info(fmt.Sprintf("reconciling params config map %s", req.NamespacedName))
+	// Fetch the ConfigMap
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, req.NamespacedName, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch ConfigMap")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	instances := &v1.ServiceInstanceList{}
+	labelSelector := client.MatchingLabels{utils.GetLabelKeyForInstanceConfigMap(configMap.Name): configMap.Name}
+	if err := r.Client.List(ctx, instances, client.InNamespace(configMap.Namespace), labelSelector); err != nil {
+		log.Error(err, "failed to list service instances")
+		return ctrl.Result{}, err
+	}
+
+	for _, instance := range instances.Items {
+		log.Info(fmt.Sprintf("waking up referencing instance %s", instance.Name))
+		instance.Status.ForceReconcile = true
+		err := utils.UpdateStatus(ctx, r.Client, &instance)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if utils.IsMarkedForDeletion(configMap.ObjectMeta) {
+		log.Info("config map is marked for deletion, removing finalizer")
+		return ctrl.Result{}, utils.RemoveFinalizer(ctx, r.Client, configMap, common.FinalizerName)
+	}
+
+	log.Info("finished reconciling params config map")
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ConfigMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchLabels: map[string]string{common.WatchConfigMapLabel: "true"},
+	})
+	if err != nil {
+		return err
+	}
+
+	dataChangedPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldConfigMap := e.ObjectOld.(*corev1.ConfigMap)
+			newConfigMap := e.ObjectNew.(*corev1.ConfigMap)
+			return (utils.IsConfigMapWatched(newConfigMap) && isConfigMapDataChanged(oldConfigMap, newConfigMap)) || isConfigMapInDelete(newConfigMap)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return utils.IsConfigMapWatched(e.Object)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return utils.IsConfigMapWatched(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return utils.IsConfigMapWatched(e.Object)
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(labelSelectorPredicate, dataChangedPredicate)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
+		Complete(r)
+}
+
+func isConfigMapDataChanged(oldConfigMap, newConfigMap *corev1.ConfigMap) bool {
+	return !reflect.DeepEqual(oldConfigMap.Data, newConfigMap.Data) || !reflect.DeepEqual(oldConfigMap.BinaryData, newConfigMap.BinaryData)
+}
+
+func isConfigMapInDelete(configMap *corev1.ConfigMap) bool {
+	return !configMap.GetDeletionTimestamp().IsZero() && controllerutil.ContainsFinalizer(configMap, common.FinalizerName)
+}
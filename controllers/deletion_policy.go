@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// retainInstance releases the instance in SM instead of deprovisioning it. The labels that tie it to the k8s instance and to
+// this cluster are removed so it is neither recovered nor reported as owned by this cluster, and can be adopted again.
+func (r *ServiceInstanceReconciler) retainInstance(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	instanceID := serviceInstance.Status.InstanceID
+
+	log.Info(fmt.Sprintf("deletion policy is %s, releasing instance %s in SM", v1.DeletionPolicyRetain, instanceID))
+	smInstance, err := smClient.GetInstanceByID(ctx, instanceID, nil)
+	if err != nil && !isNotFoundInSM(err) {
+		log.Error(err, fmt.Sprintf("failed to get instance %s from SM", instanceID))
+		return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.DELETE, err, true)
+	}
+	if err == nil {
+		if removals := utils.OwnerLabelRemovals(smInstance.Labels); len(removals) > 0 {
+			if _, err := smClient.UpdateInstanceLabels(ctx, instanceID, removals); err != nil {
+				log.Error(err, fmt.Sprintf("failed to remove the labels of instance %s in SM", instanceID))
+				return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.DELETE, err, true)
+			}
+		}
+	}
+
+	return r.releaseInstance(ctx, serviceInstance, common.Retained, fmt.Sprintf("instance %s was retained in Service Manager", instanceID))
+}
+
+// releaseInstance stops managing the instance without deleting it from SM and removes the finalizer
+func (r *ServiceInstanceReconciler) releaseInstance(ctx context.Context, serviceInstance *v1.ServiceInstance, reason, message string) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	if err := r.unwatchParametersSources(ctx, serviceInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	serviceInstance.Status.InstanceID = ""
+	if err := r.Client.Status().Update(ctx, serviceInstance); err != nil {
+		log.Error(err, "failed to update service instance status after release")
+		return ctrl.Result{}, err
+	}
+	log.Info("Instance was released successfully, removing finalizer")
+	r.Recorder.Eventf(serviceInstance, nil, corev1.EventTypeNormal, reason, actionDelete, "%s", message)
+	return ctrl.Result{}, utils.RemoveFinalizer(ctx, r.Client, serviceInstance, common.FinalizerName)
+}
+
+// retainBinding releases the binding in SM instead of deleting it, the same way retainInstance releases instances
+func (r *ServiceBindingReconciler) retainBinding(ctx context.Context, smClient sm.Client, serviceBinding *v1.ServiceBinding) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	bindingID := serviceBinding.Status.BindingID
+
+	log.Info(fmt.Sprintf("deletion policy is %s, releasing binding %s in SM", v1.DeletionPolicyRetain, bindingID))
+	smBinding, err := smClient.GetBindingByID(ctx, bindingID, nil)
+	if err != nil && !isNotFoundInSM(err) {
+		log.Error(err, fmt.Sprintf("failed to get binding %s from SM", bindingID))
+		return utils.HandleServiceManagerError(ctx, r.Client, serviceBinding, smClientTypes.DELETE, err, true)
+	}
+	if err == nil {
+		if removals := utils.OwnerLabelRemovals(smBinding.Labels); len(removals) > 0 {
+			if _, err := smClient.UpdateBindingLabels(ctx, bindingID, removals); err != nil {
+				log.Error(err, fmt.Sprintf("failed to remove the labels of binding %s in SM", bindingID))
+				return utils.HandleServiceManagerError(ctx, r.Client, serviceBinding, smClientTypes.DELETE, err, true)
+			}
+		}
+	}
+
+	return r.releaseBinding(ctx, serviceBinding, common.Retained, fmt.Sprintf("binding %s was retained in Service Manager", bindingID))
+}
+
+// releaseBinding stops managing the binding without deleting it from SM, deletes its secret and removes the finalizer
+func (r *ServiceBindingReconciler) releaseBinding(ctx context.Context, serviceBinding *v1.ServiceBinding, reason, message string) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	serviceBinding.Status.BindingID = ""
+	if err := utils.UpdateStatus(ctx, r.Client, serviceBinding); err != nil {
+		log.Error(err, "unable to update ServiceBinding status after release")
+		return ctrl.Result{}, err
+	}
+	log.Info("Binding was released successfully")
+	r.Recorder.Eventf(serviceBinding, nil, corev1.EventTypeNormal, reason, actionDelete, "%s", message)
+	return r.deleteSecretAndRemoveFinalizer(ctx, serviceBinding)
+}
+
+func isNotFoundInSM(err error) bool {
+	var smError *sm.ServiceManagerError
+	return errors.As(err, &smError) && smError.StatusCode == http.StatusNotFound
+}
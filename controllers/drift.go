@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkDrift compares the instance in SM with its spec once per drift check interval and reports the result in the Drifted condition.
+// It returns the time until the next check is due, zero when drift detection is disabled for the instance.
+func (r *ServiceInstanceReconciler) checkDrift(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance, smInstance *smClientTypes.ServiceInstance) (time.Duration, error) {
+	log := logutils.GetLogger(ctx)
+	interval := r.Config.DriftCheckInterval
+	if interval <= 0 || serviceInstance.GetDriftPolicy() == v1.DriftPolicyIgnore || smInstance == nil {
+		if utils.RemoveDriftedCondition(serviceInstance) {
+			log.Info("drift detection is disabled for the instance, removing drifted condition")
+			return 0, utils.UpdateStatus(ctx, r.Client, serviceInstance)
+		}
+		return 0, nil
+	}
+
+	if lastCheck := serviceInstance.Status.LastDriftCheckTime; lastCheck != nil {
+		if remaining := time.Until(lastCheck.Add(interval)); remaining > 0 {
+			return remaining, nil
+		}
+	}
+
+	log.Info(fmt.Sprintf("checking instance %s for drift", serviceInstance.Status.InstanceID))
+	now := metav1.Now()
+	serviceInstance.Status.LastDriftCheckTime = &now
+	drift, err := r.detectDrift(ctx, smClient, serviceInstance, smInstance)
+	if err != nil {
+		// the spec can't be resolved right now, the next check will tell
+		log.Info(fmt.Sprintf("failed to check instance for drift: %s", err.Error()))
+		return interval, utils.UpdateStatus(ctx, r.Client, serviceInstance)
+	}
+
+	if len(drift) == 0 {
+		utils.SetDriftedCondition(serviceInstance, metav1.ConditionFalse, common.NoDrift, "instance matches its spec")
+		return interval, utils.UpdateStatus(ctx, r.Client, serviceInstance)
+	}
+
+	message := fmt.Sprintf("instance differs from its spec: %s", strings.Join(drift, "; "))
+	log.Info(message)
+	if utils.SetDriftedCondition(serviceInstance, metav1.ConditionTrue, common.DriftDetected, message) {
+		r.Recorder.Eventf(serviceInstance, nil, corev1.EventTypeWarning, common.DriftDetected, actionVerify, "%s", message)
+	}
+	if serviceInstance.GetDriftPolicy() == v1.DriftPolicyReapply {
+		r.reapplySpec(ctx, serviceInstance, smInstance, len(drift))
+	}
+	return interval, utils.UpdateStatus(ctx, r.Client, serviceInstance)
+}
+
+// detectDrift lists the differences between the instance in SM and its spec, parameter values are redacted.
+// Parameters are compared only when SM can return the parameters of the instance, and only those set in the spec.
+func (r *ServiceInstanceReconciler) detectDrift(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance, smInstance *smClientTypes.ServiceInstance) ([]string, error) {
+	parameters, _, err := utils.BuildSMRequestParameters(ctx, serviceInstance.Namespace, serviceInstance.Spec.Parameters, serviceInstance.Spec.ParametersFrom)
+	if err != nil {
+		return nil, err
+	}
+	plan, _, err := smClient.GetPlan(ctx, serviceInstance.Spec.ServicePlanID, serviceInstance.Spec.ServiceOfferingName, serviceInstance.Spec.ServicePlanName, serviceInstance.Spec.DataCenter)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedParameters, err := getAppliedParameters(ctx, smClient, serviceInstance.Status.InstanceID)
+	if err != nil {
+		logutils.GetLogger(ctx).Info(fmt.Sprintf("parameters of instance %s are not compared, failed to get them: %s", serviceInstance.Status.InstanceID, err.Error()))
+	}
+	changes, err := instanceChanges(smInstance, serviceInstance, plan.ID, nil, parameters)
+	if err != nil || appliedParameters == nil {
+		return changes, err
+	}
+	// parameters the broker added or defaulted are not in the spec, an update can't remove them
+	parametersChanges, err := utils.DiffRequestedParameters(appliedParameters, parameters)
+	if err != nil {
+		return nil, err
+	}
+	return append(changes, parametersChanges...), nil
+}
+
+// reapplySpec makes the next reconcile send the spec to SM again. A drifted shared state is fixed by (un)sharing the instance,
+// any other drift by updating it. Each drift is re-applied at most once per drift check interval.
+func (r *ServiceInstanceReconciler) reapplySpec(ctx context.Context, serviceInstance *v1.ServiceInstance, smInstance *smClientTypes.ServiceInstance, driftCount int) {
+	log := logutils.GetLogger(ctx)
+	if smInstance.Shared != serviceInstance.GetShared() {
+		log.Info("re-applying the shared state of the instance")
+		sharedStatus := metav1.ConditionFalse
+		if smInstance.Shared {
+			sharedStatus = metav1.ConditionTrue
+		}
+		utils.SetSharedCondition(serviceInstance, sharedStatus, common.DriftDetected, "shared state in Service Manager differs from the spec")
+		driftCount--
+	}
+	if driftCount > 0 {
+		log.Info("re-applying the spec of the instance")
+		serviceInstance.Status.ForceReconcile = true
+	}
+}
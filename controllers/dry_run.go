@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// dryRun publishes the request the reconciler would send to SM for the current spec of the instance.
+// Only read endpoints of SM are called, the instance itself is left untouched until the annotation is removed.
+func (r *ServiceInstanceReconciler) dryRun(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance, smInstance *smClientTypes.ServiceInstance) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	log.Info("instance is in dry run mode, computing the request without sending it to SM")
+
+	preview := &v1.DryRunStatus{
+		Operation:          dryRunInstanceOperation(serviceInstance),
+		ServicePlanID:      serviceInstance.Status.ServicePlanID,
+		ObservedGeneration: serviceInstance.Generation,
+	}
+	switch preview.Operation {
+	case v1.DryRunCreate, v1.DryRunUpdate:
+		if err := r.previewInstanceRequest(ctx, smClient, serviceInstance, smInstance, preview); err != nil {
+			log.Info(fmt.Sprintf("the request would be rejected: %s", err.Error()))
+			preview.Error = err.Error()
+		}
+	case v1.DryRunAdopt:
+		if err := r.previewAdoption(ctx, smClient, serviceInstance, preview); err != nil {
+			log.Info(fmt.Sprintf("the instance would not be adopted: %s", err.Error()))
+			preview.Error = err.Error()
+		}
+	case v1.DryRunShare, v1.DryRunUnShare:
+		preview.Changes = []string{utils.DiffField("shared", !serviceInstance.GetShared(), serviceInstance.GetShared())}
+	}
+
+	if reflect.DeepEqual(serviceInstance.Status.DryRun, preview) {
+		return ctrl.Result{}, nil
+	}
+	serviceInstance.Status.DryRun = preview
+	recordDryRunEvent(r.Recorder, serviceInstance, preview)
+	return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
+}
+
+func dryRunInstanceOperation(serviceInstance *v1.ServiceInstance) v1.DryRunOperation {
+	switch {
+	case len(serviceInstance.Status.InstanceID) == 0 && len(serviceInstance.Spec.InstanceID) > 0:
+		return v1.DryRunAdopt
+	case len(serviceInstance.Status.InstanceID) == 0:
+		return v1.DryRunCreate
+	case serviceInstance.Status.ForceReconcile || serviceInstance.GetSpecHash() != serviceInstance.Status.HashedSpec:
+		return v1.DryRunUpdate
+	case shareOrUnshareRequired(serviceInstance):
+		if serviceInstance.GetShared() {
+			return v1.DryRunShare
+		}
+		return v1.DryRunUnShare
+	}
+	return v1.DryRunNone
+}
+
+// previewInstanceRequest resolves the parameters and the plan of the request and diffs them against the instance in SM.
+// The returned error is the reason the reconciler would reject the request before sending it.
+func (r *ServiceInstanceReconciler) previewInstanceRequest(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance,
+	smInstance *smClientTypes.ServiceInstance, preview *v1.DryRunStatus) error {
+	parameters, _, err := utils.BuildSMRequestParameters(ctx, serviceInstance.Namespace, serviceInstance.Spec.Parameters, serviceInstance.Spec.ParametersFrom)
+	if err != nil {
+		return err
+	}
+	plan, _, err := smClient.GetPlan(ctx, serviceInstance.Spec.ServicePlanID, serviceInstance.Spec.ServiceOfferingName, serviceInstance.Spec.ServicePlanName, serviceInstance.Spec.DataCenter)
+	if err != nil {
+		return err
+	}
+	preview.ServicePlanID = plan.ID
+
+	appliedParameters := []byte("{}")
+	if preview.Operation == v1.DryRunUpdate {
+		appliedParameters, err = getAppliedParameters(ctx, smClient, serviceInstance.Status.InstanceID)
+		if err != nil {
+			logutils.GetLogger(ctx).Info(fmt.Sprintf("failed to get the parameters of instance %s: %s", serviceInstance.Status.InstanceID, err.Error()))
+		}
+	} else {
+		smInstance = nil
+	}
+
+	preview.Changes, err = instanceChanges(smInstance, serviceInstance, plan.ID, appliedParameters, parameters)
+	if err != nil {
+		return err
+	}
+	if appliedParameters == nil {
+		preview.Changes = append(preview.Changes, "parameters: the applied parameters can't be retrieved from Service Manager")
+	}
+
+	schemaOperation := utils.InstanceCreateSchema
+	if preview.Operation == v1.DryRunUpdate {
+		schemaOperation = utils.InstanceUpdateSchema
+		err := utils.ValidatePlanTransition(ctx, smClient, serviceInstance.Status.ServicePlanID, serviceInstance)
+		var planErr *utils.PlanNotUpdatableError
+		if errors.As(err, &planErr) {
+			return planErr
+		}
+	}
+	return validateParametersSchema(ctx, r.Recorder, r.Config, smClient, serviceInstance, serviceInstance, schemaOperation, parameters)
+}
+
+// previewAdoption lists the labels that would be set on the instance referenced by spec.instanceID
+func (r *ServiceInstanceReconciler) previewAdoption(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance, preview *v1.DryRunStatus) error {
+	smInstance, err := smClient.GetInstanceByID(ctx, serviceInstance.Spec.InstanceID, nil)
+	if err != nil {
+		return err
+	}
+	preview.ServicePlanID = smInstance.ServicePlanID
+	refusal, err := r.verifyAdoption(ctx, serviceInstance, smInstance)
+	if err != nil {
+		return err
+	}
+	if len(refusal) > 0 {
+		return errors.New(refusal)
+	}
+
+	_, additions := utils.LabelChanges(smInstance.Labels, utils.OwnerLabels(serviceInstance.Namespace, serviceInstance.Name, r.Config.ClusterID))
+	for _, label := range additions {
+		preview.Changes = append(preview.Changes, utils.DiffField("labels."+label.Key, smInstance.Labels[label.Key], label.Values))
+	}
+	return nil
+}
+
+// instanceChanges lists the differences between the instance in SM and the requested state, parameter values are redacted.
+// A nil smInstance is compared as an instance that does not exist yet, nil applied parameters are not compared.
+func instanceChanges(smInstance *smClientTypes.ServiceInstance, serviceInstance *v1.ServiceInstance, planID string, appliedParameters, parameters []byte) ([]string, error) {
+	applied := smInstance
+	if applied == nil {
+		applied = &smClientTypes.ServiceInstance{}
+	}
+
+	var changes []string
+	for _, change := range []string{
+		utils.DiffField("name", applied.Name, serviceInstance.Spec.ExternalName),
+		utils.DiffField("servicePlanID", applied.ServicePlanID, planID),
+		utils.DiffField("shared", applied.Shared, serviceInstance.GetShared()),
+	} {
+		if len(change) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	if appliedParameters == nil {
+		return changes, nil
+	}
+
+	parametersChanges, err := utils.DiffParameters(appliedParameters, parameters)
+	if err != nil {
+		return nil, err
+	}
+	return append(changes, parametersChanges...), nil
+}
+
+func getAppliedParameters(ctx context.Context, smClient sm.Client, instanceID string) ([]byte, error) {
+	parameters, err := smClient.GetInstanceParameters(ctx, instanceID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(parameters)
+}
+
+// dryRun publishes the request the reconciler would send to SM to create the binding.
+// Bindings can't be updated in SM, a binding whose parameters changed is previewed as an update since it is created again.
+func (r *ServiceBindingReconciler) dryRun(ctx context.Context, smClient sm.Client, serviceBinding *v1.ServiceBinding, serviceInstance *v1.ServiceInstance) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	log.Info("binding is in dry run mode, computing the request without sending it to SM")
+
+	preview := &v1.DryRunStatus{
+		Operation:          v1.DryRunNone,
+		ObservedGeneration: serviceBinding.Generation,
+	}
+	if len(serviceBinding.Status.BindingID) == 0 {
+		preview.Operation = v1.DryRunCreate
+	} else if len(serviceBinding.Status.HashedParameters) > 0 && parametersChanged(serviceBinding) {
+		preview.Operation = v1.DryRunUpdate
+	}
+	if preview.Operation != v1.DryRunNone {
+		if err := r.previewBindingRequest(ctx, smClient, serviceBinding, serviceInstance, preview); err != nil {
+			log.Info(fmt.Sprintf("the request would be rejected: %s", err.Error()))
+			preview.Error = err.Error()
+		}
+	}
+
+	if reflect.DeepEqual(serviceBinding.Status.DryRun, preview) {
+		return ctrl.Result{}, nil
+	}
+	serviceBinding.Status.DryRun = preview
+	recordDryRunEvent(r.Recorder, serviceBinding, preview)
+	return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceBinding)
+}
+
+func (r *ServiceBindingReconciler) previewBindingRequest(ctx context.Context, smClient sm.Client, serviceBinding *v1.ServiceBinding, serviceInstance *v1.ServiceInstance, preview *v1.DryRunStatus) error {
+	parameters, _, err := utils.BuildSMRequestParameters(ctx, serviceBinding.Namespace, serviceBinding.Spec.Parameters, serviceBinding.Spec.ParametersFrom)
+	if err != nil {
+		return err
+	}
+
+	if preview.Operation == v1.DryRunUpdate {
+		// the parameters of the existing binding can't be read from SM
+		preview.Changes = []string{"parameters: changed, the binding is created again"}
+	} else {
+		preview.Changes = []string{utils.DiffField("name", "", serviceBinding.Spec.ExternalName)}
+		changes, err := utils.DiffParameters(nil, parameters)
+		if err != nil {
+			return err
+		}
+		preview.Changes = append(preview.Changes, changes...)
+	}
+	return validateParametersSchema(ctx, r.Recorder, r.Config, smClient, serviceBinding, serviceInstance, utils.BindingCreateSchema, parameters)
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+)
+
+// event actions, the reasons are the condition reasons from api/common
+const (
+	actionCreate            = "Create"
+	actionUpdate            = "Update"
+	actionDelete            = "Delete"
+	actionShare             = "Share"
+	actionUnShare           = "UnShare"
+	actionRecover           = "Recover"
+	actionAdopt             = "Adopt"
+	actionHandover          = "Handover"
+	actionVerify            = "Verify"
+	actionStoreSecret       = "StoreSecret"
+	actionReplicateSecret   = "ReplicateSecret"
+	actionRotateCredentials = "RotateCredentials"
+	actionRebind            = "Rebind"
+	actionDeleteStale       = "DeleteStale"
+	actionDryRun            = "DryRun"
+)
+
+func operationAction(operation smClientTypes.OperationCategory) string {
+	switch operation {
+	case smClientTypes.CREATE:
+		return actionCreate
+	case smClientTypes.UPDATE:
+		return actionUpdate
+	case smClientTypes.DELETE:
+		return actionDelete
+	}
+	return string(operation)
+}
+
+// recordOperationEvent emits an event for an operation of the object reaching the given state,
+// the reason matches the one of the Succeeded condition and failures are emitted as warnings
+func recordOperationEvent(recorder events.EventRecorder, object runtime.Object, operation smClientTypes.OperationCategory, state smClientTypes.OperationState, note string) {
+	eventType := corev1.EventTypeNormal
+	if state == smClientTypes.FAILED {
+		eventType = corev1.EventTypeWarning
+	}
+	recorder.Eventf(object, nil, eventType, utils.GetConditionReason(operation, state), operationAction(operation), "%s", note)
+}
+
+func recordDryRunEvent(recorder events.EventRecorder, object runtime.Object, preview *v1.DryRunStatus) {
+	note := fmt.Sprintf("dry run computed, operation: %s", preview.Operation)
+	if len(preview.Error) > 0 {
+		note = fmt.Sprintf("%s, the request would be rejected: %s", note, preview.Error)
+	}
+	recorder.Eventf(object, nil, corev1.EventTypeNormal, common.DryRunComputed, actionDryRun, "%s", note)
+}
+
+func recordNotFoundEvent(recorder events.EventRecorder, object runtime.Object, note string) {
+	recorder.Eventf(object, nil, corev1.EventTypeWarning, common.ResourceNotFound, actionVerify, "%s", note)
+}
+
+// progressChanged reports whether the description of an ongoing async operation differs from the one already
+// reported in the Succeeded condition, so that progress events are emitted once per change rather than once per poll
+func progressChanged(object common.SAPBTPResource, description string) bool {
+	condition := meta.FindStatusCondition(object.GetConditions(), common.ConditionSucceeded)
+	return condition == nil || condition.Message != description
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+)
+
+var _ = Describe("Events", func() {
+	var (
+		recorder *events.FakeRecorder
+		instance *v1.ServiceInstance
+	)
+
+	BeforeEach(func() {
+		recorder = events.NewFakeRecorder(10)
+		instance = &v1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"}}
+	})
+
+	It("uses the condition reason of the operation state", func() {
+		recordOperationEvent(recorder, instance, smClientTypes.CREATE, smClientTypes.INPROGRESS, "provisioning started")
+		Expect(<-recorder.Events).To(Equal("Normal " + common.CreateInProgress + " provisioning started"))
+
+		recordOperationEvent(recorder, instance, smClientTypes.UPDATE, smClientTypes.SUCCEEDED, "updated")
+		Expect(<-recorder.Events).To(Equal("Normal " + common.Updated + " updated"))
+	})
+
+	It("emits failures as warnings", func() {
+		recordOperationEvent(recorder, instance, smClientTypes.DELETE, smClientTypes.FAILED, "100% broken")
+		Expect(<-recorder.Events).To(Equal("Warning " + common.DeleteFailed + " 100% broken"))
+	})
+
+	It("emits progress only when the description changed", func() {
+		Expect(progressChanged(instance, "step 1")).To(BeTrue())
+		instance.Status.Conditions = []metav1.Condition{{Type: common.ConditionSucceeded, Status: metav1.ConditionFalse, Message: "step 1"}}
+		Expect(progressChanged(instance, "step 1")).To(BeFalse())
+		Expect(progressChanged(instance, "step 2")).To(BeTrue())
+	})
+})
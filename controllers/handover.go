@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// handover hands the instance and the bindings this cluster manages for it over to the namespace, and optionally the cluster,
+// set in the handoverTo annotation. Only the labels in SM are changed: k8s resources with the same names in the target
+// recover the instance and its bindings, the resources in the source are released when they are deleted.
+func (r *ServiceInstanceReconciler) handover(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance, smInstance *smClientTypes.ServiceInstance) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	target := serviceInstance.Annotations[common.HandoverToAnnotation]
+	clusterID, namespace, _ := serviceInstance.GetHandoverTarget()
+	if len(clusterID) == 0 {
+		clusterID = r.Config.ClusterID
+	}
+	desiredLabels := smClientTypes.Labels{
+		common.NamespaceLabel: []string{namespace},
+		common.ClusterIDLabel: []string{clusterID},
+	}
+
+	log.Info(fmt.Sprintf("handing over instance %s to %s", serviceInstance.Status.InstanceID, target))
+	bindings, err := smClient.ListBindings(ctx, &sm.Parameters{
+		FieldQuery: []string{fmt.Sprintf("service_instance_id eq '%s'", serviceInstance.Status.InstanceID)},
+	})
+	if err != nil {
+		log.Error(err, "failed to list the bindings of the instance in SM")
+		return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.UPDATE, err, true)
+	}
+	bindingCount := 0
+	if bindings != nil {
+		for i := range bindings.ServiceBindings {
+			smBinding := &bindings.ServiceBindings[i]
+			if utils.OwnerClusterID(smBinding.Labels) != r.Config.ClusterID {
+				// bindings of other clusters to a shared instance, or bindings already handed over to another cluster
+				continue
+			}
+			if err := relabelBinding(ctx, smClient, smBinding, desiredLabels); err != nil {
+				log.Error(err, fmt.Sprintf("failed to hand over binding %s", smBinding.ID))
+				return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.UPDATE, err, true)
+			}
+			bindingCount++
+		}
+	}
+
+	if smInstance != nil {
+		if err := relabelInstance(ctx, smClient, smInstance, desiredLabels); err != nil {
+			log.Error(err, fmt.Sprintf("failed to hand over instance %s", smInstance.ID))
+			return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.UPDATE, err, true)
+		}
+	}
+
+	metrics.Provisions.Forget(serviceInstance.UID)
+	serviceInstance.Status.HandedOverTo = target
+	r.Recorder.Eventf(serviceInstance, nil, corev1.EventTypeNormal, common.HandedOver, actionHandover, "instance %s and %d bindings were handed over to %s", serviceInstance.Status.InstanceID, bindingCount, target)
+	return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
+}
+
+// releaseHandedOverInstance releases a handed over instance once the bindings to it are released, a binding deleted
+// after its instance would otherwise be deleted from SM
+func (r *ServiceInstanceReconciler) releaseHandedOverInstance(ctx context.Context, serviceInstance *v1.ServiceInstance) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	bindings := &v1.ServiceBindingList{}
+	if err := r.Client.List(ctx, bindings); err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, binding := range bindings.Items {
+		instanceNamespace := binding.Namespace
+		if len(binding.Spec.ServiceInstanceNamespace) > 0 {
+			instanceNamespace = binding.Spec.ServiceInstanceNamespace
+		}
+		if binding.Spec.ServiceInstanceName == serviceInstance.Name && instanceNamespace == serviceInstance.Namespace {
+			log.Info(fmt.Sprintf("instance was handed over, waiting for binding %s/%s to be released", binding.Namespace, binding.Name))
+			return ctrl.Result{RequeueAfter: r.Config.PollInterval}, nil
+		}
+	}
+
+	return r.releaseInstance(ctx, serviceInstance, common.HandedOver, fmt.Sprintf("instance %s was released, it was handed over to %s", serviceInstance.Status.InstanceID, serviceInstance.Status.HandedOverTo))
+}
+
+// handleHandedOverBinding leaves a binding to an instance that was handed over alone, the binding is managed by the target.
+// Rotated bindings are still deleted from SM when they expire.
+func (r *ServiceBindingReconciler) handleHandedOverBinding(ctx context.Context, serviceBinding *v1.ServiceBinding, serviceInstance *v1.ServiceInstance) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	if utils.IsMarkedForDeletion(serviceBinding.ObjectMeta) {
+		return r.releaseBinding(ctx, serviceBinding, common.HandedOver, fmt.Sprintf("binding %s was released, its instance was handed over to %s", serviceBinding.Status.BindingID, serviceInstance.Status.HandedOverTo))
+	}
+	log.Info(fmt.Sprintf("instance of the binding was handed over to %s, nothing to do", serviceInstance.Status.HandedOverTo))
+	return ctrl.Result{}, nil
+}
+
+// handedOverQuery returns the label query that finds an SM resource handed over to the k8s resource, the context of a
+// handed over resource still refers to the namespace and cluster it was created in
+func handedOverQuery(namespace, name, clusterID string) []string {
+	return []string{
+		fmt.Sprintf("%s eq '%s'", common.ClusterIDLabel, clusterID),
+		fmt.Sprintf("%s eq '%s'", common.NamespaceLabel, namespace),
+		fmt.Sprintf("%s eq '%s'", common.K8sNameLabel, name),
+	}
+}
+
+// handedOverElsewhere reports whether an SM resource found by its context was handed over to another namespace or cluster
+func handedOverElsewhere(labels smClientTypes.Labels, namespace, clusterID string) bool {
+	owner := utils.OwnerClusterID(labels)
+	namespaces := labels[common.NamespaceLabel]
+	return (len(owner) > 0 && owner != clusterID) || (len(namespaces) > 0 && namespaces[0] != namespace)
+}
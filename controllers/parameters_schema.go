@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/internal/config"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+)
+
+// validateParametersSchema validates the parameters against the schema of the instance plan before they are sent to SM.
+// An error is returned only when the parameters violate the schema and the validation is enforced, when the plan
+// or its schema can't be resolved the validation is skipped and SM has the final say.
+func validateParametersSchema(ctx context.Context, recorder events.EventRecorder, cfg config.Config, smClient sm.Client, object runtime.Object,
+	serviceInstance *v1.ServiceInstance, operation utils.PlanSchemaOperation, parameters []byte) error {
+	if cfg.ParamsSchemaValidation == config.SchemaValidationDisabled {
+		return nil
+	}
+	log := logutils.GetLogger(ctx)
+
+	plan, _, err := smClient.GetPlan(ctx, serviceInstance.Spec.ServicePlanID, serviceInstance.Spec.ServiceOfferingName, serviceInstance.Spec.ServicePlanName, serviceInstance.Spec.DataCenter)
+	if err != nil {
+		log.Info(fmt.Sprintf("skipping parameters schema validation, failed to get plan: %s", err.Error()))
+		return nil
+	}
+
+	err = utils.ValidateParametersSchema(plan, operation, parameters)
+	var schemaErr *utils.ParametersSchemaError
+	if err == nil || !errors.As(err, &schemaErr) {
+		if err != nil {
+			log.Info(fmt.Sprintf("skipping parameters schema validation: %s", err.Error()))
+		}
+		return nil
+	}
+
+	if cfg.ParamsSchemaValidation == config.SchemaValidationWarn {
+		log.Info(schemaErr.Error())
+		recorder.Eventf(object, nil, corev1.EventTypeWarning, common.InvalidParameters, actionVerify, "%s", schemaErr.Error())
+		return nil
+	}
+	return schemaErr
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm/smfakes"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/SAP/sap-btp-service-operator/internal/config"
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// instances that stop being provisioned by the reconciler never report a successful provision, their provision starts
+// must not be kept for the lifetime of the operator
+var _ = Describe("Provision metrics", func() {
+	var (
+		reconciler *ServiceInstanceReconciler
+		instance   *v1.ServiceInstance
+		smClient   *smfakes.FakeClient
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1.AddToScheme(scheme)).To(Succeed())
+		instance = &v1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "provisioning-instance",
+				Namespace:  "default",
+				UID:        types.UID("provisioning-instance-uid"),
+				Finalizers: []string{common.FinalizerName},
+			},
+			Spec: v1.ServiceInstanceSpec{ExternalName: "provisioning-instance"},
+		}
+		reconciler = &ServiceInstanceReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).WithStatusSubresource(instance).Build(),
+			Scheme:   scheme,
+			Config:   config.Config{ClusterID: "cluster-id"},
+			Recorder: events.NewFakeRecorder(10),
+		}
+		smClient = &smfakes.FakeClient{}
+		metrics.Provisions.Started(instance.UID)
+	})
+
+	AfterEach(func() {
+		metrics.Provisions.Forget(instance.UID)
+	})
+
+	It("forgets the provision of a deleted instance", func() {
+		Expect(reconciler.Client.Delete(context.Background(), instance)).To(Succeed())
+		Expect(reconciler.Client.Get(context.Background(), client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+		_, err := reconciler.deleteInstance(context.Background(), smClient, instance)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metrics.Provisions.InProgress(instance.UID)).To(BeFalse())
+	})
+
+	It("forgets the provision of a handed over instance", func() {
+		instance.Annotations = map[string]string{common.HandoverToAnnotation: "other-namespace"}
+		_, err := reconciler.handover(context.Background(), smClient, instance, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instance.Status.HandedOverTo).To(Equal("other-namespace"))
+		Expect(metrics.Provisions.InProgress(instance.UID)).To(BeFalse())
+	})
+
+	It("forgets the provision of an adopted or recovered instance", func() {
+		_, err := reconciler.importInstance(context.Background(), smClient, instance, &smClientTypes.ServiceInstance{ID: "instance-id", Ready: true})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instance.Status.InstanceID).To(Equal("instance-id"))
+		Expect(metrics.Provisions.InProgress(instance.UID)).To(BeFalse())
+	})
+})
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// serviceBindingSecretTypePrefix prefixes the type of servicebinding.io secrets, see https://servicebinding.io/spec/core/1.1.0/#provisioned-service
+	serviceBindingSecretTypePrefix = "servicebinding.io/"
+	serviceBindingProvider         = "sap"
+)
+
+var invalidServiceBindingTypeChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// serviceBindingType derives the servicebinding.io type of the instance from its first tag, brokers tag their instances
+// with the kind of service they provide, for example hana or postgresql. Falls back to the offering name.
+func serviceBindingType(instance *v1.ServiceInstance) string {
+	tags := mergeInstanceTags(instance.Status.Tags, instance.Spec.CustomTags)
+	if len(tags) > 0 {
+		if bindingType := normalizeServiceBindingType(tags[0]); len(bindingType) > 0 {
+			return bindingType
+		}
+	}
+	return normalizeServiceBindingType(instance.Spec.ServiceOfferingName)
+}
+
+func normalizeServiceBindingType(value string) string {
+	return strings.Trim(invalidServiceBindingTypeChars.ReplaceAllString(strings.ToLower(value), "-"), "-.")
+}
+
+// exposeInstanceBinding references the binding secret in the status of the instance, so the instance can be used as a
+// servicebinding.io ProvisionedService. Only secrets in the namespace of the instance can be referenced, the secret of the
+// first binding is used until it is deleted.
+func (r *ServiceBindingReconciler) exposeInstanceBinding(ctx context.Context, binding *v1.ServiceBinding) {
+	log := logutils.GetLogger(ctx)
+	if _, isStale := binding.Labels[common.StaleBindingIDLabel]; isStale {
+		return
+	}
+	if len(binding.Spec.ServiceInstanceNamespace) > 0 && binding.Spec.ServiceInstanceNamespace != binding.Namespace {
+		return
+	}
+
+	instance, err := r.getServiceInstanceForBinding(ctx, binding)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "failed to get the instance to expose its binding")
+		}
+		return
+	}
+	if instance.Status.Binding != nil {
+		if instance.Status.Binding.Name == binding.Spec.SecretName {
+			return
+		}
+		if _, err := r.getSecret(ctx, instance.Namespace, instance.Status.Binding.Name); !apierrors.IsNotFound(err) {
+			return
+		}
+	}
+
+	log.Info(fmt.Sprintf("exposing secret %s as the binding of instance %s", binding.Spec.SecretName, instance.Name))
+	instance.Status.Binding = &v1.BindingSecretReference{Name: binding.Spec.SecretName}
+	if err := utils.UpdateStatus(ctx, r.Client, instance); err != nil {
+		log.Error(err, "failed to expose the binding of the instance")
+	}
+}
+
+// releaseInstanceBinding removes the reference of the instance to the secret of a deleted binding, the secret of another
+// ready binding of the instance in its namespace is referenced instead
+func (r *ServiceBindingReconciler) releaseInstanceBinding(ctx context.Context, binding *v1.ServiceBinding) error {
+	log := logutils.GetLogger(ctx)
+	instance, err := r.getServiceInstanceForBinding(ctx, binding)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if instance.Status.Binding == nil || instance.Namespace != binding.Namespace || instance.Status.Binding.Name != binding.Spec.SecretName {
+		return nil
+	}
+
+	instance.Status.Binding = nil
+	bindings := &v1.ServiceBindingList{}
+	if err := r.Client.List(ctx, bindings, client.InNamespace(instance.Namespace)); err != nil {
+		return err
+	}
+	var replacement *v1.ServiceBinding
+	for i := range bindings.Items {
+		other := &bindings.Items[i]
+		if other.UID == binding.UID || !other.DeletionTimestamp.IsZero() || !meta.IsStatusConditionTrue(other.Status.Conditions, common.ConditionReady) {
+			continue
+		}
+		if other.Spec.ServiceInstanceName != instance.Name || (len(other.Spec.ServiceInstanceNamespace) > 0 && other.Spec.ServiceInstanceNamespace != instance.Namespace) {
+			continue
+		}
+		if _, isStale := other.Labels[common.StaleBindingIDLabel]; isStale {
+			continue
+		}
+		if replacement == nil || other.CreationTimestamp.Before(&replacement.CreationTimestamp) {
+			replacement = other
+		}
+	}
+	if replacement != nil {
+		log.Info(fmt.Sprintf("exposing secret %s as the binding of instance %s instead of the deleted one", replacement.Spec.SecretName, instance.Name))
+		instance.Status.Binding = &v1.BindingSecretReference{Name: replacement.Spec.SecretName}
+	}
+	return utils.UpdateStatus(ctx, r.Client, instance)
+}
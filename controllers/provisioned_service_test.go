@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"context"
+
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("servicebinding.io type", func() {
+	var instance *v1.ServiceInstance
+
+	BeforeEach(func() {
+		instance = &v1.ServiceInstance{Spec: v1.ServiceInstanceSpec{ServiceOfferingName: "PostgreSQL-db"}}
+	})
+
+	It("uses the first tag of the instance", func() {
+		instance.Status.Tags = []string{"PostgreSQL", "relational"}
+		instance.Spec.CustomTags = []string{"custom"}
+		Expect(serviceBindingType(instance)).To(Equal("postgresql"))
+	})
+
+	It("replaces the characters a secret type can't hold", func() {
+		instance.Spec.CustomTags = []string{"Hyperscaler Option"}
+		Expect(serviceBindingType(instance)).To(Equal("hyperscaler-option"))
+	})
+
+	It("falls back to the offering name", func() {
+		Expect(serviceBindingType(instance)).To(Equal("postgresql-db"))
+	})
+
+	Context("binding secret", func() {
+		var (
+			reconciler *ServiceBindingReconciler
+			binding    *v1.ServiceBinding
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(v1.AddToScheme(scheme)).To(Succeed())
+			instance.ObjectMeta = metav1.ObjectMeta{Name: "instance", Namespace: "default"}
+			binding = &v1.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "default"},
+				Spec: v1.ServiceBindingSpec{
+					ServiceInstanceName: instance.Name,
+					SecretName:          "binding-secret",
+					SecretFormat:        v1.SecretFormatServiceBinding,
+				},
+			}
+			reconciler = &ServiceBindingReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).Build(),
+				Scheme: scheme,
+			}
+		})
+
+		It("derives the type from the instance", func() {
+			secret, err := reconciler.createBindingSecret(context.Background(), binding, &smClientTypes.ServiceBinding{Credentials: []byte(`{"user":"admin"}`)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(secret.Data["type"])).To(Equal("postgresql-db"))
+			Expect(secret.Type).To(Equal(corev1.SecretType("servicebinding.io/postgresql-db")))
+		})
+
+		It("keeps a type provided by the broker", func() {
+			secret, err := reconciler.createBindingSecret(context.Background(), binding, &smClientTypes.ServiceBinding{Credentials: []byte(`{"type":"MySQL","user":"admin"}`)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(secret.Data["type"])).To(Equal("MySQL"))
+			Expect(secret.Type).To(Equal(corev1.SecretType("servicebinding.io/mysql")))
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// rebindMessage marks a credentials rotation that applies changed parameters
+const rebindMessage = "binding again with the changed parameters"
+
+// startRebind applies changed parameters by binding again, SM can't update bindings. The credentials rotation creates the
+// new binding and stores its credentials in the secret of the binding, the old binding is deleted once the new one is ready.
+func (r *ServiceBindingReconciler) startRebind(ctx context.Context, serviceBinding *v1.ServiceBinding) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	if len(serviceBinding.Status.HashedParameters) == 0 {
+		log.Info("binding has no parameters hash, assuming it was created with the parameters of its spec")
+		serviceBinding.Status.HashedParameters = serviceBinding.GetParametersHash()
+		return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceBinding)
+	}
+
+	log.Info(fmt.Sprintf("parameters of binding %s changed, binding again", serviceBinding.Status.BindingID))
+	utils.SetCredRotationInProgressConditions(common.CredPreparing, rebindMessage, serviceBinding)
+	return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceBinding)
+}
+
+// parametersChanged reports whether the parameters of the binding changed since it was created, bindings replaced by a
+// rotation keep the parameters they were created with
+func parametersChanged(serviceBinding *v1.ServiceBinding) bool {
+	if _, isStale := serviceBinding.Labels[common.StaleBindingIDLabel]; isStale {
+		return false
+	}
+	return serviceBinding.Status.HashedParameters != serviceBinding.GetParametersHash()
+}
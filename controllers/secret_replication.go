@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// replicateSecret copies the binding secret to the namespaces selected by the secret replication of the binding and
+// removes the copies from the namespaces that are no longer selected. Owner references can't cross namespaces, the copies
+// are tracked with the replica-of-binding label instead. Failures to replicate don't fail the binding, they are reported
+// with events and the namespaces that got the secret are listed in the status.
+func (r *ServiceBindingReconciler) replicateSecret(ctx context.Context, binding *v1.ServiceBinding, secret *corev1.Secret) error {
+	log := logutils.GetLogger(ctx)
+	if _, isStale := binding.Labels[common.StaleBindingIDLabel]; isStale {
+		return nil
+	}
+
+	targets, err := r.getReplicationTargets(ctx, binding)
+	if err != nil {
+		return err
+	}
+	if err := r.deleteSecretReplicas(ctx, binding, targets); err != nil {
+		return err
+	}
+
+	var replicatedTo, failures []string
+	for _, namespace := range targets {
+		if err := r.storeSecretReplica(ctx, binding, secret, namespace); err != nil {
+			log.Error(err, fmt.Sprintf("failed to replicate binding secret to namespace %s", namespace))
+			failures = append(failures, err.Error())
+			continue
+		}
+		replicatedTo = append(replicatedTo, namespace)
+	}
+	binding.Status.ReplicatedTo = replicatedTo
+	if len(failures) > 0 {
+		r.Recorder.Eventf(binding, nil, corev1.EventTypeWarning, common.ReplicationFailed, actionReplicateSecret, "failed to replicate secret %s: %s", binding.Spec.SecretName, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// getReplicationTargets returns the sorted namespaces the binding secret should be copied to, namespaces that don't allow
+// replication from the namespace of the binding are skipped
+func (r *ServiceBindingReconciler) getReplicationTargets(ctx context.Context, binding *v1.ServiceBinding) ([]string, error) {
+	log := logutils.GetLogger(ctx)
+	replication := binding.Spec.SecretReplication
+	if replication == nil {
+		return nil, nil
+	}
+
+	namespaces := make(map[string]*corev1.Namespace)
+	for _, name := range replication.Namespaces {
+		namespace := &corev1.Namespace{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, namespace); err != nil {
+			if apierrors.IsNotFound(err) {
+				log.Info(fmt.Sprintf("namespace %s not found, skipping secret replication to it", name))
+				continue
+			}
+			return nil, err
+		}
+		namespaces[name] = namespace
+	}
+	if replication.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(replication.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		namespaceList := &corev1.NamespaceList{}
+		if err := r.Client.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for i := range namespaceList.Items {
+			namespaces[namespaceList.Items[i].Name] = &namespaceList.Items[i]
+		}
+	}
+
+	var targets, refused []string
+	for name, namespace := range namespaces {
+		if name == binding.Namespace || !namespace.DeletionTimestamp.IsZero() || namespace.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		if !allowsReplicationFrom(namespace, binding.Namespace) {
+			refused = append(refused, name)
+			continue
+		}
+		targets = append(targets, name)
+	}
+	sort.Strings(targets)
+	if len(refused) > 0 {
+		sort.Strings(refused)
+		r.Recorder.Eventf(binding, nil, corev1.EventTypeWarning, common.ReplicationFailed, actionReplicateSecret,
+			"namespaces %s don't allow secret replication from namespace %s, annotate them with %s to allow it", strings.Join(refused, ", "), binding.Namespace, common.AllowReplicationFromAnnotation)
+	}
+	return targets, nil
+}
+
+// bindingsReplicatingTo returns the bindings whose secret replication selects the namespace or that replicated their
+// secret to it, a namespace that is created or (un)labeled changes the replication targets of these bindings
+func (r *ServiceBindingReconciler) bindingsReplicatingTo(ctx context.Context, namespace client.Object) []reconcile.Request {
+	bindings := &v1.ServiceBindingList{}
+	if err := r.Client.List(ctx, bindings); err != nil {
+		r.Log.Error(err, "failed to list service bindings")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range bindings.Items {
+		binding := &bindings.Items[i]
+		if replicatesTo(binding, namespace) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(binding)})
+		}
+	}
+	return requests
+}
+
+func replicatesTo(binding *v1.ServiceBinding, namespace client.Object) bool {
+	if slices.Contains(binding.Status.ReplicatedTo, namespace.GetName()) {
+		return true
+	}
+	replication := binding.Spec.SecretReplication
+	if replication == nil || binding.Namespace == namespace.GetName() {
+		return false
+	}
+	if slices.Contains(replication.Namespaces, namespace.GetName()) {
+		return true
+	}
+	if replication.NamespaceSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(replication.NamespaceSelector)
+	return err == nil && selector.Matches(labels.Set(namespace.GetLabels()))
+}
+
+// allowsReplicationFrom reports whether the namespace accepts secret replicas from the source namespace, the annotation
+// holds a comma separated list of namespaces or * for all of them
+func allowsReplicationFrom(namespace *corev1.Namespace, source string) bool {
+	for _, allowed := range strings.Split(namespace.Annotations[common.AllowReplicationFromAnnotation], ",") {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || allowed == source {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ServiceBindingReconciler) storeSecretReplica(ctx context.Context, binding *v1.ServiceBinding, secret *corev1.Secret, namespace string) error {
+	log := logutils.GetLogger(ctx)
+	replica := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        binding.Spec.SecretName,
+			Namespace:   namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Type:       secret.Type,
+		Data:       secret.Data,
+		StringData: secret.StringData,
+	}
+	for key, value := range secret.Labels {
+		replica.Labels[key] = value
+	}
+	replica.Labels[common.ManagedByBTPOperatorLabel] = "true"
+	replica.Labels[common.ReplicaOfBindingLabel] = string(binding.UID)
+	for key, value := range secret.Annotations {
+		replica.Annotations[key] = value
+	}
+	replica.Annotations[common.ReplicaOfAnnotation] = fmt.Sprintf("%s/%s", binding.Namespace, binding.Name)
+
+	existing := &corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(replica), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		log.Info("Creating binding secret replica", "name", replica.Name, "namespace", namespace)
+		if err := r.Client.Create(ctx, replica); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(binding, nil, corev1.EventTypeNormal, common.SecretReplicated, actionReplicateSecret, "secret %s replicated to namespace %s", replica.Name, namespace)
+		return nil
+	}
+
+	if existing.Labels[common.ReplicaOfBindingLabel] != string(binding.UID) {
+		return fmt.Errorf("secret %s already exists in namespace %s and is not a replica of this binding", replica.Name, namespace)
+	}
+	if secretTypeChanged(existing, replica) {
+		log.Info("Binding secret replica type changed, creating it again", "name", replica.Name, "namespace", namespace)
+		if err := r.Client.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		return r.Client.Create(ctx, replica)
+	}
+	if !secretReplicaChanged(existing, replica) {
+		return nil
+	}
+	existing.Data = replica.Data
+	existing.StringData = replica.StringData
+	existing.Labels = replica.Labels
+	existing.Annotations = replica.Annotations
+	return r.Client.Update(ctx, existing)
+}
+
+// secretReplicaChanged reports whether the stored replica differs from the desired one. String data is write only,
+// a desired replica that has string data is always written.
+func secretReplicaChanged(existing, desired *corev1.Secret) bool {
+	return len(desired.StringData) > 0 ||
+		!maps.EqualFunc(existing.Data, desired.Data, bytes.Equal) ||
+		!maps.Equal(existing.Labels, desired.Labels) ||
+		!maps.Equal(existing.Annotations, desired.Annotations)
+}
+
+// deleteSecretReplicas deletes the replicas of the binding secret in all namespaces except the given ones
+func (r *ServiceBindingReconciler) deleteSecretReplicas(ctx context.Context, binding *v1.ServiceBinding, keep []string) error {
+	log := logutils.GetLogger(ctx)
+	replicas := &corev1.SecretList{}
+	if err := r.Client.List(ctx, replicas, client.MatchingLabels{common.ReplicaOfBindingLabel: string(binding.UID)}); err != nil {
+		return err
+	}
+	for i := range replicas.Items {
+		replica := &replicas.Items[i]
+		if replica.Name == binding.Spec.SecretName && slices.Contains(keep, replica.Namespace) {
+			continue
+		}
+		log.Info("Deleting binding secret replica", "name", replica.Name, "namespace", replica.Namespace)
+		if err := r.Client.Delete(ctx, replica); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Secret replication", func() {
+	var existing, desired *corev1.Secret
+
+	BeforeEach(func() {
+		existing = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:          map[string]string{"label": "value"},
+				Annotations:     map[string]string{"annotation": "value"},
+				ResourceVersion: "5",
+			},
+			Data: map[string][]byte{"key": []byte("value")},
+		}
+		desired = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"label": "value"},
+				Annotations: map[string]string{"annotation": "value"},
+			},
+			Data: map[string][]byte{"key": []byte("value")},
+		}
+	})
+
+	It("does not change an up to date replica", func() {
+		Expect(secretReplicaChanged(existing, desired)).To(BeFalse())
+	})
+
+	It("changes a replica with different data, labels or annotations", func() {
+		desired.Data["key"] = []byte("other")
+		Expect(secretReplicaChanged(existing, desired)).To(BeTrue())
+
+		desired.Data["key"] = []byte("value")
+		desired.Labels["label"] = "other"
+		Expect(secretReplicaChanged(existing, desired)).To(BeTrue())
+
+		desired.Labels["label"] = "value"
+		delete(desired.Annotations, "annotation")
+		Expect(secretReplicaChanged(existing, desired)).To(BeTrue())
+	})
+
+	It("always changes a replica with string data", func() {
+		desired.StringData = map[string]string{"key": "value"}
+		Expect(secretReplicaChanged(existing, desired)).To(BeTrue())
+	})
+
+	Context("namespace changes", func() {
+		var binding *v1.ServiceBinding
+
+		BeforeEach(func() {
+			binding = &v1.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "source"},
+				Spec: v1.ServiceBindingSpec{SecretReplication: &v1.SecretReplication{
+					Namespaces:        []string{"listed"},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"replicate": "true"}},
+				}},
+			}
+		})
+
+		namespace := func(name string, labels map[string]string) *corev1.Namespace {
+			return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		}
+
+		It("enqueues the bindings that select the namespace", func() {
+			Expect(replicatesTo(binding, namespace("listed", nil))).To(BeTrue())
+			Expect(replicatesTo(binding, namespace("labeled", map[string]string{"replicate": "true"}))).To(BeTrue())
+			Expect(replicatesTo(binding, namespace("other", nil))).To(BeFalse())
+			Expect(replicatesTo(binding, namespace("source", map[string]string{"replicate": "true"}))).To(BeFalse())
+		})
+
+		It("enqueues the bindings that replicated to the namespace", func() {
+			binding.Spec.SecretReplication = nil
+			binding.Status.ReplicatedTo = []string{"unlabeled"}
+			Expect(replicatesTo(binding, namespace("unlabeled", nil))).To(BeTrue())
+			Expect(replicatesTo(binding, namespace("other", nil))).To(BeFalse())
+		})
+	})
+})
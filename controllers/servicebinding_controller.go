@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -31,6 +32,8 @@ import (
 
 	"github.com/SAP/sap-btp-service-operator/api/common"
 	"github.com/SAP/sap-btp-service-operator/internal/config"
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+	"github.com/SAP/sap-btp-service-operator/internal/tracing"
 	"github.com/SAP/sap-btp-service-operator/internal/utils"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -59,6 +62,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 )
 
 const (
@@ -83,7 +87,7 @@ type ServiceBindingReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 
-func (r *ServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	correlationID := uuid.New().String()
 	retry := r.Retries.Get(req.NamespacedName)
 	if retry != nil {
@@ -97,6 +101,8 @@ func (r *ServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 	ctx = context.WithValue(ctx, logutils.LogKey, log)
 	ctx = context.WithValue(ctx, logutils.CorrelationIDKey, correlationID)
+	ctx, span := tracing.StartReconcile(ctx, "ServiceBinding", req.NamespacedName, correlationID)
+	defer func() { tracing.End(span, err) }()
 
 	serviceBinding := &v1.ServiceBinding{}
 	if err := r.Client.Get(ctx, req.NamespacedName, serviceBinding); err != nil {
@@ -136,6 +142,10 @@ func (r *ServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 	}
 
+	if _, stale := serviceBinding.Labels[common.StaleBindingIDLabel]; instanceErr == nil && len(serviceInstance.Status.HandedOverTo) > 0 && !stale {
+		return r.handleHandedOverBinding(ctx, serviceBinding, serviceInstance)
+	}
+
 	smClient, err := r.GetSMClient(ctx, serviceInstance)
 	if err != nil {
 		return utils.HandleOperationFailure(ctx, r.Client, serviceBinding, common.Unknown, err)
@@ -153,7 +163,7 @@ func (r *ServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 
 	if len(serviceBinding.Status.BindingID) > 0 {
-		if bindingExist, err := isBindingExistInSM(smClient, serviceInstance, serviceBinding.Status.BindingID, log); err != nil {
+		if bindingExist, err := isBindingExistInSM(ctx, smClient, serviceInstance, serviceBinding.Status.BindingID, log); err != nil {
 			log.Error(err, "failed to check if binding exist in sm due to unknown error")
 			return utils.HandleServiceManagerError(ctx, r.Client, serviceBinding, common.Unknown, err, false)
 		} else if !bindingExist {
@@ -168,7 +178,13 @@ func (r *ServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			}
 			serviceBinding.Status.Conditions = []metav1.Condition{condition}
 			serviceBinding.Status.Ready = metav1.ConditionFalse
+			recordNotFoundEvent(r.Recorder, serviceBinding, condition.Message)
 			return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceBinding)
+		} else if utils.RemoveDegradedCondition(serviceBinding) {
+			log.Info("Service Manager is available again, removing degraded condition")
+			if err := utils.UpdateStatus(ctx, r.Client, serviceBinding); err != nil {
+				return ctrl.Result{}, err
+			}
 		}
 	}
 
@@ -194,6 +210,16 @@ func (r *ServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, errors.New("ServiceInstance is not ready")
 	}
 
+	if utils.IsDryRun(serviceBinding) {
+		return r.dryRun(ctx, smClient, serviceBinding, serviceInstance)
+	} else if serviceBinding.Status.DryRun != nil {
+		log.Info("binding left dry run mode, removing the dry run status")
+		serviceBinding.Status.DryRun = nil
+		if err := utils.UpdateStatus(ctx, r.Client, serviceBinding); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// should rotate creds
 	if meta.IsStatusConditionTrue(serviceBinding.Status.Conditions, common.ConditionCredRotationInProgress) {
 		log.Info("rotating credentials")
@@ -213,6 +239,10 @@ func (r *ServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return r.handleStaleServiceBinding(ctx, serviceBinding)
 		}
 
+		if parametersChanged(serviceBinding) {
+			return r.startRebind(ctx, serviceBinding)
+		}
+
 		if initCredRotationIfRequired(serviceBinding) {
 			log.Info("cred rotation required, updating status")
 			return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceBinding)
@@ -249,6 +279,7 @@ func (r *ServiceBindingReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1.ServiceBinding{}).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.bindingsReplicatingTo)).
 		WithOptions(controller.Options{RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](r.Config.RetryBaseDelay, r.Config.RetryMaxDelay)}).
 		Complete(r)
 }
@@ -257,13 +288,20 @@ func (r *ServiceBindingReconciler) createBinding(ctx context.Context, smClient s
 	log := logutils.GetLogger(ctx)
 	log.Info("Creating smBinding in SM")
 	serviceBinding.Status.InstanceID = serviceInstance.Status.InstanceID
-	bindingParameters, _, err := utils.BuildSMRequestParameters(serviceBinding.Namespace, serviceBinding.Spec.Parameters, serviceBinding.Spec.ParametersFrom)
+	serviceBinding.Status.HashedParameters = serviceBinding.GetParametersHash()
+	bindingParameters, _, err := utils.BuildSMRequestParameters(ctx, serviceBinding.Namespace, serviceBinding.Spec.Parameters, serviceBinding.Spec.ParametersFrom)
 	if err != nil {
 		log.Error(err, "failed to parse smBinding parameters")
 		return utils.HandleOperationFailure(ctx, r.Client, serviceBinding, smClientTypes.CREATE, err)
 	}
 
-	smBinding, operationURL, bindErr := smClient.Bind(&smClientTypes.ServiceBinding{
+	if err := validateParametersSchema(ctx, r.Recorder, r.Config, smClient, serviceBinding, serviceInstance, utils.BindingCreateSchema, bindingParameters); err != nil {
+		log.Error(err, "binding parameters do not match the plan schema")
+		recordOperationEvent(r.Recorder, serviceBinding, smClientTypes.CREATE, smClientTypes.FAILED, err.Error())
+		return utils.HandleOperationFailure(ctx, r.Client, serviceBinding, smClientTypes.CREATE, err)
+	}
+
+	smBinding, operationURL, bindErr := smClient.Bind(ctx, &smClientTypes.ServiceBinding{
 		Name: serviceBinding.Spec.ExternalName,
 		Labels: smClientTypes.Labels{
 			common.NamespaceLabel: []string{serviceBinding.Namespace},
@@ -276,6 +314,7 @@ func (r *ServiceBindingReconciler) createBinding(ctx context.Context, smClient s
 
 	if bindErr != nil {
 		log.Error(err, "failed to create service binding", "serviceInstanceID", serviceInstance.Status.InstanceID)
+		recordOperationEvent(r.Recorder, serviceBinding, smClientTypes.CREATE, smClientTypes.FAILED, fmt.Sprintf("failed to create binding: %s", bindErr.Error()))
 		return utils.HandleServiceManagerError(ctx, r.Client, serviceBinding, smClientTypes.CREATE, bindErr, true)
 	}
 
@@ -291,6 +330,7 @@ func (r *ServiceBindingReconciler) createBinding(ctx context.Context, smClient s
 		serviceBinding.Status.OperationURL = operationURL
 		serviceBinding.Status.OperationType = smClientTypes.CREATE
 		utils.SetInProgressConditions(ctx, smClientTypes.CREATE, "", serviceBinding, false)
+		recordOperationEvent(r.Recorder, serviceBinding, smClientTypes.CREATE, smClientTypes.INPROGRESS, fmt.Sprintf("creation of binding %s started", bindingID))
 		if err := utils.UpdateStatus(ctx, r.Client, serviceBinding); err != nil {
 			log.Error(err, "unable to update ServiceBinding status")
 			return ctrl.Result{}, err
@@ -314,6 +354,7 @@ func (r *ServiceBindingReconciler) createBinding(ctx context.Context, smClient s
 	serviceBinding.Status.Ready = metav1.ConditionTrue
 	r.Retries.Reset(types.NamespacedName{Name: serviceBinding.Name, Namespace: serviceBinding.Namespace})
 	utils.SetSuccessConditions(smClientTypes.CREATE, serviceBinding, false)
+	recordOperationEvent(r.Recorder, serviceBinding, smClientTypes.CREATE, smClientTypes.SUCCEEDED, fmt.Sprintf("binding %s created successfully", smBinding.ID))
 	log.Info("Updating binding", "bindingID", smBinding.ID)
 
 	return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceBinding)
@@ -353,9 +394,14 @@ func (r *ServiceBindingReconciler) delete(ctx context.Context, smClient sm.Clien
 			return r.poll(ctx, smClient, serviceBinding)
 		}
 
+		if _, stale := serviceBinding.Labels[common.StaleBindingIDLabel]; serviceBinding.Spec.DeletionPolicy == v1.DeletionPolicyRetain && !stale {
+			return r.retainBinding(ctx, smClient, serviceBinding)
+		}
+
 		log.Info(fmt.Sprintf("Deleting binding with id %v from SM, resourceMarkedForDeletions=%v", serviceBinding.Status.BindingID, utils.IsMarkedForDeletion(serviceBinding.ObjectMeta)))
-		operationURL, unbindErr := smClient.Unbind(serviceBinding.Status.BindingID, nil, utils.BuildUserInfo(ctx, serviceBinding.Spec.UserInfo))
+		operationURL, unbindErr := smClient.Unbind(ctx, serviceBinding.Status.BindingID, nil, utils.BuildUserInfo(ctx, serviceBinding.Spec.UserInfo))
 		if unbindErr != nil {
+			recordOperationEvent(r.Recorder, serviceBinding, smClientTypes.DELETE, smClientTypes.FAILED, fmt.Sprintf("failed to delete binding: %s", unbindErr.Error()))
 			return utils.HandleServiceManagerError(ctx, r.Client, serviceBinding, smClientTypes.DELETE, unbindErr, true)
 		}
 
@@ -364,6 +410,7 @@ func (r *ServiceBindingReconciler) delete(ctx context.Context, smClient sm.Clien
 			serviceBinding.Status.OperationURL = operationURL
 			serviceBinding.Status.OperationType = smClientTypes.DELETE
 			utils.SetInProgressConditions(ctx, smClientTypes.DELETE, "", serviceBinding, false)
+			recordOperationEvent(r.Recorder, serviceBinding, smClientTypes.DELETE, smClientTypes.INPROGRESS, fmt.Sprintf("deletion of binding %s started", serviceBinding.Status.BindingID))
 			if err := utils.UpdateStatus(ctx, r.Client, serviceBinding); err != nil {
 				return ctrl.Result{}, err
 			}
@@ -377,6 +424,7 @@ func (r *ServiceBindingReconciler) delete(ctx context.Context, smClient sm.Clien
 			return ctrl.Result{}, err
 		}
 		log.Info("Binding was deleted successfully")
+		recordOperationEvent(r.Recorder, serviceBinding, smClientTypes.DELETE, smClientTypes.SUCCEEDED, "binding deleted successfully")
 		return r.deleteSecretAndRemoveFinalizer(ctx, serviceBinding)
 	}
 	return ctrl.Result{}, nil
@@ -386,14 +434,14 @@ func (r *ServiceBindingReconciler) poll(ctx context.Context, smClient sm.Client,
 	log := logutils.GetLogger(ctx)
 	log.Info(fmt.Sprintf("binding resource is in progress, found operation url %s", serviceBinding.Status.OperationURL))
 
-	status, statusErr := smClient.Status(serviceBinding.Status.OperationURL, serviceBinding.Status.OperationType, nil)
+	status, statusErr := smClient.Status(ctx, serviceBinding.Status.OperationURL, serviceBinding.Status.OperationType, nil)
 	if statusErr != nil {
 		log.Info(fmt.Sprintf("failed to fetch operation, got error from SM: %s", statusErr.Error()), "operationURL", serviceBinding.Status.OperationURL, "operationType", serviceBinding.Status.OperationType)
 		if serviceBinding.Status.OperationType == smClientTypes.DELETE {
 			var smError *sm.ServiceManagerError
 			if ok := errors.As(statusErr, &smError); ok && smError.StatusCode == http.StatusInternalServerError {
 				log.Info("sm returned 500 for polling delete operation, checking if binding still exist")
-				if _, err := smClient.GetBindingByID(serviceBinding.Status.BindingID, nil); err != nil {
+				if _, err := smClient.GetBindingByID(ctx, serviceBinding.Status.BindingID, nil); err != nil {
 					if ok = errors.As(err, &smError); ok {
 						log.Error(smError, fmt.Sprintf("SM returned status code %d", smError.StatusCode))
 						if smError.StatusCode == http.StatusNotFound {
@@ -427,6 +475,9 @@ func (r *ServiceBindingReconciler) poll(ctx context.Context, smClient sm.Client,
 	case smClientTypes.PENDING:
 		log.Info(fmt.Sprintf("%s is still in progress", serviceBinding.Status.OperationURL))
 		if len(status.Description) != 0 {
+			if progressChanged(serviceBinding, status.Description) {
+				recordOperationEvent(r.Recorder, serviceBinding, status.Type, status.State, status.Description)
+			}
 			utils.SetInProgressConditions(ctx, status.Type, status.Description, serviceBinding, true)
 			if err := utils.UpdateStatus(ctx, r.Client, serviceBinding); err != nil {
 				log.Error(err, "unable to update ServiceBinding polling description")
@@ -436,6 +487,7 @@ func (r *ServiceBindingReconciler) poll(ctx context.Context, smClient sm.Client,
 		return ctrl.Result{RequeueAfter: r.Config.PollInterval}, nil
 	case smClientTypes.FAILED:
 		log.Info(fmt.Sprintf("%s ended with failure", serviceBinding.Status.OperationURL))
+		recordOperationEvent(r.Recorder, serviceBinding, status.Type, smClientTypes.FAILED, getErrorMsgFromLastOperation(status))
 		utils.SetFailureConditions(status.Type, status.Description, serviceBinding, true)
 		if serviceBinding.Status.OperationType == smClientTypes.CREATE ||
 			(serviceBinding.Status.OperationType == smClientTypes.DELETE && !utils.IsMarkedForDeletion(serviceBinding.ObjectMeta)) {
@@ -459,13 +511,19 @@ func (r *ServiceBindingReconciler) poll(ctx context.Context, smClient sm.Client,
 		return ctrl.Result{}, errors.New(errMsg)
 	case smClientTypes.SUCCEEDED:
 		log.Info(fmt.Sprintf("%s completed successfully", serviceBinding.Status.OperationURL))
+		recordOperationEvent(r.Recorder, serviceBinding, status.Type, smClientTypes.SUCCEEDED, fmt.Sprintf("%s operation of binding %s completed successfully", status.Type, serviceBinding.Status.BindingID))
 		switch serviceBinding.Status.OperationType {
 		case smClientTypes.CREATE:
-			smBinding, err := smClient.GetBindingByID(serviceBinding.Status.BindingID, nil)
-			if err != nil || smBinding == nil {
+			smBinding, err := smClient.GetBindingByID(ctx, serviceBinding.Status.BindingID, nil)
+			if err != nil {
 				log.Error(err, fmt.Sprintf("binding %s succeeded but could not fetch it from SM", serviceBinding.Status.BindingID))
-				return ctrl.Result{}, err
+				return utils.HandleServiceManagerError(ctx, r.Client, serviceBinding, smClientTypes.CREATE, err, false)
 			}
+			if smBinding == nil {
+				log.Info(fmt.Sprintf("binding %s succeeded but was not returned by SM", serviceBinding.Status.BindingID))
+				return ctrl.Result{}, nil
+			}
+			utils.RemoveDegradedCondition(serviceBinding)
 			if len(smBinding.Labels["subaccount_id"]) > 0 {
 				serviceBinding.Status.SubaccountID = smBinding.Labels["subaccount_id"][0]
 			}
@@ -497,7 +555,7 @@ func (r *ServiceBindingReconciler) poll(ctx context.Context, smClient sm.Client,
 func (r *ServiceBindingReconciler) handleFailedAsyncBinding(ctx context.Context, smClient sm.Client, serviceBinding *v1.ServiceBinding) (ctrl.Result, error) {
 	log := logutils.GetLogger(ctx)
 	log.Info(fmt.Sprintf("handleFailedAsyncBinding deleting binding id %s that failed from SM", serviceBinding.Status.BindingID))
-	operationURL, unbindErr := smClient.Unbind(serviceBinding.Status.BindingID, nil, utils.BuildUserInfo(ctx, serviceBinding.Spec.UserInfo))
+	operationURL, unbindErr := smClient.Unbind(ctx, serviceBinding.Status.BindingID, nil, utils.BuildUserInfo(ctx, serviceBinding.Spec.UserInfo))
 	if unbindErr != nil {
 		log.Error(unbindErr, fmt.Sprintf("handleFailedAsyncBinding unbind binding with id %s failed", serviceBinding.Status.BindingID))
 		return utils.HandleServiceManagerError(ctx, r.Client, serviceBinding, smClientTypes.DELETE, unbindErr, false)
@@ -534,17 +592,41 @@ func (r *ServiceBindingReconciler) getBindingForRecovery(ctx context.Context, sm
 	}
 	log.Info(fmt.Sprintf("binding recovery query params: %s, %s, %s, %s", nameQuery, clusterIDQuery, namespaceQuery, k8sNameQuery))
 
-	bindings, err := smClient.ListBindings(&parameters)
+	bindings, err := smClient.ListBindings(ctx, &parameters)
 	if err != nil {
 		log.Error(err, "failed to list bindings in SM")
 		return nil, err
 	}
 	if bindings != nil {
-		log.Info(fmt.Sprintf("found %d bindings", len(bindings.ServiceBindings)))
-		if len(bindings.ServiceBindings) == 1 {
-			return &bindings.ServiceBindings[0], nil
+		var found []*smClientTypes.ServiceBinding
+		for i := range bindings.ServiceBindings {
+			if !handedOverElsewhere(bindings.ServiceBindings[i].Labels, serviceBinding.Namespace, r.Config.ClusterID) {
+				found = append(found, &bindings.ServiceBindings[i])
+			}
+		}
+		log.Info(fmt.Sprintf("found %d bindings", len(found)))
+		if len(found) == 1 {
+			return found[0], nil
+		}
+		if len(found) > 1 {
+			return nil, nil
 		}
 	}
+
+	log.Info("binding not found in SM by its context, looking for a binding handed over to it")
+	parameters = sm.Parameters{
+		FieldQuery:    []string{nameQuery},
+		LabelQuery:    handedOverQuery(serviceBinding.Namespace, serviceBinding.Name, r.Config.ClusterID),
+		GeneralParams: []string{"attach_last_operations=true"},
+	}
+	bindings, err = smClient.ListBindings(ctx, &parameters)
+	if err != nil {
+		log.Error(err, "failed to list bindings in SM")
+		return nil, err
+	}
+	if bindings != nil && len(bindings.ServiceBindings) == 1 {
+		return &bindings.ServiceBindings[0], nil
+	}
 	return nil, nil
 }
 
@@ -552,7 +634,8 @@ func (r *ServiceBindingReconciler) maintain(ctx context.Context, smClient sm.Cli
 	log := logutils.GetLogger(ctx)
 	if err := r.maintainSecret(ctx, smClient, binding); err != nil {
 		log.Error(err, "failed to maintain secret")
-		return r.handleSecretError(ctx, smClientTypes.UPDATE, err, binding)
+		// the binding is fetched from SM to maintain the secret, SM errors are handled like in any other SM call
+		return utils.HandleServiceManagerError(ctx, r.Client, binding, smClientTypes.UPDATE, err, true)
 	}
 
 	log.Info("maintain finished successfully")
@@ -563,17 +646,17 @@ func (r *ServiceBindingReconciler) maintainSecret(ctx context.Context, smClient
 	log := logutils.GetLogger(ctx)
 	if common.GetObservedGeneration(serviceBinding) == serviceBinding.Generation {
 		log.Info("observed generation is up to date, checking if secret exists")
-		if _, err := r.getSecret(ctx, serviceBinding.Namespace, serviceBinding.Spec.SecretName); err == nil {
+		if secret, err := r.getSecret(ctx, serviceBinding.Namespace, serviceBinding.Spec.SecretName); err == nil {
 			log.Info("secret exists, no need to maintain secret")
-			return nil
+			return r.maintainExistingSecret(ctx, serviceBinding, secret)
 		}
 
 		log.Info("binding's secret was not found")
-		r.Recorder.Eventf(serviceBinding, nil, corev1.EventTypeWarning, "SecretDeleted", "SecretDeleted", "SecretDeleted")
+		r.Recorder.Eventf(serviceBinding, nil, corev1.EventTypeWarning, common.SecretDeleted, actionStoreSecret, "secret %s of the binding was deleted, recreating it", serviceBinding.Spec.SecretName)
 	}
 
 	log.Info("maintaining binding's secret")
-	smBinding, err := smClient.GetBindingByID(serviceBinding.Status.BindingID, nil)
+	smBinding, err := smClient.GetBindingByID(ctx, serviceBinding.Status.BindingID, nil)
 	if err != nil {
 		log.Error(err, "failed to get binding for update secret")
 		return err
@@ -591,6 +674,27 @@ func (r *ServiceBindingReconciler) maintainSecret(ctx context.Context, smClient
 	return utils.UpdateStatus(ctx, r.Client, serviceBinding)
 }
 
+// maintainExistingSecret keeps what is derived from an up to date binding secret in sync: the references of the binding and
+// its instance to the secret, and the replicas of the secret in the namespaces that are selected, namespaces may be
+// labeled or allow the replication after the secret was stored
+func (r *ServiceBindingReconciler) maintainExistingSecret(ctx context.Context, serviceBinding *v1.ServiceBinding, secret *corev1.Secret) error {
+	changed := serviceBinding.Status.Binding == nil
+	serviceBinding.Status.Binding = &v1.BindingSecretReference{Name: serviceBinding.Spec.SecretName}
+	r.exposeInstanceBinding(ctx, serviceBinding)
+
+	if serviceBinding.Spec.SecretReplication != nil || len(serviceBinding.Status.ReplicatedTo) > 0 {
+		replicatedTo := serviceBinding.Status.ReplicatedTo
+		if err := r.replicateSecret(ctx, serviceBinding, secret); err != nil {
+			return err
+		}
+		changed = changed || !slices.Equal(replicatedTo, serviceBinding.Status.ReplicatedTo)
+	}
+	if !changed {
+		return nil
+	}
+	return utils.UpdateStatus(ctx, r.Client, serviceBinding)
+}
+
 func (r *ServiceBindingReconciler) getServiceInstanceForBinding(ctx context.Context, binding *v1.ServiceBinding) (*v1.ServiceInstance, error) {
 	log := logutils.GetLogger(ctx)
 	serviceInstance := &v1.ServiceInstance{}
@@ -670,7 +774,12 @@ func (r *ServiceBindingReconciler) storeBindingSecret(ctx context.Context, k8sBi
 	}
 	secret.Annotations["binding"] = k8sBinding.Name
 
-	return r.createOrUpdateBindingSecret(ctx, k8sBinding, secret)
+	if err = r.createOrUpdateBindingSecret(ctx, k8sBinding, secret); err != nil {
+		return err
+	}
+	k8sBinding.Status.Binding = &v1.BindingSecretReference{Name: k8sBinding.Spec.SecretName}
+	r.exposeInstanceBinding(ctx, k8sBinding)
+	return r.replicateSecret(ctx, k8sBinding, secret)
 }
 
 func (r *ServiceBindingReconciler) createBindingSecret(ctx context.Context, k8sBinding *v1.ServiceBinding, smBinding *smClientTypes.ServiceBinding) (*corev1.Secret, error) {
@@ -688,6 +797,11 @@ func (r *ServiceBindingReconciler) createBindingSecret(ctx context.Context, k8sB
 		},
 		Data: credentialsMap,
 	}
+	if k8sBinding.Spec.SecretFormat == v1.SecretFormatServiceBinding {
+		if bindingType := normalizeServiceBindingType(string(credentialsMap["type"])); len(bindingType) > 0 {
+			secret.Type = corev1.SecretType(serviceBindingSecretTypePrefix + bindingType)
+		}
+	}
 	return secret, nil
 }
 
@@ -801,6 +915,13 @@ func (r *ServiceBindingReconciler) createOrUpdateBindingSecret(ctx context.Conte
 			return err
 		}
 		create = true
+	} else if secretTypeChanged(dbSecret, secret) {
+		// the type of a secret can't be changed, the secret is created again
+		log.Info("Binding secret type changed, deleting the secret to create it again", "name", secret.Name)
+		if err := r.Client.Delete(ctx, dbSecret); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		create = true
 	}
 
 	if create {
@@ -811,7 +932,7 @@ func (r *ServiceBindingReconciler) createOrUpdateBindingSecret(ctx context.Conte
 			}
 			return nil
 		}
-		r.Recorder.Eventf(binding, nil, corev1.EventTypeNormal, "SecretCreated", "SecretCreated", "SecretCreated")
+		r.Recorder.Eventf(binding, nil, corev1.EventTypeNormal, common.SecretCreated, actionStoreSecret, "secret %s created", secret.Name)
 		return nil
 	}
 
@@ -823,9 +944,28 @@ func (r *ServiceBindingReconciler) createOrUpdateBindingSecret(ctx context.Conte
 	return r.Client.Update(ctx, dbSecret)
 }
 
+func secretTypeChanged(existing, desired *corev1.Secret) bool {
+	existingType, desiredType := existing.Type, desired.Type
+	if len(existingType) == 0 {
+		existingType = corev1.SecretTypeOpaque
+	}
+	if len(desiredType) == 0 {
+		desiredType = corev1.SecretTypeOpaque
+	}
+	return existingType != desiredType
+}
+
 func (r *ServiceBindingReconciler) deleteBindingSecret(ctx context.Context, binding *v1.ServiceBinding) error {
 	log := logutils.GetLogger(ctx)
 	log.Info("Deleting binding secret")
+	if err := r.deleteSecretReplicas(ctx, binding, nil); err != nil {
+		log.Error(err, "failed to delete binding secret replicas")
+		return err
+	}
+	if err := r.releaseInstanceBinding(ctx, binding); err != nil {
+		log.Error(err, "failed to release the binding of the instance")
+		return err
+	}
 	bindingSecret := &corev1.Secret{}
 	if err := r.Client.Get(ctx, types.NamespacedName{
 		Namespace: binding.Namespace,
@@ -921,6 +1061,7 @@ func (r *ServiceBindingReconciler) addInstanceInfo(ctx context.Context, binding
 		return nil, err
 	}
 
+	brokerType := credentialsMap["type"]
 	credentialsMap["instance_name"] = getInstanceNameForSecretCredentials(instance)
 	credentialsMap["instance_guid"] = []byte(instance.Status.InstanceID)
 	credentialsMap["plan"] = []byte(instance.Spec.ServicePlanName)
@@ -959,6 +1100,16 @@ func (r *ServiceBindingReconciler) addInstanceInfo(ctx context.Context, binding
 	if _, ok := credentialsMap["tags"]; ok {
 		metadata = append(metadata, utils.SecretMetadataProperty{Name: "tags", Format: string(utils.JSON)})
 	}
+	if binding.Spec.SecretFormat == v1.SecretFormatServiceBinding {
+		// a type provided by the broker describes the credentials better than the one derived from the instance
+		if len(brokerType) > 0 {
+			credentialsMap["type"] = brokerType
+		} else {
+			credentialsMap["type"] = []byte(serviceBindingType(instance))
+		}
+		credentialsMap["provider"] = []byte(serviceBindingProvider)
+		metadata = append(metadata, utils.SecretMetadataProperty{Name: "provider", Format: string(utils.TEXT)})
+	}
 
 	return metadata, nil
 }
@@ -971,9 +1122,16 @@ func (r *ServiceBindingReconciler) rotateCredentials(ctx context.Context, bindin
 	}
 
 	credInProgressCondition := meta.FindStatusCondition(binding.GetConditions(), common.ConditionCredRotationInProgress)
+	rebind := credInProgressCondition.Message == rebindMessage
 	if credInProgressCondition.Reason == common.CredRotating {
 		if len(binding.Status.BindingID) > 0 && binding.Status.Ready == metav1.ConditionTrue {
 			log.Info("Credentials rotation - finished successfully")
+			metrics.CredentialRotations.WithLabelValues("succeeded").Inc()
+			if rebind {
+				r.Recorder.Eventf(binding, nil, corev1.EventTypeNormal, common.Rebound, actionRebind, "binding %s was created with the changed parameters", binding.Status.BindingID)
+			} else {
+				r.Recorder.Eventf(binding, nil, corev1.EventTypeNormal, common.CredRotationSucceeded, actionRotateCredentials, "credentials rotated successfully")
+			}
 			now := metav1.NewTime(time.Now())
 			binding.Status.LastCredentialsRotationTime = &now
 			return false, r.stopRotation(ctx, binding)
@@ -1003,23 +1161,34 @@ func (r *ServiceBindingReconciler) rotateCredentials(ctx context.Context, bindin
 		// rename current binding
 		suffix := "-" + utils.RandStringRunes(6)
 		log.Info("Credentials rotation - renaming binding to old in SM", "current", binding.Spec.ExternalName)
-		if _, errRenaming := smClient.RenameBinding(binding.Status.BindingID, binding.Spec.ExternalName+suffix, binding.Name+suffix); errRenaming != nil {
+		if _, errRenaming := smClient.RenameBinding(ctx, binding.Status.BindingID, binding.Spec.ExternalName+suffix, binding.Name+suffix); errRenaming != nil {
 			log.Error(errRenaming, "Credentials rotation - failed renaming binding to old in SM", "binding", binding.Spec.ExternalName)
+			metrics.CredentialRotations.WithLabelValues("failed").Inc()
+			r.Recorder.Eventf(binding, nil, corev1.EventTypeWarning, common.CredRotationFailed, actionRotateCredentials, "failed to rename binding in Service Manager: %s", errRenaming.Error())
 			return true, errRenaming
 		}
 
 		log.Info("Credentials rotation - backing up old binding in K8S", "name", binding.Name+suffix)
-		if err := r.createOldBinding(ctx, suffix, binding); err != nil {
+		if err := r.createOldBinding(ctx, suffix, binding, rebind); err != nil {
 			log.Error(err, "Credentials rotation - failed to back up old binding in K8S")
+			metrics.CredentialRotations.WithLabelValues("failed").Inc()
+			r.Recorder.Eventf(binding, nil, corev1.EventTypeWarning, common.CredRotationFailed, actionRotateCredentials, "failed to back up old binding: %s", err.Error())
 			return true, err
 		}
 	}
 
 	log.Info("reset binding id after successful rotation")
+	message := ""
+	if rebind {
+		message = rebindMessage
+		r.Recorder.Eventf(binding, nil, corev1.EventTypeNormal, common.Rebinding, actionRebind, "parameters changed, old binding %s is deleted when the new binding is ready", binding.Status.BindingID)
+	} else {
+		r.Recorder.Eventf(binding, nil, corev1.EventTypeNormal, common.CredRotationStarted, actionRotateCredentials, "rotating credentials, old binding %s is kept until the new credentials are ready", binding.Status.BindingID)
+	}
 	binding.Status.BindingID = ""
 	binding.Status.Ready = metav1.ConditionFalse
 	utils.SetInProgressConditions(ctx, smClientTypes.CREATE, "rotating binding credentials", binding, false)
-	utils.SetCredRotationInProgressConditions(common.CredRotating, "", binding)
+	utils.SetCredRotationInProgressConditions(common.CredRotating, message, binding)
 	return false, utils.UpdateStatus(ctx, r.Client, binding)
 }
 
@@ -1041,7 +1210,7 @@ func (r *ServiceBindingReconciler) stopRotation(ctx context.Context, binding *v1
 	return utils.UpdateStatus(ctx, r.Client, binding)
 }
 
-func (r *ServiceBindingReconciler) createOldBinding(ctx context.Context, suffix string, binding *v1.ServiceBinding) error {
+func (r *ServiceBindingReconciler) createOldBinding(ctx context.Context, suffix string, binding *v1.ServiceBinding, rebind bool) error {
 	oldBinding := newBindingObject(binding.Name+suffix, binding.Namespace)
 	err := controllerutil.SetControllerReference(binding, oldBinding, r.Scheme)
 	if err != nil {
@@ -1055,7 +1224,18 @@ func (r *ServiceBindingReconciler) createOldBinding(ctx context.Context, suffix
 		common.StaleBindingOrigBindingNameAnnotation: binding.Name,
 	}
 	spec := binding.Spec.DeepCopy()
+	if spec.CredRotationPolicy == nil {
+		spec.CredRotationPolicy = &v1.CredentialsRotationPolicy{RotationFrequency: "0s", RotatedBindingTTL: "0s"}
+	}
 	spec.CredRotationPolicy.Enabled = false
+	if rebind {
+		// the old binding was created with the previous parameters, it is deleted as soon as the new one is ready
+		spec.CredRotationPolicy.RotatedBindingTTL = "0s"
+	}
+	// the rotated credentials must be revoked in SM when the stale binding is deleted
+	spec.DeletionPolicy = ""
+	// the replicas stay with the binding, they get the new credentials
+	spec.SecretReplication = nil
 	spec.SecretName = spec.SecretName + suffix
 	spec.ExternalName = spec.ExternalName + suffix
 	oldBinding.Spec = *spec
@@ -1070,19 +1250,19 @@ func (r *ServiceBindingReconciler) handleStaleServiceBinding(ctx context.Context
 		//the stale binding should be deleted otherwise it will remain forever
 		if originalBindingName, ok = serviceBinding.Labels[common.StaleBindingRotationOfLabel]; !ok {
 			log.Info("missing rotationOf label/annotation, unable to fetch original binding, deleting stale")
-			return ctrl.Result{}, r.Client.Delete(ctx, serviceBinding)
+			return ctrl.Result{}, r.deleteStaleBinding(ctx, serviceBinding, "missing_reference")
 		}
 	}
 	origBinding := &v1.ServiceBinding{}
 	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: originalBindingName}, origBinding); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("original binding not found, deleting stale binding")
-			return ctrl.Result{}, r.Client.Delete(ctx, serviceBinding)
+			return ctrl.Result{}, r.deleteStaleBinding(ctx, serviceBinding, "original_not_found")
 		}
 		return ctrl.Result{}, err
 	}
 	if meta.IsStatusConditionTrue(origBinding.Status.Conditions, common.ConditionReady) {
-		return ctrl.Result{}, r.Client.Delete(ctx, serviceBinding)
+		return ctrl.Result{}, r.deleteStaleBinding(ctx, serviceBinding, "original_ready")
 	}
 
 	log.Info("not deleting stale binding since original binding is not ready")
@@ -1100,9 +1280,19 @@ func (r *ServiceBindingReconciler) handleStaleServiceBinding(ctx context.Context
 	return ctrl.Result{}, nil
 }
 
+func (r *ServiceBindingReconciler) deleteStaleBinding(ctx context.Context, serviceBinding *v1.ServiceBinding, reason string) error {
+	if err := r.Client.Delete(ctx, serviceBinding); err != nil {
+		return err
+	}
+	metrics.StaleBindingDeletions.WithLabelValues(reason).Inc()
+	r.Recorder.Eventf(serviceBinding, nil, corev1.EventTypeNormal, common.StaleBindingDeleted, actionDeleteStale, "stale binding deleted (%s)", reason)
+	return nil
+}
+
 func (r *ServiceBindingReconciler) recover(ctx context.Context, serviceBinding *v1.ServiceBinding, smBinding *smClientTypes.ServiceBinding) (ctrl.Result, error) {
 	log := logutils.GetLogger(ctx)
 	log.Info(fmt.Sprintf("found existing smBinding in SM with id %s, updating status", smBinding.ID))
+	r.Recorder.Eventf(serviceBinding, nil, corev1.EventTypeNormal, common.Recovered, actionRecover, "recovered existing binding %s from Service Manager", smBinding.ID)
 
 	if smBinding.Credentials != nil {
 		if err := r.storeBindingSecret(ctx, serviceBinding, smBinding); err != nil {
@@ -1114,6 +1304,8 @@ func (r *ServiceBindingReconciler) recover(ctx context.Context, serviceBinding *
 		}
 	}
 	r.resyncBindingStatus(ctx, serviceBinding, smBinding)
+	// the parameters of a binding can't be read from SM, the recovered binding is assumed to match the spec
+	serviceBinding.Status.HashedParameters = serviceBinding.GetParametersHash()
 
 	return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceBinding)
 }
@@ -1227,7 +1419,7 @@ func singleKeyMap(credentialsMap map[string][]byte, key string) (map[string][]by
 	}, nil
 }
 
-func isBindingExistInSM(smClient sm.Client, instance *v1.ServiceInstance, bindingID string, log logr.Logger) (bool, error) {
+func isBindingExistInSM(ctx context.Context, smClient sm.Client, instance *v1.ServiceInstance, bindingID string, log logr.Logger) (bool, error) {
 	log.Info("checking if k8s instance status is NotFound")
 	instanceReadyCond := meta.FindStatusCondition(instance.GetConditions(), common.ConditionReady)
 	if instanceReadyCond != nil && instanceReadyCond.Reason == common.ResourceNotFound {
@@ -1236,7 +1428,7 @@ func isBindingExistInSM(smClient sm.Client, instance *v1.ServiceInstance, bindin
 	}
 
 	log.Info(fmt.Sprintf("trying to get from SM binding with id %s", bindingID))
-	if _, err := smClient.GetBindingByID(bindingID, nil); err != nil {
+	if _, err := smClient.GetBindingByID(ctx, bindingID, nil); err != nil {
 		var smError *sm.ServiceManagerError
 		if ok := errors.As(err, &smError); ok {
 			log.Error(smError, fmt.Sprintf("SM returned status code %d", smError.StatusCode))
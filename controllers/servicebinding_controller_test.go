@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -80,7 +81,7 @@ var _ = Describe("ServiceBinding controller", func() {
 		Expect(createdBinding.Spec.SecretName).To(Not(BeEmpty()))
 		Expect(common.GetObservedGeneration(createdBinding)).To(Equal(int64(1)))
 		Expect(string(createdBinding.Spec.Parameters.Raw)).To(ContainSubstring("\"key\":\"value\""))
-		smBinding, _, _ := fakeClient.BindArgsForCall(0)
+		_, smBinding, _, _ := fakeClient.BindArgsForCall(0)
 		params := smBinding.Parameters
 		Expect(params).To(ContainSubstring("\"key\":\"value\""))
 		Expect(params).To(ContainSubstring("\"secret-key\":\"secret-value\""))
@@ -298,6 +299,82 @@ var _ = Describe("ServiceBinding controller", func() {
 				validateSecretMetadata(bindingSecret, credentialProperties)
 			})
 
+			It("should create a servicebinding.io secret if spec.secretFormat is ServiceBinding", func() {
+				binding := newBindingObject("binding-with-servicebinding-format", bindingTestNamespace)
+				binding.Spec.ServiceInstanceName = instanceName
+				binding.Spec.SecretFormat = v1.SecretFormatServiceBinding
+				Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+
+				waitForResourceToBeReady(ctx, binding)
+				Expect(binding.Status.Binding).To(Equal(&v1.BindingSecretReference{Name: binding.Spec.SecretName}))
+
+				bindingSecret := getSecret(ctx, binding.Spec.SecretName, bindingTestNamespace, true)
+				Expect(bindingSecret.Type).To(Equal(corev1.SecretType("servicebinding.io/test")))
+				validateSecretData(bindingSecret, "type", "test")
+				validateSecretData(bindingSecret, "provider", "sap")
+				validateSecretData(bindingSecret, "secret_key", "secret_value")
+
+				By("exposing the secret as the binding of the instance")
+				Eventually(func() bool {
+					instance := &v1.ServiceInstance{}
+					if err := k8sClient.Get(ctx, types.NamespacedName{Name: instanceName, Namespace: bindingTestNamespace}, instance); err != nil {
+						return false
+					}
+					return instance.Status.Binding != nil && instance.Status.Binding.Name == binding.Spec.SecretName
+				}, timeout, interval).Should(BeTrue())
+
+				By("switching back to the default format")
+				binding.Spec.SecretFormat = v1.SecretFormatDefault
+				Expect(k8sClient.Update(ctx, binding)).To(Succeed())
+				Eventually(func() bool {
+					secret := getSecret(ctx, binding.Spec.SecretName, bindingTestNamespace, false)
+					return secret.Type == corev1.SecretTypeOpaque && string(secret.Data["type"]) == "an-offering-name"
+				}, timeout, interval).Should(BeTrue())
+			})
+
+			It("should aggregate the binding into a VCAP_SERVICES secret", func() {
+				binding := newBindingObject("binding-in-vcap-services", bindingTestNamespace)
+				binding.Spec.ServiceInstanceName = instanceName
+				Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+				waitForResourceToBeReady(ctx, binding)
+
+				vcapServices := &v1.VCAPServices{
+					ObjectMeta: metav1.ObjectMeta{Name: "vcap-services-" + testUUID, Namespace: bindingTestNamespace},
+					Spec:       v1.VCAPServicesSpec{Bindings: []string{binding.Name, "not-created-yet"}},
+				}
+				Expect(k8sClient.Create(ctx, vcapServices)).To(Succeed())
+				defer func() {
+					Expect(k8sClient.Delete(ctx, vcapServices)).To(Succeed())
+				}()
+				Eventually(func() []string {
+					if err := k8sClient.Get(ctx, getResourceNamespacedName(vcapServices), vcapServices); err != nil {
+						return nil
+					}
+					return vcapServices.Status.Bindings
+				}, timeout, interval).Should(Equal([]string{binding.Name}))
+				Expect(vcapServices.Status.Ready).To(Equal(metav1.ConditionFalse))
+
+				secret := getSecret(ctx, vcapServices.Name, bindingTestNamespace, true)
+				document := make(map[string][]map[string]interface{})
+				Expect(json.Unmarshal(secret.Data[v1.VCAPServicesKey], &document)).To(Succeed())
+				Expect(document).To(HaveKey("an-offering-name"))
+				service := document["an-offering-name"][0]
+				Expect(service["name"]).To(Equal(instanceExternalName))
+				Expect(service["plan"]).To(Equal("a-plan-name"))
+				Expect(service["instance_guid"]).To(Equal(createdInstance.Status.InstanceID))
+				Expect(service["tags"]).To(ConsistOf("test", "custom-tag"))
+				Expect(service["credentials"]).To(Equal(map[string]interface{}{"secret_key": "secret_value", "escaped": `{"escaped_key":"escaped_val"}`}))
+
+				By("regenerating the secret when the binding secret changes")
+				bindingSecret := getSecret(ctx, binding.Spec.SecretName, bindingTestNamespace, true)
+				bindingSecret.Data["secret_key"] = []byte("rotated_value")
+				Expect(k8sClient.Update(ctx, bindingSecret)).To(Succeed())
+				Eventually(func() bool {
+					secret := getSecret(ctx, vcapServices.Name, bindingTestNamespace, false)
+					return strings.Contains(string(secret.Data[v1.VCAPServicesKey]), "rotated_value")
+				}, timeout, interval).Should(BeTrue())
+			})
+
 			It("should put binding data in single key if spec.secretRootKey is provided", func() {
 				binding := newBindingObject("binding-with-secretrootkey", bindingTestNamespace)
 				binding.Spec.ServiceInstanceName = instanceName
@@ -470,7 +547,7 @@ var _ = Describe("ServiceBinding controller", func() {
 				It("should fail with the error returned from SM and create a new binding", func() {
 					errorMessage := "no binding for you"
 
-					fakeClient.StatusStub = func(url string, operationType smClientTypes.OperationCategory, parameters *sm.Parameters) (*smClientTypes.Operation, error) {
+					fakeClient.StatusStub = func(_ context.Context, url string, operationType smClientTypes.OperationCategory, parameters *sm.Parameters) (*smClientTypes.Operation, error) {
 						if strings.Contains(url, "successful-binding-id") {
 							return &smClientTypes.Operation{
 								Type:        smClientTypes.CREATE,
@@ -497,6 +574,32 @@ var _ = Describe("ServiceBinding controller", func() {
 				})
 			})
 
+			When("bind polling returns success while Service Manager is unavailable", func() {
+				It("should set the degraded condition and store the secret once Service Manager is available", func() {
+					fakeClient.StatusReturns(&smClientTypes.Operation{ResourceID: fakeBindingID, Type: smClientTypes.CREATE, State: smClientTypes.SUCCEEDED}, nil)
+					fakeClient.GetBindingByIDReturns(nil, &sm.ServiceManagerUnavailableError{URL: "https://sm.url", RetryAt: time.Now().Add(time.Second)})
+
+					binding, err := createBindingWithoutAssertions(ctx, bindingName, bindingTestNamespace, instanceName, "", "", "", false)
+					Expect(err).ToNot(HaveOccurred())
+					Eventually(func() bool {
+						if err := k8sClient.Get(ctx, getResourceNamespacedName(binding), binding); err != nil {
+							return false
+						}
+						return meta.IsStatusConditionTrue(binding.GetConditions(), common.ConditionDegraded)
+					}, timeout, interval).Should(BeTrue())
+					Expect(meta.FindStatusCondition(binding.GetConditions(), common.ConditionDegraded).Reason).To(Equal(common.ServiceManagerUnavailable))
+
+					fakeClient.GetBindingByIDReturns(&smClientTypes.ServiceBinding{ID: fakeBindingID, Credentials: json.RawMessage(`{"secret_key": "secret_value"}`)}, nil)
+					waitForResourceToBeReady(ctx, binding)
+					Eventually(func() bool {
+						if err := k8sClient.Get(ctx, getResourceNamespacedName(binding), binding); err != nil {
+							return false
+						}
+						return meta.FindStatusCondition(binding.GetConditions(), common.ConditionDegraded) == nil
+					}, timeout, interval).Should(BeTrue())
+				})
+			})
+
 			// TODO redefine test
 			XWhen("bind polling returns error", func() {
 				BeforeEach(func() {
@@ -546,6 +649,22 @@ var _ = Describe("ServiceBinding controller", func() {
 			})
 		})
 
+		When("binding is in dry run mode", func() {
+			It("should publish the request without binding", func() {
+				binding := generateBasicBindingTemplate(bindingName, bindingTestNamespace, instanceName, "", "", "")
+				binding.Annotations = map[string]string{common.DryRunAnnotation: "true"}
+				Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+				createdBinding = &v1.ServiceBinding{}
+				Eventually(func() bool {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: bindingName, Namespace: bindingTestNamespace}, createdBinding)
+					return err == nil && createdBinding.Status.DryRun != nil
+				}, timeout, interval).Should(BeTrue())
+				Expect(createdBinding.Status.DryRun.Operation).To(Equal(v1.DryRunCreate))
+				Expect(createdBinding.Status.DryRun.Changes).To(ContainElements("parameters.key: added", "parameters.secret-key: added"))
+				Expect(fakeClient.BindCallCount()).To(BeZero())
+			})
+		})
+
 		When("secret name is provided", func() {
 			It("should create a secret with the provided name", func() {
 				binding := newBindingObject(bindingName, bindingTestNamespace)
@@ -555,6 +674,109 @@ var _ = Describe("ServiceBinding controller", func() {
 			})
 		})
 
+		When("secret replication is set", func() {
+			var allowedNamespace, selectedNamespace, refusingNamespace string
+
+			createNamespace := func(name string, labels, annotations map[string]string) {
+				namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations}}
+				Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				allowedNamespace = "allowed-" + testUUID
+				selectedNamespace = "selected-" + testUUID
+				refusingNamespace = "refusing-" + testUUID
+				allowAll := map[string]string{common.AllowReplicationFromAnnotation: "*"}
+				createNamespace(allowedNamespace, nil, map[string]string{common.AllowReplicationFromAnnotation: "other, " + bindingTestNamespace})
+				createNamespace(selectedNamespace, map[string]string{"replicate": testUUID}, allowAll)
+				createNamespace(refusingNamespace, nil, nil)
+			})
+
+			It("should copy the secret to the selected namespaces that allow it and delete the copies with the binding", func() {
+				binding := newBindingObject(bindingName, bindingTestNamespace)
+				binding.Spec.ServiceInstanceName = instanceName
+				binding.Spec.SecretReplication = &v1.SecretReplication{
+					Namespaces:        []string{allowedNamespace, refusingNamespace},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"replicate": testUUID}},
+				}
+				Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+				createdBinding = binding
+				waitForResourceToBeReady(ctx, createdBinding)
+				Expect(createdBinding.Status.ReplicatedTo).To(Equal([]string{allowedNamespace, selectedNamespace}))
+
+				replica := getSecret(ctx, createdBinding.Spec.SecretName, allowedNamespace, true)
+				Expect(replica.Labels).To(HaveKeyWithValue(common.ReplicaOfBindingLabel, string(createdBinding.UID)))
+				Expect(replica.Annotations).To(HaveKeyWithValue(common.ReplicaOfAnnotation, bindingTestNamespace+"/"+bindingName))
+				Expect(replica.OwnerReferences).To(BeEmpty())
+				validateSecretData(replica, "secret_key", "secret_value")
+				getSecret(ctx, createdBinding.Spec.SecretName, selectedNamespace, true)
+				Expect(getSecret(ctx, createdBinding.Spec.SecretName, refusingNamespace, false).Name).To(BeEmpty())
+
+				By("removing a namespace from the replication")
+				createdBinding.Spec.SecretReplication.NamespaceSelector = nil
+				Expect(k8sClient.Update(ctx, createdBinding)).To(Succeed())
+				Eventually(func() bool {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: createdBinding.Spec.SecretName, Namespace: selectedNamespace}, &corev1.Secret{})
+					return apierrors.IsNotFound(err)
+				}, timeout, interval).Should(BeTrue())
+
+				By("deleting the binding")
+				fakeClient.UnbindReturns("", nil)
+				deleteAndWait(ctx, createdBinding)
+				createdBinding = nil
+				Eventually(func() bool {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: binding.Spec.SecretName, Namespace: allowedNamespace}, &corev1.Secret{})
+					return apierrors.IsNotFound(err)
+				}, timeout, interval).Should(BeTrue())
+			})
+
+			It("should follow namespaces that are created or relabeled after the binding", func() {
+				binding := newBindingObject(bindingName, bindingTestNamespace)
+				binding.Spec.ServiceInstanceName = instanceName
+				binding.Spec.SecretReplication = &v1.SecretReplication{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"late-replicate": testUUID}},
+				}
+				Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+				createdBinding = binding
+				waitForResourceToBeReady(ctx, createdBinding)
+				Expect(createdBinding.Status.ReplicatedTo).To(BeEmpty())
+
+				By("creating a selected namespace")
+				lateNamespace := "late-" + testUUID
+				createNamespace(lateNamespace, map[string]string{"late-replicate": testUUID}, map[string]string{common.AllowReplicationFromAnnotation: "*"})
+				Eventually(func() error {
+					return k8sClient.Get(ctx, types.NamespacedName{Name: createdBinding.Spec.SecretName, Namespace: lateNamespace}, &corev1.Secret{})
+				}, timeout, interval).Should(Succeed())
+
+				By("removing the label of the namespace")
+				namespace := &corev1.Namespace{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: lateNamespace}, namespace)).To(Succeed())
+				namespace.Labels = nil
+				Expect(k8sClient.Update(ctx, namespace)).To(Succeed())
+				Eventually(func() bool {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: createdBinding.Spec.SecretName, Namespace: lateNamespace}, &corev1.Secret{})
+					return apierrors.IsNotFound(err)
+				}, timeout, interval).Should(BeTrue())
+			})
+
+			It("should not overwrite a secret that is not a copy of the binding secret", func() {
+				existing := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: bindingName, Namespace: allowedNamespace}, StringData: map[string]string{"key": "value"}}
+				Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+
+				binding := newBindingObject(bindingName, bindingTestNamespace)
+				binding.Spec.ServiceInstanceName = instanceName
+				binding.Spec.SecretReplication = &v1.SecretReplication{Namespaces: []string{allowedNamespace}}
+				Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+				createdBinding = binding
+				waitForResourceToBeReady(ctx, createdBinding)
+				Expect(createdBinding.Status.ReplicatedTo).To(BeEmpty())
+
+				secret := getSecret(ctx, bindingName, allowedNamespace, true)
+				Expect(secret.Data).To(HaveKeyWithValue("key", []byte("value")))
+				Expect(secret.Labels).ToNot(HaveKey(common.ReplicaOfBindingLabel))
+			})
+		})
+
 		When("instance is provisioned but update failed", func() {
 			It("binding creation succeeds", func() {
 				fakeClient.UpdateInstanceReturns(nil, "", errors.New("update failed"))
@@ -823,7 +1045,7 @@ stringData:
 				createdBinding.Spec.ExternalName = "new-external-name"
 				err := k8sClient.Update(ctx, createdBinding)
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("updating service bindings is not supported"))
+				Expect(err.Error()).To(ContainSubstring("of service bindings is not supported"))
 			})
 		})
 
@@ -832,28 +1054,50 @@ stringData:
 				createdBinding.Spec.ServiceInstanceName = "new-instance-name"
 				err := k8sClient.Update(ctx, createdBinding)
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("updating service bindings is not supported"))
+				Expect(err.Error()).To(ContainSubstring("of service bindings is not supported"))
 			})
 		})
 
 		When("parameters are changed", func() {
-			It("should fail", func() {
+			It("should bind again and delete the old binding", func() {
+				fakeClient.RenameBindingReturns(nil, nil)
+				origBindingID := createdBinding.Status.BindingID
+				bindCalls := fakeClient.BindCallCount()
 				createdBinding.Spec.Parameters = &runtime.RawExtension{
 					Raw: []byte(`{"new-key": "new-value"}`),
 				}
-				err := k8sClient.Update(ctx, createdBinding)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("updating service bindings is not supported"))
+				updateBinding(ctx, getResourceNamespacedName(createdBinding), createdBinding)
+
+				By("validate a new binding was created with the new parameters")
+				Eventually(func() bool {
+					err := k8sClient.Get(ctx, defaultLookupKey, createdBinding)
+					return err == nil && fakeClient.BindCallCount() > bindCalls && isResourceReady(createdBinding) &&
+						createdBinding.Status.HashedParameters == createdBinding.GetParametersHash() &&
+						!meta.IsStatusConditionTrue(createdBinding.Status.Conditions, common.ConditionCredRotationInProgress)
+				}, timeout, interval).Should(BeTrue())
+				_, smBinding, _, _ := fakeClient.BindArgsForCall(fakeClient.BindCallCount() - 1)
+				Expect(string(smBinding.Parameters)).To(ContainSubstring("new-key"))
+				Expect(fakeClient.RenameBindingCallCount()).To(BeNumerically(">", 0))
+
+				By("validate the old binding is deleted")
+				Eventually(func() bool {
+					bindingList := &v1.ServiceBindingList{}
+					err := k8sClient.List(ctx, bindingList, client.MatchingLabels{common.StaleBindingIDLabel: origBindingID}, client.InNamespace(bindingTestNamespace))
+					return err == nil && len(bindingList.Items) == 0
+				}, timeout, interval).Should(BeTrue())
 			})
 		})
 
 		When("secretKey is changed", func() {
-			It("should fail", func() {
-				secretKey := "not-nil"
+			It("should regenerate the secret", func() {
+				secretKey := "credentials"
 				createdBinding.Spec.SecretKey = &secretKey
-				err := k8sClient.Update(ctx, createdBinding)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("updating service bindings is not supported"))
+				createdBinding.Spec.SecretTemplate = ""
+				updateBinding(ctx, getResourceNamespacedName(createdBinding), createdBinding)
+				Eventually(func() bool {
+					bindingSecret := getSecret(ctx, createdBinding.Spec.SecretName, createdBinding.Namespace, true)
+					return len(bindingSecret.Data["credentials"]) > 0
+				}, timeout, interval).Should(BeTrue())
 			})
 		})
 
@@ -965,6 +1209,46 @@ stringData:
 				})
 			})
 
+			When("deletion policy is Retain", func() {
+				BeforeEach(func() {
+					fakeClient.GetBindingByIDReturns(&smClientTypes.ServiceBinding{
+						ID:     fakeBindingID,
+						Labels: smClientTypes.Labels{common.ClusterIDLabel: []string{"cluster"}},
+					}, nil)
+				})
+
+				It("should remove the cluster labels and keep the binding in SM", func() {
+					Eventually(func() bool {
+						if err := k8sClient.Get(ctx, getResourceNamespacedName(createdBinding), createdBinding); err != nil {
+							return false
+						}
+						createdBinding.Spec.DeletionPolicy = v1.DeletionPolicyRetain
+						return k8sClient.Update(ctx, createdBinding) == nil
+					}, timeout, interval).Should(BeTrue())
+					deleteAndWait(ctx, createdBinding)
+					Expect(fakeClient.UnbindCallCount()).To(BeZero())
+					Expect(fakeClient.UpdateBindingLabelsCallCount()).To(Equal(1))
+					_, id, removals := fakeClient.UpdateBindingLabelsArgsForCall(0)
+					Expect(id).To(Equal(fakeBindingID))
+					Expect(removals).To(ConsistOf(&smClientTypes.LabelChange{Operation: smClientTypes.RemoveLabelOperation, Key: common.ClusterIDLabel}))
+				})
+			})
+
+			When("the instance was handed over", func() {
+				It("should release the binding without deleting it from SM", func() {
+					Eventually(func() bool {
+						if err := k8sClient.Get(ctx, getResourceNamespacedName(createdInstance), createdInstance); err != nil {
+							return false
+						}
+						createdInstance.Status.HandedOverTo = "other-namespace"
+						return k8sClient.Status().Update(ctx, createdInstance) == nil
+					}, timeout, interval).Should(BeTrue())
+					deleteAndWait(ctx, createdBinding)
+					Expect(fakeClient.UnbindCallCount()).To(BeZero())
+					Expect(fakeClient.UpdateBindingLabelsCallCount()).To(BeZero())
+				})
+			})
+
 			When("delete when binding id is empty", func() {
 				BeforeEach(func() {
 					fakeClient.UnbindReturns("", nil)
@@ -1155,7 +1439,7 @@ stringData:
 						var err error
 						createdBinding, err = createBindingWithoutAssertions(ctx, bindingName, bindingTestNamespace, instanceName, "", "fake-binding-external-name", "", false)
 						Expect(err).ToNot(HaveOccurred())
-						smCallArgs := fakeClient.ListBindingsArgsForCall(0)
+						_, smCallArgs := fakeClient.ListBindingsArgsForCall(0)
 						Expect(smCallArgs.LabelQuery).To(HaveLen(1))
 						Expect(smCallArgs.LabelQuery[0]).To(ContainSubstring("_k8sname"))
 
@@ -1213,7 +1497,7 @@ stringData:
 		BeforeEach(func() {
 			fakeClient.RenameBindingReturns(nil, nil)
 			createdBinding = createAndValidateBinding(ctx, bindingName, bindingTestNamespace, instanceName, "", "binding-external-name", "", fakeBindingID)
-			fakeClient.ListBindingsStub = func(params *sm.Parameters) (*smClientTypes.ServiceBindings, error) {
+			fakeClient.ListBindingsStub = func(_ context.Context, params *sm.Parameters) (*smClientTypes.ServiceBindings, error) {
 				if params == nil || params.FieldQuery == nil || len(params.FieldQuery) == 0 {
 					return nil, nil
 				}
@@ -1392,7 +1676,7 @@ stringData:
 				fakeClient.BindReturns(&smClientTypes.ServiceBinding{ID: bindingID, Credentials: json.RawMessage(`{"secret_key": "secret_value", "escaped": "{\"escaped_key\":\"escaped_val\"}"}`)}, "", nil)
 				fakeClient.RenameBindingReturns(nil, nil)
 				binding = createAndValidateBinding(ctx, longBindingName, bindingTestNamespace, instanceName, "", longBindingName, "", bindingID)
-				fakeClient.ListBindingsStub = func(params *sm.Parameters) (*smClientTypes.ServiceBindings, error) {
+				fakeClient.ListBindingsStub = func(_ context.Context, params *sm.Parameters) (*smClientTypes.ServiceBindings, error) {
 					if params == nil || params.FieldQuery == nil || len(params.FieldQuery) == 0 {
 						return nil, nil
 					}
@@ -1502,7 +1786,7 @@ stringData:
 			BeforeEach(func() {
 				fakeClient.RenameBindingReturns(nil, nil)
 				crossBinding = createAndValidateBinding(ctx, bindingName, bindingTestNamespace, instanceName, testNamespace, "cross-binding-external-name", "", fakeBindingID)
-				fakeClient.ListBindingsStub = func(params *sm.Parameters) (*smClientTypes.ServiceBindings, error) {
+				fakeClient.ListBindingsStub = func(_ context.Context, params *sm.Parameters) (*smClientTypes.ServiceBindings, error) {
 					if params == nil || params.FieldQuery == nil || len(params.FieldQuery) == 0 {
 						return nil, nil
 					}
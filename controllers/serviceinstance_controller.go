@@ -35,6 +35,8 @@ import (
 
 	"github.com/SAP/sap-btp-service-operator/api/common"
 	"github.com/SAP/sap-btp-service-operator/internal/config"
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+	"github.com/SAP/sap-btp-service-operator/internal/tracing"
 	"github.com/SAP/sap-btp-service-operator/internal/utils"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -70,10 +72,11 @@ type ServiceInstanceReconciler struct {
 
 // +kubebuilder:rbac:groups=services.cloud.sap.com,resources=serviceinstances,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=services.cloud.sap.com,resources=serviceinstances/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=services.cloud.sap.com,resources=servicebindings,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 
-func (r *ServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	correlationID := uuid.New().String()
 	retry := r.Retries.Get(req.NamespacedName)
 	if retry != nil {
@@ -89,6 +92,8 @@ func (r *ServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	ctx = context.WithValue(ctx, logutils.LogKey, log)
 	ctx = context.WithValue(ctx, logutils.CorrelationIDKey, correlationID)
+	ctx, span := tracing.StartReconcile(ctx, "ServiceInstance", req.NamespacedName, correlationID)
+	defer func() { tracing.End(span, err) }()
 
 	serviceInstance := &v1.ServiceInstance{}
 	if err := r.Client.Get(ctx, req.NamespacedName, serviceInstance); err != nil {
@@ -118,6 +123,11 @@ func (r *ServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return r.deleteInstance(ctx, smClient, serviceInstance)
 	}
 
+	if len(serviceInstance.Status.HandedOverTo) > 0 {
+		log.Info(fmt.Sprintf("instance was handed over to %s, nothing to do", serviceInstance.Status.HandedOverTo))
+		return ctrl.Result{}, nil
+	}
+
 	// If stored hash is MD5 (32 chars) and we're now using SHA256 (64 chars),
 	// perform one-time migration by updating the stored hash without triggering update
 	if len(serviceInstance.Status.HashedSpec) == 32 {
@@ -128,8 +138,10 @@ func (r *ServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
 	}
 
+	var smInstance *smClientTypes.ServiceInstance
 	if len(serviceInstance.Status.InstanceID) > 0 {
-		if _, err := smClient.GetInstanceByID(serviceInstance.Status.InstanceID, nil); err != nil {
+		smInstance, err = smClient.GetInstanceByID(ctx, serviceInstance.Status.InstanceID, nil)
+		if err != nil {
 			var smError *sm.ServiceManagerError
 			if ok := errors.As(err, &smError); ok {
 				if smError.StatusCode == http.StatusNotFound {
@@ -144,12 +156,33 @@ func (r *ServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 					}
 					serviceInstance.Status.Conditions = []metav1.Condition{condition}
 					serviceInstance.Status.Ready = metav1.ConditionFalse
+					recordNotFoundEvent(r.Recorder, serviceInstance, condition.Message)
 					return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
 				}
 			}
+			var unavailableErr *sm.ServiceManagerUnavailableError
+			if errors.As(err, &unavailableErr) {
+				return utils.HandleServiceManagerUnavailable(ctx, r.Client, serviceInstance, unavailableErr)
+			}
 			log.Error(err, fmt.Sprintf("failed to get instance %s from SM", serviceInstance.Status.InstanceID))
 			return ctrl.Result{}, err
 		}
+		if utils.RemoveDegradedCondition(serviceInstance) {
+			log.Info("Service Manager is available again, removing degraded condition")
+			if err := utils.UpdateStatus(ctx, r.Client, serviceInstance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if smInstance != nil && len(smInstance.ServicePlanID) > 0 && smInstance.ServicePlanID != serviceInstance.Status.ServicePlanID {
+			log.Info(fmt.Sprintf("instance %s is on plan %s, updating the allowed plan transitions", serviceInstance.Status.InstanceID, smInstance.ServicePlanID))
+			if err := r.setServicePlan(ctx, smClient, serviceInstance, smInstance.ServicePlanID); err != nil {
+				log.Error(err, "failed to update the plan of the instance")
+				return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, common.Unknown, err, false)
+			}
+			if err := utils.UpdateStatus(ctx, r.Client, serviceInstance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 	}
 
 	if len(serviceInstance.GetConditions()) == 0 {
@@ -159,8 +192,21 @@ func (r *ServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	if utils.IsDryRun(serviceInstance) {
+		return r.dryRun(ctx, smClient, serviceInstance, smInstance)
+	} else if serviceInstance.Status.DryRun != nil {
+		log.Info("instance left dry run mode, removing the dry run status")
+		serviceInstance.Status.DryRun = nil
+		if err := utils.UpdateStatus(ctx, r.Client, serviceInstance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	if isFinalState(ctx, serviceInstance) {
-		return r.maintainFinalState(ctx, serviceInstance)
+		if _, _, handover := serviceInstance.GetHandoverTarget(); handover {
+			return r.handover(ctx, smClient, serviceInstance, smInstance)
+		}
+		return r.maintainFinalState(ctx, smClient, serviceInstance, smInstance)
 	}
 
 	if controllerutil.AddFinalizer(serviceInstance, common.FinalizerName) {
@@ -171,6 +217,10 @@ func (r *ServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	if serviceInstance.Status.InstanceID == "" {
+		if len(serviceInstance.Spec.InstanceID) > 0 {
+			return r.adopt(ctx, smClient, serviceInstance)
+		}
+
 		log.Info("Instance ID is empty, checking if instance exist in SM")
 		smInstance, err := r.getInstanceForRecovery(ctx, smClient, serviceInstance)
 		if err != nil {
@@ -194,6 +244,13 @@ func (r *ServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return r.handleInstanceSharing(ctx, serviceInstance, smClient)
 	}
 
+	if serviceInstance.Generation != common.GetObservedGeneration(serviceInstance) && serviceInstance.GetSpecHash() == serviceInstance.Status.HashedSpec {
+		// e.g. the drift or deletion policy changed, nothing to send to SM
+		log.Info("spec change does not require an update in SM, updating observed generation")
+		utils.AlignConditionsGeneration(serviceInstance)
+		return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
+	}
+
 	log.Info("No action required")
 	return ctrl.Result{}, nil
 }
@@ -216,7 +273,13 @@ func (r *ServiceInstanceReconciler) createInstance(ctx context.Context, smClient
 		return utils.HandleOperationFailure(ctx, r.Client, serviceInstance, smClientTypes.CREATE, err)
 	}
 
-	provision, provisionErr := smClient.Provision(&smClientTypes.ServiceInstance{
+	if err := validateParametersSchema(ctx, r.Recorder, r.Config, smClient, serviceInstance, serviceInstance, utils.InstanceCreateSchema, instanceParameters); err != nil {
+		log.Error(err, "instance parameters do not match the plan schema")
+		recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.CREATE, smClientTypes.FAILED, err.Error())
+		return utils.HandleOperationFailure(ctx, r.Client, serviceInstance, smClientTypes.CREATE, err)
+	}
+
+	provision, provisionErr := smClient.Provision(ctx, &smClientTypes.ServiceInstance{
 		Name:          serviceInstance.Spec.ExternalName,
 		ServicePlanID: serviceInstance.Spec.ServicePlanID,
 		Parameters:    instanceParameters,
@@ -230,11 +293,16 @@ func (r *ServiceInstanceReconciler) createInstance(ctx context.Context, smClient
 	if provisionErr != nil {
 		log.Error(provisionErr, "failed to create service instance", "serviceOfferingName", serviceInstance.Spec.ServiceOfferingName,
 			"servicePlanName", serviceInstance.Spec.ServicePlanName)
+		recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.CREATE, smClientTypes.FAILED, fmt.Sprintf("failed to provision instance: %s", provisionErr.Error()))
 		return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.CREATE, provisionErr, true)
 	}
 
 	serviceInstance.Status.InstanceID = provision.InstanceID
 	serviceInstance.Status.SubaccountID = provision.SubaccountID
+	if err := r.setServicePlan(ctx, smClient, serviceInstance, provision.PlanID); err != nil {
+		// the plan of the instance in SM differs from the status, the next reconcile sets it again
+		log.Error(err, "failed to set the plan of the provisioned instance")
+	}
 	if len(provision.Tags) > 0 {
 		tags, err := getTags(provision.Tags)
 		if err != nil {
@@ -249,6 +317,8 @@ func (r *ServiceInstanceReconciler) createInstance(ctx context.Context, smClient
 		serviceInstance.Status.OperationURL = provision.Location
 		serviceInstance.Status.OperationType = smClientTypes.CREATE
 		utils.SetInProgressConditions(ctx, smClientTypes.CREATE, "", serviceInstance, false)
+		metrics.Provisions.Started(serviceInstance.UID)
+		recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.CREATE, smClientTypes.INPROGRESS, fmt.Sprintf("provisioning of instance %s started", serviceInstance.Status.InstanceID))
 
 		return ctrl.Result{RequeueAfter: r.Config.PollInterval}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
 	}
@@ -257,6 +327,7 @@ func (r *ServiceInstanceReconciler) createInstance(ctx context.Context, smClient
 		serviceInstance.Status.SubaccountID))
 	r.Retries.Reset(types.NamespacedName{Name: serviceInstance.Name, Namespace: serviceInstance.Namespace})
 	utils.SetSuccessConditions(smClientTypes.CREATE, serviceInstance, false)
+	recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.CREATE, smClientTypes.SUCCEEDED, fmt.Sprintf("instance %s provisioned successfully", serviceInstance.Status.InstanceID))
 	return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
 }
 
@@ -264,21 +335,42 @@ func (r *ServiceInstanceReconciler) updateInstance(ctx context.Context, smClient
 	log := logutils.GetLogger(ctx)
 	log.Info(fmt.Sprintf("updating instance %s in SM", serviceInstance.Status.InstanceID))
 
+	if err := utils.ValidatePlanTransition(ctx, smClient, serviceInstance.Status.ServicePlanID, serviceInstance); err != nil {
+		var planErr *utils.PlanNotUpdatableError
+		if !errors.As(err, &planErr) {
+			log.Info(fmt.Sprintf("could not validate the plan change, leaving it to Service Manager: %s", err.Error()))
+		} else {
+			// the change can't succeed until the plan in the spec is reverted, so there is no point in retrying
+			log.Info(fmt.Sprintf("blocking the update of instance %s: %s", serviceInstance.Status.InstanceID, err.Error()))
+			recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.UPDATE, smClientTypes.FAILED, err.Error())
+			utils.SetPlanNotUpdatableCondition(err.Error(), serviceInstance)
+			return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
+		}
+	}
+
 	instanceParameters, err := r.buildSMRequestParameters(ctx, serviceInstance)
 	if err != nil {
 		log.Error(err, "failed to parse instance parameters")
 		return utils.HandleOperationFailure(ctx, r.Client, serviceInstance, smClientTypes.UPDATE, err)
 	}
 
+	if err := validateParametersSchema(ctx, r.Recorder, r.Config, smClient, serviceInstance, serviceInstance, utils.InstanceUpdateSchema, instanceParameters); err != nil {
+		log.Error(err, "instance parameters do not match the plan schema")
+		recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.UPDATE, smClientTypes.FAILED, err.Error())
+		return utils.HandleOperationFailure(ctx, r.Client, serviceInstance, smClientTypes.UPDATE, err)
+	}
+
 	updateHashedSpecValue(serviceInstance)
-	_, operationURL, err := smClient.UpdateInstance(serviceInstance.Status.InstanceID, &smClientTypes.ServiceInstance{
+	updatedInstance := &smClientTypes.ServiceInstance{
 		Name:          serviceInstance.Spec.ExternalName,
 		ServicePlanID: serviceInstance.Spec.ServicePlanID,
 		Parameters:    instanceParameters,
-	}, serviceInstance.Spec.ServiceOfferingName, serviceInstance.Spec.ServicePlanName, nil, utils.BuildUserInfo(ctx, serviceInstance.Spec.UserInfo), serviceInstance.Spec.DataCenter)
+	}
+	smInstance, operationURL, err := smClient.UpdateInstance(ctx, serviceInstance.Status.InstanceID, updatedInstance, serviceInstance.Spec.ServiceOfferingName, serviceInstance.Spec.ServicePlanName, nil, utils.BuildUserInfo(ctx, serviceInstance.Spec.UserInfo), serviceInstance.Spec.DataCenter)
 
 	if err != nil {
 		log.Error(err, fmt.Sprintf("failed to update service instance with ID %s", serviceInstance.Status.InstanceID))
+		recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.UPDATE, smClientTypes.FAILED, fmt.Sprintf("failed to update instance: %s", err.Error()))
 		return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.UPDATE, err, true)
 	}
 
@@ -288,6 +380,7 @@ func (r *ServiceInstanceReconciler) updateInstance(ctx context.Context, smClient
 		serviceInstance.Status.OperationType = smClientTypes.UPDATE
 		utils.SetInProgressConditions(ctx, smClientTypes.UPDATE, "", serviceInstance, false)
 		serviceInstance.Status.ForceReconcile = false
+		recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.UPDATE, smClientTypes.INPROGRESS, fmt.Sprintf("update of instance %s started", serviceInstance.Status.InstanceID))
 		if err := utils.UpdateStatus(ctx, r.Client, serviceInstance); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -295,7 +388,17 @@ func (r *ServiceInstanceReconciler) updateInstance(ctx context.Context, smClient
 		return ctrl.Result{RequeueAfter: r.Config.PollInterval}, nil
 	}
 	log.Info("Instance updated successfully")
+	// the plan may be given by name only, the client resolves it to the plan ID of the request
+	planID := updatedInstance.ServicePlanID
+	if smInstance != nil && len(smInstance.ServicePlanID) > 0 {
+		planID = smInstance.ServicePlanID
+	}
+	if err := r.setServicePlan(ctx, smClient, serviceInstance, planID); err != nil {
+		// the plan of the instance in SM differs from the status, the next reconcile sets it again
+		log.Error(err, "failed to set the plan of the updated instance")
+	}
 	utils.SetSuccessConditions(smClientTypes.UPDATE, serviceInstance, false)
+	recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.UPDATE, smClientTypes.SUCCEEDED, fmt.Sprintf("instance %s updated successfully", serviceInstance.Status.InstanceID))
 	serviceInstance.Status.ForceReconcile = false
 	return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
 }
@@ -304,6 +407,12 @@ func (r *ServiceInstanceReconciler) deleteInstance(ctx context.Context, smClient
 	log := logutils.GetLogger(ctx)
 
 	if controllerutil.ContainsFinalizer(serviceInstance, common.FinalizerName) {
+		// a provision in flight will not be observed once the instance is deleted, released or handed over
+		metrics.Provisions.Forget(serviceInstance.UID)
+		if len(serviceInstance.Status.HandedOverTo) > 0 {
+			return r.releaseHandedOverInstance(ctx, serviceInstance)
+		}
+
 		log.Info("instance has finalizer, deleting it from sm")
 		if len(serviceInstance.Status.InstanceID) == 0 {
 			log.Info("No instance id found validating instance does not exists in SM before removing finalizer")
@@ -321,9 +430,14 @@ func (r *ServiceInstanceReconciler) deleteInstance(ctx context.Context, smClient
 			return ctrl.Result{}, utils.RemoveFinalizer(ctx, r.Client, serviceInstance, common.FinalizerName)
 		}
 
+		if serviceInstance.Spec.DeletionPolicy == v1.DeletionPolicyRetain {
+			return r.retainInstance(ctx, smClient, serviceInstance)
+		}
+
 		log.Info(fmt.Sprintf("Deleting instance with id %v from SM", serviceInstance.Status.InstanceID))
-		operationURL, deprovisionErr := smClient.Deprovision(serviceInstance.Status.InstanceID, nil, utils.BuildUserInfo(ctx, serviceInstance.Spec.UserInfo))
+		operationURL, deprovisionErr := smClient.Deprovision(ctx, serviceInstance.Status.InstanceID, nil, utils.BuildUserInfo(ctx, serviceInstance.Spec.UserInfo))
 		if deprovisionErr != nil {
+			recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.DELETE, smClientTypes.FAILED, fmt.Sprintf("failed to deprovision instance: %s", deprovisionErr.Error()))
 			return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.DELETE, deprovisionErr, true)
 		}
 
@@ -332,13 +446,8 @@ func (r *ServiceInstanceReconciler) deleteInstance(ctx context.Context, smClient
 			return r.handleAsyncDelete(ctx, serviceInstance, operationURL)
 		}
 
-		for key, secretName := range serviceInstance.Labels {
-			if strings.HasPrefix(key, common.InstanceSecretRefLabel) {
-				if err := utils.RemoveWatchForSecret(ctx, r.Client, types.NamespacedName{Name: secretName, Namespace: serviceInstance.Namespace}, string(serviceInstance.UID)); err != nil {
-					log.Error(err, fmt.Sprintf("failed to unwatch secret %s", secretName))
-					return ctrl.Result{}, err
-				}
-			}
+		if err := r.unwatchParametersSources(ctx, serviceInstance); err != nil {
+			return ctrl.Result{}, err
 		}
 
 		serviceInstance.Status.InstanceID = ""
@@ -347,33 +456,56 @@ func (r *ServiceInstanceReconciler) deleteInstance(ctx context.Context, smClient
 			return ctrl.Result{}, err
 		}
 		log.Info("Instance was deleted successfully, removing finalizer")
+		recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.DELETE, smClientTypes.SUCCEEDED, "instance deprovisioned successfully")
 		// remove our finalizer from the list and update it.
 		return ctrl.Result{}, utils.RemoveFinalizer(ctx, r.Client, serviceInstance, common.FinalizerName)
 	}
 	return ctrl.Result{}, nil
 }
 
+func (r *ServiceInstanceReconciler) unwatchParametersSources(ctx context.Context, serviceInstance *v1.ServiceInstance) error {
+	log := logutils.GetLogger(ctx)
+	for key, sourceName := range serviceInstance.Labels {
+		if strings.HasPrefix(key, common.InstanceSecretRefLabel) {
+			if err := utils.RemoveWatchForSecret(ctx, r.Client, types.NamespacedName{Name: sourceName, Namespace: serviceInstance.Namespace}, string(serviceInstance.UID)); err != nil {
+				log.Error(err, fmt.Sprintf("failed to unwatch secret %s", sourceName))
+				return err
+			}
+		} else if strings.HasPrefix(key, common.InstanceConfigMapRefLabel) {
+			if err := utils.RemoveWatchForConfigMap(ctx, r.Client, types.NamespacedName{Name: sourceName, Namespace: serviceInstance.Namespace}, string(serviceInstance.UID)); err != nil {
+				log.Error(err, fmt.Sprintf("failed to unwatch config map %s", sourceName))
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (r *ServiceInstanceReconciler) handleInstanceSharing(ctx context.Context, serviceInstance *v1.ServiceInstance, smClient sm.Client) (ctrl.Result, error) {
 	log := logutils.GetLogger(ctx)
 	log.Info("Handling change in instance sharing")
 
 	if serviceInstance.GetShared() {
 		log.Info("Service instance appears to be unshared, sharing the instance")
-		err := smClient.ShareInstance(serviceInstance.Status.InstanceID, utils.BuildUserInfo(ctx, serviceInstance.Spec.UserInfo))
+		err := smClient.ShareInstance(ctx, serviceInstance.Status.InstanceID, utils.BuildUserInfo(ctx, serviceInstance.Spec.UserInfo))
 		if err != nil {
 			log.Error(err, "failed to share instance")
+			r.Recorder.Eventf(serviceInstance, nil, corev1.EventTypeWarning, common.ShareFailed, actionShare, "failed to share instance: %s", err.Error())
 			return utils.HandleInstanceSharingError(ctx, r.Client, serviceInstance, metav1.ConditionFalse, common.ShareFailed, err)
 		}
 		log.Info("instance shared successfully")
 		utils.SetSharedCondition(serviceInstance, metav1.ConditionTrue, common.ShareSucceeded, "instance shared successfully")
+		r.Recorder.Eventf(serviceInstance, nil, corev1.EventTypeNormal, common.ShareSucceeded, actionShare, "instance shared successfully")
 	} else { //un-share
 		log.Info("Service instance appears to be shared, un-sharing the instance")
-		err := smClient.UnShareInstance(serviceInstance.Status.InstanceID, utils.BuildUserInfo(ctx, serviceInstance.Spec.UserInfo))
+		err := smClient.UnShareInstance(ctx, serviceInstance.Status.InstanceID, utils.BuildUserInfo(ctx, serviceInstance.Spec.UserInfo))
 		if err != nil {
 			log.Error(err, "failed to un-share instance")
+			r.Recorder.Eventf(serviceInstance, nil, corev1.EventTypeWarning, common.UnShareFailed, actionUnShare, "failed to un-share instance: %s", err.Error())
 			return utils.HandleInstanceSharingError(ctx, r.Client, serviceInstance, metav1.ConditionTrue, common.UnShareFailed, err)
 		}
 		log.Info("instance un-shared successfully")
+		r.Recorder.Eventf(serviceInstance, nil, corev1.EventTypeNormal, common.UnShareSucceeded, actionUnShare, "instance un-shared successfully")
 		if serviceInstance.Spec.Shared != nil {
 			utils.SetSharedCondition(serviceInstance, metav1.ConditionFalse, common.UnShareSucceeded, "instance un-shared successfully")
 		} else {
@@ -390,7 +522,7 @@ func (r *ServiceInstanceReconciler) handleInstanceSharing(ctx context.Context, s
 func (r *ServiceInstanceReconciler) poll(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance) (ctrl.Result, error) {
 	log := logutils.GetLogger(ctx)
 	log.Info(fmt.Sprintf("instance %s is '%s' in progress, polling operation %s", serviceInstance.Status.InstanceID, serviceInstance.Status.OperationType, serviceInstance.Status.OperationURL))
-	status, statusErr := smClient.Status(serviceInstance.Status.OperationURL, serviceInstance.Status.OperationType, nil)
+	status, statusErr := smClient.Status(ctx, serviceInstance.Status.OperationURL, serviceInstance.Status.OperationType, nil)
 	if statusErr != nil {
 		log.Info(fmt.Sprintf("failed to fetch operation, got error from SM: %s", statusErr.Error()), "operationURL", serviceInstance.Status.OperationURL)
 		return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, serviceInstance.Status.OperationType, statusErr, true)
@@ -407,6 +539,9 @@ func (r *ServiceInstanceReconciler) poll(ctx context.Context, smClient sm.Client
 		log.Info(fmt.Sprintf("operation %s %s is still in progress", serviceInstance.Status.OperationType, serviceInstance.Status.OperationURL))
 		if len(status.Description) > 0 {
 			log.Info(fmt.Sprintf("last operation description is '%s'", status.Description))
+			if progressChanged(serviceInstance, status.Description) {
+				recordOperationEvent(r.Recorder, serviceInstance, status.Type, status.State, status.Description)
+			}
 			utils.SetInProgressConditions(ctx, status.Type, status.Description, serviceInstance, true)
 			if err := utils.UpdateStatus(ctx, r.Client, serviceInstance); err != nil {
 				log.Error(err, "unable to update ServiceInstance polling description")
@@ -417,10 +552,12 @@ func (r *ServiceInstanceReconciler) poll(ctx context.Context, smClient sm.Client
 	case smClientTypes.FAILED:
 		errMsg := getErrorMsgFromLastOperation(status)
 		log.Info(fmt.Sprintf("operation %s %s failed, error: %s", serviceInstance.Status.OperationType, serviceInstance.Status.OperationURL, errMsg))
+		recordOperationEvent(r.Recorder, serviceInstance, status.Type, smClientTypes.FAILED, errMsg)
 		utils.SetFailureConditions(status.Type, errMsg, serviceInstance, true)
 		if serviceInstance.Status.OperationType == smClientTypes.CREATE ||
 			(serviceInstance.Status.OperationType == smClientTypes.DELETE && !utils.IsMarkedForDeletion(serviceInstance.ObjectMeta)) {
 			log.Info(fmt.Sprintf("async provision failed for instance %s", serviceInstance.Status.InstanceID))
+			metrics.Provisions.Forget(serviceInstance.UID)
 			key := types.NamespacedName{Namespace: serviceInstance.GetNamespace(), Name: serviceInstance.GetName()}
 			newState := r.Retries.RegisterFailure(key, logutils.GetCorrelationID(ctx))
 			log.Info(fmt.Sprintf("async provision failed. attempts=%d nextRetry=%s currrent error=%s\n", newState.Attempts, newState.NextRetry.Format(time.RFC3339), errMsg))
@@ -434,8 +571,9 @@ func (r *ServiceInstanceReconciler) poll(ctx context.Context, smClient sm.Client
 		return ctrl.Result{}, errors.New(errMsg)
 	case smClientTypes.SUCCEEDED:
 		log.Info(fmt.Sprintf("operation %s %s completed succefully", serviceInstance.Status.OperationType, serviceInstance.Status.OperationURL))
+		recordOperationEvent(r.Recorder, serviceInstance, status.Type, smClientTypes.SUCCEEDED, fmt.Sprintf("%s operation of instance %s completed successfully", status.Type, serviceInstance.Status.InstanceID))
 		if serviceInstance.Status.OperationType == smClientTypes.CREATE {
-			smInstance, err := smClient.GetInstanceByID(serviceInstance.Status.InstanceID, nil)
+			smInstance, err := smClient.GetInstanceByID(ctx, serviceInstance.Status.InstanceID, nil)
 			if err != nil {
 				log.Error(err, fmt.Sprintf("instance %s succeeded but could not fetch it from SM", serviceInstance.Status.InstanceID))
 				return ctrl.Result{}, err
@@ -444,6 +582,7 @@ func (r *ServiceInstanceReconciler) poll(ctx context.Context, smClient sm.Client
 				serviceInstance.Status.SubaccountID = smInstance.Labels["subaccount_id"][0]
 			}
 			serviceInstance.Status.Ready = metav1.ConditionTrue
+			metrics.Provisions.Succeeded(serviceInstance.UID, serviceInstance.Spec.ServiceOfferingName, serviceInstance.Spec.ServicePlanName)
 		} else if serviceInstance.Status.OperationType == smClientTypes.DELETE {
 			log.Info(fmt.Sprintf("instance %s deleted successfully from sm, removing finalizer", serviceInstance.Status.InstanceID))
 			if err := utils.RemoveFinalizer(ctx, r.Client, serviceInstance, common.FinalizerName); err != nil {
@@ -466,7 +605,7 @@ func (r *ServiceInstanceReconciler) poll(ctx context.Context, smClient sm.Client
 func (r *ServiceInstanceReconciler) handleFailedAsyncProvision(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance) (ctrl.Result, error) {
 	log := logutils.GetLogger(ctx)
 	log.Info(fmt.Sprintf("handleFailedAsyncProvision deleting instance that failed to be provisioned with id %s from SM", serviceInstance.Status.InstanceID))
-	operationURL, deprovisionErr := smClient.Deprovision(serviceInstance.Status.InstanceID, nil, utils.BuildUserInfo(ctx, serviceInstance.Spec.UserInfo))
+	operationURL, deprovisionErr := smClient.Deprovision(ctx, serviceInstance.Status.InstanceID, nil, utils.BuildUserInfo(ctx, serviceInstance.Spec.UserInfo))
 	if deprovisionErr != nil {
 		log.Error(deprovisionErr, fmt.Sprintf("handleFailedAsyncProvision failed to deprovision instance: %s", serviceInstance.Status.InstanceID))
 		return utils.HandleServiceManagerError(ctx, r.Client, serviceInstance, smClientTypes.DELETE, deprovisionErr, false)
@@ -494,6 +633,7 @@ func (r *ServiceInstanceReconciler) handleAsyncDelete(ctx context.Context, servi
 	serviceInstance.Status.OperationURL = opURL
 	serviceInstance.Status.OperationType = smClientTypes.DELETE
 	utils.SetInProgressConditions(ctx, smClientTypes.DELETE, "", serviceInstance, false)
+	recordOperationEvent(r.Recorder, serviceInstance, smClientTypes.DELETE, smClientTypes.INPROGRESS, fmt.Sprintf("deprovisioning of instance %s started", serviceInstance.Status.InstanceID))
 
 	return ctrl.Result{RequeueAfter: r.Config.PollInterval}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
 }
@@ -510,12 +650,31 @@ func (r *ServiceInstanceReconciler) getInstanceForRecovery(ctx context.Context,
 		GeneralParams: []string{"attach_last_operations=true"},
 	}
 
-	instances, err := smClient.ListInstances(&parameters)
+	instances, err := smClient.ListInstances(ctx, &parameters)
 	if err != nil {
 		log.Error(err, "failed to list instances in SM")
 		return nil, err
 	}
 
+	if instances != nil {
+		for i := range instances.ServiceInstances {
+			if !handedOverElsewhere(instances.ServiceInstances[i].Labels, serviceInstance.Namespace, r.Config.ClusterID) {
+				return &instances.ServiceInstances[i], nil
+			}
+		}
+	}
+
+	log.Info("instance not found in SM by its context, looking for an instance handed over to it")
+	parameters = sm.Parameters{
+		FieldQuery:    []string{fmt.Sprintf("name eq '%s'", serviceInstance.Spec.ExternalName)},
+		LabelQuery:    handedOverQuery(serviceInstance.Namespace, serviceInstance.Name, r.Config.ClusterID),
+		GeneralParams: []string{"attach_last_operations=true"},
+	}
+	instances, err = smClient.ListInstances(ctx, &parameters)
+	if err != nil {
+		log.Error(err, "failed to list instances in SM")
+		return nil, err
+	}
 	if instances != nil && len(instances.ServiceInstances) > 0 {
 		return &instances.ServiceInstances[0], nil
 	}
@@ -527,6 +686,16 @@ func (r *ServiceInstanceReconciler) recover(ctx context.Context, smClient sm.Cli
 	log := logutils.GetLogger(ctx)
 
 	log.Info(fmt.Sprintf("found existing instance in SM with id %s, updating status", smInstance.ID))
+	r.Recorder.Eventf(k8sInstance, nil, corev1.EventTypeNormal, common.Recovered, actionRecover, "recovered existing instance %s from Service Manager", smInstance.ID)
+	return r.importInstance(ctx, smClient, k8sInstance, smInstance)
+}
+
+// importInstance takes the state of an instance that exists in SM into the status of the k8s instance
+func (r *ServiceInstanceReconciler) importInstance(ctx context.Context, smClient sm.Client, k8sInstance *v1.ServiceInstance, smInstance *smClientTypes.ServiceInstance) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+
+	// the instance was provisioned outside of this reconciler, e.g. adopted or recovered, its provision is not observed
+	metrics.Provisions.Forget(k8sInstance.UID)
 	updateHashedSpecValue(k8sInstance)
 	if smInstance.Ready {
 		k8sInstance.Status.Ready = metav1.ConditionTrue
@@ -537,7 +706,11 @@ func (r *ServiceInstanceReconciler) recover(ctx context.Context, smClient sm.Cli
 	k8sInstance.Status.InstanceID = smInstance.ID
 	k8sInstance.Status.OperationURL = ""
 	k8sInstance.Status.OperationType = ""
-	tags, err := getOfferingTags(smClient, smInstance.ServicePlanID)
+	if err := r.setServicePlan(ctx, smClient, k8sInstance, smInstance.ServicePlanID); err != nil {
+		// the plan of the instance in SM differs from the status, the next reconcile sets it again
+		log.Error(err, "failed to set the plan of the imported instance")
+	}
+	tags, err := getOfferingTags(ctx, smClient, smInstance.ServicePlanID)
 	if err != nil {
 		log.Error(err, "could not recover offering tags")
 	}
@@ -579,7 +752,7 @@ func (r *ServiceInstanceReconciler) recover(ctx context.Context, smClient sm.Cli
 
 func (r *ServiceInstanceReconciler) buildSMRequestParameters(ctx context.Context, serviceInstance *v1.ServiceInstance) ([]byte, error) {
 	log := logutils.GetLogger(ctx)
-	instanceParameters, paramSecrets, err := utils.BuildSMRequestParameters(serviceInstance.Namespace, serviceInstance.Spec.Parameters, serviceInstance.Spec.ParametersFrom)
+	instanceParameters, paramSources, err := utils.BuildSMRequestParameters(ctx, serviceInstance.Namespace, serviceInstance.Spec.Parameters, serviceInstance.Spec.ParametersFrom)
 	if err != nil {
 		log.Error(err, "failed to build instance parameters")
 		return nil, err
@@ -588,7 +761,7 @@ func (r *ServiceInstanceReconciler) buildSMRequestParameters(ctx context.Context
 	newInstanceLabels := make(map[string]string)
 	if serviceInstance.IsSubscribedToParamSecretsChanges() {
 		// find all new secrets on the instance
-		for _, secret := range paramSecrets {
+		for _, secret := range paramSources.Secrets {
 			labelKey := utils.GetLabelKeyForInstanceSecret(secret.Name)
 			newInstanceLabels[labelKey] = secret.Name
 			if _, ok := serviceInstance.Labels[labelKey]; !ok {
@@ -600,6 +773,20 @@ func (r *ServiceInstanceReconciler) buildSMRequestParameters(ctx context.Context
 				return nil, err
 			}
 		}
+
+		// find all new config maps on the instance
+		for _, configMap := range paramSources.ConfigMaps {
+			labelKey := utils.GetLabelKeyForInstanceConfigMap(configMap.Name)
+			newInstanceLabels[labelKey] = configMap.Name
+			if _, ok := serviceInstance.Labels[labelKey]; !ok {
+				instanceLabelsChanged = true
+			}
+
+			if err := utils.AddWatchForConfigMapIfNeeded(ctx, r.Client, configMap, string(serviceInstance.UID)); err != nil {
+				log.Error(err, fmt.Sprintf("failed to mark config map for watch %s", configMap.Name))
+				return nil, err
+			}
+		}
 	}
 
 	//sync instance labels
@@ -613,34 +800,82 @@ func (r *ServiceInstanceReconciler) buildSMRequestParameters(ctx context.Context
 					return nil, err
 				}
 			}
+		} else if strings.HasPrefix(labelKey, common.InstanceConfigMapRefLabel) {
+			if _, ok := newInstanceLabels[labelKey]; !ok {
+				log.Info(fmt.Sprintf("params config map named %s was removed, unwatching it", labelValue))
+				instanceLabelsChanged = true
+				if err := utils.RemoveWatchForConfigMap(ctx, r.Client, types.NamespacedName{Name: labelValue, Namespace: serviceInstance.Namespace}, string(serviceInstance.UID)); err != nil {
+					log.Error(err, fmt.Sprintf("failed to unwatch config map %s", labelValue))
+					return nil, err
+				}
+			}
 		} else {
-			// this label not related to secrets, add it
+			// this label not related to parameters sources, add it
 			newInstanceLabels[labelKey] = labelValue
 		}
 	}
 	if instanceLabelsChanged {
 		serviceInstance.Labels = newInstanceLabels
-		log.Info("updating instance with secret labels")
+		log.Info("updating instance with parameters sources labels")
 		return instanceParameters, r.Client.Update(ctx, serviceInstance)
 	}
 
 	return instanceParameters, nil
 }
 
-func (r *ServiceInstanceReconciler) maintainFinalState(ctx context.Context, serviceInstance *v1.ServiceInstance) (ctrl.Result, error) {
+// setServicePlan records the current plan of the instance and the plans it can be updated to. The status is left unchanged
+// when the plans can't be resolved, so that the plan is set again once they can.
+func (r *ServiceInstanceReconciler) setServicePlan(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance, planID string) error {
+	if len(planID) == 0 {
+		return nil
+	}
+	allowedPlans, err := utils.GetAllowedPlanTransitions(ctx, smClient, planID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the allowed plan transitions of plan %s: %w", planID, err)
+	}
+	serviceInstance.Status.ServicePlanID = planID
+	serviceInstance.Status.AllowedPlans = allowedPlans
+	return nil
+}
+
+func (r *ServiceInstanceReconciler) maintainFinalState(ctx context.Context, smClient sm.Client, serviceInstance *v1.ServiceInstance, smInstance *smClientTypes.ServiceInstance) (ctrl.Result, error) {
 	log := logutils.GetLogger(ctx)
 
 	if serviceInstance.IsSubscribedToParamSecretsChanges() {
-		log.Info("instance is in final state, WatchParametersFromChanges is true, validating that all parameters secrets are watched")
+		log.Info("instance is in final state, WatchParametersFromChanges is true, validating that all parameters sources are watched")
 		for _, param := range serviceInstance.Spec.ParametersFrom {
-			if param.SecretKeyRef != nil {
+			var secretName, configMapName string
+			switch {
+			case param.SecretKeyRef != nil:
+				secretName = param.SecretKeyRef.Name
+			case param.SecretRef != nil:
+				secretName = param.SecretRef.Name
+			case param.ConfigMapKeyRef != nil:
+				configMapName = param.ConfigMapKeyRef.Name
+			case param.ConfigMapRef != nil:
+				configMapName = param.ConfigMapRef.Name
+			}
+
+			if len(secretName) > 0 {
 				secret := &corev1.Secret{}
-				if err := utils.GetSecretWithFallback(ctx, types.NamespacedName{Name: param.SecretKeyRef.Name, Namespace: serviceInstance.Namespace}, secret); err != nil {
-					log.Error(err, fmt.Sprintf("failed to get secret %s", param.SecretKeyRef.Name))
+				if err := utils.GetSecretWithFallback(ctx, types.NamespacedName{Name: secretName, Namespace: serviceInstance.Namespace}, secret); err != nil {
+					log.Error(err, fmt.Sprintf("failed to get secret %s", secretName))
 					return ctrl.Result{}, err
 				}
 				if err := utils.AddWatchForSecretIfNeeded(ctx, r.Client, secret, string(serviceInstance.UID)); err != nil {
-					log.Error(err, fmt.Sprintf("failed to mark secret for watch %s", param.SecretKeyRef.Name))
+					log.Error(err, fmt.Sprintf("failed to mark secret for watch %s", secretName))
+					return ctrl.Result{}, err
+				}
+			}
+
+			if len(configMapName) > 0 {
+				configMap := &corev1.ConfigMap{}
+				if err := utils.GetConfigMapWithFallback(ctx, types.NamespacedName{Name: configMapName, Namespace: serviceInstance.Namespace}, configMap); err != nil {
+					log.Error(err, fmt.Sprintf("failed to get config map %s", configMapName))
+					return ctrl.Result{}, err
+				}
+				if err := utils.AddWatchForConfigMapIfNeeded(ctx, r.Client, configMap, string(serviceInstance.UID)); err != nil {
+					log.Error(err, fmt.Sprintf("failed to mark config map for watch %s", configMapName))
 					return ctrl.Result{}, err
 				}
 			}
@@ -652,8 +887,13 @@ func (r *ServiceInstanceReconciler) maintainFinalState(ctx context.Context, serv
 		updateHashedSpecValue(serviceInstance)
 		return ctrl.Result{}, utils.UpdateStatus(ctx, r.Client, serviceInstance)
 	}
+
+	requeueAfter, err := r.checkDrift(ctx, smClient, serviceInstance, smInstance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 	log.Info("maintain finished successfully")
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func isFinalState(ctx context.Context, serviceInstance *v1.ServiceInstance) bool {
@@ -732,11 +972,11 @@ func shareOrUnshareRequired(serviceInstance *v1.ServiceInstance) bool {
 	return !serviceInstance.GetShared()
 }
 
-func getOfferingTags(smClient sm.Client, planID string) ([]string, error) {
+func getOfferingTags(ctx context.Context, smClient sm.Client, planID string) ([]string, error) {
 	planQuery := &sm.Parameters{
 		FieldQuery: []string{fmt.Sprintf("id eq '%s'", planID)},
 	}
-	plans, err := smClient.ListPlans(planQuery)
+	plans, err := smClient.ListPlans(ctx, planQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -749,7 +989,7 @@ func getOfferingTags(smClient sm.Client, planID string) ([]string, error) {
 		FieldQuery: []string{fmt.Sprintf("id eq '%s'", plans.ServicePlans[0].ServiceOfferingID)},
 	}
 
-	offerings, err := smClient.ListOfferings(offeringQuery)
+	offerings, err := smClient.ListOfferings(ctx, offeringQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,6 +19,7 @@ import (
 	"github.com/SAP/sap-btp-service-operator/client/sm/smfakes"
 	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
 	smclientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/SAP/sap-btp-service-operator/internal/config"
 	"github.com/SAP/sap-btp-service-operator/internal/utils"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo"
@@ -29,8 +31,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 // +kubebuilder:docs-gen:collapse=Imports
@@ -209,6 +213,39 @@ var _ = Describe("ServiceInstance controller", func() {
 					})
 				})
 			})
+
+			When("parameters do not match the plan schema", func() {
+				BeforeEach(func() {
+					fakeClient.GetPlanReturns(&smclientTypes.ServicePlan{
+						Name:    fakePlanName,
+						Schemas: []byte(`{"service_instance":{"create":{"parameters":{"type":"object","properties":{"key":{"type":"integer"}}}}}}`),
+					}, nil, nil)
+				})
+
+				It("provisioning should fail before calling SM", func() {
+					serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, false)
+					waitForInstanceConditionAndMessage(ctx, defaultLookupKey, common.ConditionSucceeded, "parameters.key in body must be of type integer")
+					Expect(fakeClient.ProvisionCallCount()).To(BeZero())
+				})
+			})
+
+			When("instance is in dry run mode", func() {
+				BeforeEach(func() {
+					fakeClient.GetPlanReturns(&smclientTypes.ServicePlan{ID: "fake-plan-id", Name: fakePlanName}, nil, nil)
+				})
+
+				It("should publish the request without provisioning", func() {
+					serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, map[string]string{common.DryRunAnnotation: "true"}, false)
+					Eventually(func() bool {
+						err := k8sClient.Get(ctx, defaultLookupKey, serviceInstance)
+						return err == nil && serviceInstance.Status.DryRun != nil
+					}, timeout, interval).Should(BeTrue())
+					Expect(serviceInstance.Status.DryRun.Operation).To(Equal(v1.DryRunCreate))
+					Expect(serviceInstance.Status.DryRun.ServicePlanID).To(Equal("fake-plan-id"))
+					Expect(serviceInstance.Status.DryRun.Changes).To(ContainElements("parameters.key: added", "parameters.secret-key: added"))
+					Expect(fakeClient.ProvisionCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		Context("Sync", func() {
@@ -222,7 +259,7 @@ var _ = Describe("ServiceInstance controller", func() {
 					Expect(serviceInstance.Status.HashedSpec).To(Not(BeNil()))
 					Expect(string(serviceInstance.Spec.Parameters.Raw)).To(ContainSubstring("\"key\":\"value\""))
 					Expect(serviceInstance.Status.HashedSpec).To(Equal(serviceInstance.GetSpecHash()))
-					smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
+					_, smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
 					params := smInstance.Parameters
 					Expect(params).To(ContainSubstring("\"key\":\"value\""))
 					Expect(params).To(ContainSubstring("\"secret-key\":\"secret-value\""))
@@ -338,7 +375,7 @@ var _ = Describe("ServiceInstance controller", func() {
 
 			When("polling ends with failure", func() {
 				BeforeEach(func() {
-					fakeClient.StatusStub = func(url string, operationType smClientTypes.OperationCategory, parameters *sm.Parameters) (*smClientTypes.Operation, error) {
+					fakeClient.StatusStub = func(_ context.Context, url string, operationType smClientTypes.OperationCategory, parameters *sm.Parameters) (*smClientTypes.Operation, error) {
 						if strings.Contains(url, "successful-instance-id") {
 							return &smClientTypes.Operation{
 								Type:        smClientTypes.CREATE,
@@ -455,6 +492,51 @@ var _ = Describe("ServiceInstance controller", func() {
 						waitForResourceCondition(ctx, serviceInstance, common.ConditionSucceeded, metav1.ConditionTrue, common.Updated, "")
 					})
 				})
+
+				When("plan is changed by name", func() {
+					BeforeEach(func() {
+						fakeClient.UpdateInstanceReturns(&smclientTypes.ServiceInstance{ID: fakeInstanceID, ServicePlanID: "new-plan-id"}, "", nil)
+					})
+
+					It("should set the plan of the updated instance", func() {
+						fakeClient.ListPlansReturns(&smclientTypes.ServicePlans{ServicePlans: []smclientTypes.ServicePlan{
+							{ID: "new-plan-id", CatalogName: "plan-b", ServiceOfferingID: "offering-id"},
+						}}, nil)
+						serviceInstance.Spec.ServicePlanName = "plan-b"
+						updateInstance(ctx, serviceInstance)
+						waitForResourceCondition(ctx, serviceInstance, common.ConditionSucceeded, metav1.ConditionTrue, common.Updated, "")
+						Expect(serviceInstance.Status.ServicePlanID).To(Equal("new-plan-id"))
+					})
+
+					It("should keep the plan in the status when the plan can't be resolved", func() {
+						fakeClient.ListPlansReturns(nil, fmt.Errorf("catalog unavailable"))
+						serviceInstance.Spec.ServicePlanName = "plan-b"
+						updateInstance(ctx, serviceInstance)
+						waitForResourceCondition(ctx, serviceInstance, common.ConditionSucceeded, metav1.ConditionTrue, common.Updated, "")
+						Expect(serviceInstance.Status.ServicePlanID).To(BeEmpty())
+					})
+				})
+
+				When("plan is changed and the current plan is not updatable", func() {
+					BeforeEach(func() {
+						fakeClient.GetInstanceByIDReturns(&smclientTypes.ServiceInstance{ID: fakeInstanceID, Ready: true, ServicePlanID: "current-plan-id"}, nil)
+						fakeClient.GetPlanReturns(&smclientTypes.ServicePlan{ID: "new-plan-id", CatalogName: "plan-b", ServiceOfferingID: "offering-id"},
+							&smclientTypes.ServiceOffering{ID: "offering-id", CatalogName: fakeOfferingName}, nil)
+						fakeClient.ListPlansReturns(&smclientTypes.ServicePlans{ServicePlans: []smclientTypes.ServicePlan{
+							{ID: "current-plan-id", CatalogName: fakePlanName, ServiceOfferingID: "offering-id"},
+						}}, nil)
+						fakeClient.ListOfferingsReturns(&smclientTypes.ServiceOfferings{ServiceOfferings: []smclientTypes.ServiceOffering{
+							{ID: "offering-id", CatalogName: fakeOfferingName},
+						}}, nil)
+					})
+
+					It("should block the update without calling SM", func() {
+						serviceInstance.Spec.ServicePlanName = "plan-b"
+						updateInstance(ctx, serviceInstance)
+						waitForInstanceConditionAndMessage(ctx, defaultLookupKey, common.ConditionSucceeded, "is not updatable")
+						Expect(fakeClient.UpdateInstanceCallCount()).To(BeZero())
+					})
+				})
 			})
 
 			Context("Async", func() {
@@ -525,6 +607,27 @@ var _ = Describe("ServiceInstance controller", func() {
 			})
 		})
 
+		Context("When Service Manager is unavailable", func() {
+			BeforeEach(func() {
+				fakeClient.GetInstanceByIDReturns(nil, &sm.ServiceManagerUnavailableError{URL: "https://sm.url", RetryAt: time.Now().Add(time.Minute)})
+			})
+
+			It("should set degraded condition and keep the instance ready", func() {
+				serviceInstance.Spec.ExternalName = "my-new-external-name" + uuid.New().String()
+				updateInstance(ctx, serviceInstance)
+				Eventually(func() bool {
+					if err := k8sClient.Get(ctx, defaultLookupKey, serviceInstance); err != nil {
+						return false
+					}
+					return meta.IsStatusConditionTrue(serviceInstance.GetConditions(), common.ConditionDegraded)
+				}, timeout, interval).Should(BeTrue())
+				degradedCond := meta.FindStatusCondition(serviceInstance.GetConditions(), common.ConditionDegraded)
+				Expect(degradedCond.Reason).To(Equal(common.ServiceManagerUnavailable))
+				Expect(meta.IsStatusConditionTrue(serviceInstance.GetConditions(), common.ConditionReady)).To(BeTrue())
+				Expect(fakeClient.UpdateInstanceCallCount()).To(BeZero())
+			})
+		})
+
 		Context("When update call to SM fails", func() {
 			Context("Sync", func() {
 				When("spec is changed", func() {
@@ -705,6 +808,29 @@ var _ = Describe("ServiceInstance controller", func() {
 				})
 			})
 
+			When("deletion policy is Retain", func() {
+				BeforeEach(func() {
+					fakeClient.GetInstanceByIDReturns(&smclientTypes.ServiceInstance{
+						ID:     serviceInstance.Status.InstanceID,
+						Ready:  true,
+						Labels: smclientTypes.Labels{common.ClusterIDLabel: []string{"cluster"}, common.K8sNameLabel: []string{fakeInstanceName}},
+					}, nil)
+				})
+
+				It("should remove the cluster labels and keep the instance in SM", func() {
+					serviceInstance.Spec.DeletionPolicy = v1.DeletionPolicyRetain
+					updateInstance(ctx, serviceInstance)
+					deleteInstance(ctx, serviceInstance, true)
+					Expect(fakeClient.DeprovisionCallCount()).To(BeZero())
+					Expect(fakeClient.UpdateInstanceLabelsCallCount()).To(Equal(1))
+					_, _, removals := fakeClient.UpdateInstanceLabelsArgsForCall(0)
+					Expect(removals).To(ConsistOf(
+						&smclientTypes.LabelChange{Operation: smclientTypes.RemoveLabelOperation, Key: common.ClusterIDLabel},
+						&smclientTypes.LabelChange{Operation: smclientTypes.RemoveLabelOperation, Key: common.K8sNameLabel},
+					))
+				})
+			})
+
 			When("delete without instance id", func() {
 				BeforeEach(func() {
 					fakeClient.ListInstancesReturns(&smclientTypes.ServiceInstances{
@@ -881,7 +1007,7 @@ var _ = Describe("ServiceInstance controller", func() {
 				serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, true)
 				Expect(fakeClient.ProvisionCallCount()).To(Equal(0))
 				Expect(serviceInstance.Status.InstanceID).To(Equal(fakeInstanceID))
-				smCallArgs := fakeClient.ListInstancesArgsForCall(0)
+				_, smCallArgs := fakeClient.ListInstancesArgsForCall(0)
 				Expect(smCallArgs.LabelQuery).To(HaveLen(1))
 				Expect(smCallArgs.LabelQuery[0]).To(ContainSubstring("_k8sname"))
 
@@ -968,6 +1094,121 @@ var _ = Describe("ServiceInstance controller", func() {
 		})
 	})
 
+	Context("Adoption", func() {
+		var adoptedInstance *smclientTypes.ServiceInstance
+		var adoptionSpec v1.ServiceInstanceSpec
+
+		BeforeEach(func() {
+			adoptedInstance = &smclientTypes.ServiceInstance{
+				ID:            fakeInstanceID,
+				Name:          "cockpit-instance",
+				ServicePlanID: "adopted-plan-id",
+				Ready:         true,
+				Labels:        smclientTypes.Labels{common.K8sNameLabel: []string{"old-name"}},
+				LastOperation: &smClientTypes.Operation{State: smClientTypes.SUCCEEDED, Type: smClientTypes.CREATE},
+			}
+			fakeClient.GetInstanceByIDReturns(adoptedInstance, nil)
+			adoptionSpec = *instanceSpec.DeepCopy()
+			adoptionSpec.InstanceID = fakeInstanceID
+		})
+
+		It("should adopt and relabel the instance", func() {
+			serviceInstance = createInstance(ctx, fakeInstanceName, adoptionSpec, nil, true)
+			Expect(fakeClient.ProvisionCallCount()).To(BeZero())
+			Expect(fakeClient.ListInstancesCallCount()).To(BeZero())
+			Expect(serviceInstance.Status.InstanceID).To(Equal(fakeInstanceID))
+			Expect(serviceInstance.Status.ServicePlanID).To(Equal("adopted-plan-id"))
+
+			Expect(fakeClient.UpdateInstanceLabelsCallCount()).To(Equal(2))
+			_, id, removals := fakeClient.UpdateInstanceLabelsArgsForCall(0)
+			Expect(id).To(Equal(fakeInstanceID))
+			Expect(removals).To(ConsistOf(&smclientTypes.LabelChange{Operation: smclientTypes.RemoveLabelOperation, Key: common.K8sNameLabel}))
+			_, _, additions := fakeClient.UpdateInstanceLabelsArgsForCall(1)
+			Expect(additions).To(ContainElement(&smclientTypes.LabelChange{Operation: smclientTypes.AddLabelOperation, Key: common.K8sNameLabel, Values: []string{fakeInstanceName}}))
+			Expect(additions).To(ContainElement(&smclientTypes.LabelChange{Operation: smclientTypes.AddLabelOperation, Key: common.NamespaceLabel, Values: []string{testNamespace}}))
+		})
+
+		When("the instance is managed by another cluster", func() {
+			BeforeEach(func() {
+				adoptedInstance.Labels = smclientTypes.Labels{common.ClusterIDLabel: []string{"other-cluster"}}
+			})
+
+			It("should refuse the adoption", func() {
+				serviceInstance = createInstance(ctx, fakeInstanceName, adoptionSpec, nil, false)
+				waitForResourceCondition(ctx, serviceInstance, common.ConditionSucceeded, metav1.ConditionFalse, common.AdoptionRefused, "managed by cluster other-cluster")
+				Expect(serviceInstance.Status.InstanceID).To(BeEmpty())
+				Expect(fakeClient.UpdateInstanceLabelsCallCount()).To(BeZero())
+				Expect(fakeClient.ProvisionCallCount()).To(BeZero())
+			})
+		})
+
+		When("the instance is managed by another ServiceInstance in this cluster", func() {
+			var managingInstance *v1.ServiceInstance
+
+			BeforeEach(func() {
+				managingInstance = createInstance(ctx, "managing-instance", instanceSpec, nil, true)
+				Expect(managingInstance.Status.InstanceID).To(Equal(fakeInstanceID))
+				adoptedInstance.Labels = utils.OwnerLabels(testNamespace, managingInstance.Name, config.Get().ClusterID)
+			})
+
+			AfterEach(func() {
+				deleteAndWait(ctx, managingInstance)
+			})
+
+			It("should refuse the adoption", func() {
+				serviceInstance = createInstance(ctx, fakeInstanceName, adoptionSpec, nil, false)
+				waitForResourceCondition(ctx, serviceInstance, common.ConditionSucceeded, metav1.ConditionFalse, common.AdoptionRefused, "managed by ServiceInstance "+testNamespace+"/managing-instance")
+				Expect(serviceInstance.Status.InstanceID).To(BeEmpty())
+				Expect(fakeClient.UpdateInstanceLabelsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("Handover", func() {
+		BeforeEach(func() {
+			serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, true)
+			fakeClient.GetInstanceByIDReturns(&smclientTypes.ServiceInstance{
+				ID:     serviceInstance.Status.InstanceID,
+				Ready:  true,
+				Labels: utils.OwnerLabels(testNamespace, fakeInstanceName, config.Get().ClusterID),
+			}, nil)
+			fakeClient.ListBindingsReturns(&smclientTypes.ServiceBindings{ServiceBindings: []smclientTypes.ServiceBinding{
+				{ID: "binding-id", Labels: utils.OwnerLabels(testNamespace, "binding", config.Get().ClusterID)},
+				{ID: "other-cluster-binding-id", Labels: utils.OwnerLabels(testNamespace, "binding", "other-cluster")},
+			}}, nil)
+		})
+
+		It("should relabel the instance and its bindings for the target and release the instance on delete", func() {
+			serviceInstance.Annotations = map[string]string{common.HandoverToAnnotation: "other-cluster/other-namespace"}
+			serviceInstance = updateInstance(ctx, serviceInstance)
+			Eventually(func() string {
+				_ = k8sClient.Get(ctx, defaultLookupKey, serviceInstance)
+				return serviceInstance.Status.HandedOverTo
+			}, timeout, interval).Should(Equal("other-cluster/other-namespace"))
+
+			Expect(fakeClient.UpdateBindingLabelsCallCount()).To(Equal(2))
+			_, bindingID, _ := fakeClient.UpdateBindingLabelsArgsForCall(0)
+			Expect(bindingID).To(Equal("binding-id"))
+			Expect(fakeClient.UpdateInstanceLabelsCallCount()).To(Equal(2))
+			_, _, additions := fakeClient.UpdateInstanceLabelsArgsForCall(1)
+			Expect(additions).To(ConsistOf(
+				&smclientTypes.LabelChange{Operation: smclientTypes.AddLabelOperation, Key: common.ClusterIDLabel, Values: []string{"other-cluster"}},
+				&smclientTypes.LabelChange{Operation: smclientTypes.AddLabelOperation, Key: common.NamespaceLabel, Values: []string{"other-namespace"}},
+			))
+
+			deleteInstance(ctx, serviceInstance, true)
+			Expect(fakeClient.DeprovisionCallCount()).To(BeZero())
+		})
+
+		It("should ignore instances handed over elsewhere when recovering by context", func() {
+			labels := utils.OwnerLabels("other-namespace", fakeInstanceName, "cluster")
+			Expect(handedOverElsewhere(labels, testNamespace, "cluster")).To(BeTrue())
+			Expect(handedOverElsewhere(labels, "other-namespace", "other-cluster")).To(BeTrue())
+			Expect(handedOverElsewhere(labels, "other-namespace", "cluster")).To(BeFalse())
+			Expect(handedOverElsewhere(nil, testNamespace, "cluster")).To(BeFalse())
+		})
+	})
+
 	Describe("Share instance", func() {
 		Context("Share", func() {
 			When("creating instance with shared=true", func() {
@@ -1347,6 +1588,83 @@ var _ = Describe("ServiceInstance controller", func() {
 				})
 			})
 		})
+
+		Context("drift", func() {
+			var instance *v1.ServiceInstance
+			var smInstance *smClientTypes.ServiceInstance
+
+			BeforeEach(func() {
+				instance = &v1.ServiceInstance{
+					Spec: v1.ServiceInstanceSpec{
+						ExternalName: "name",
+						Shared:       pointer.Bool(true),
+					},
+					Status: v1.ServiceInstanceStatus{
+						Conditions: []metav1.Condition{
+							{Type: common.ConditionShared, Status: metav1.ConditionTrue},
+						},
+						Ready: metav1.ConditionTrue,
+					},
+				}
+				smInstance = &smClientTypes.ServiceInstance{Name: "name", ServicePlanID: "plan-id", Shared: true}
+			})
+
+			It("should not report drift when the instance matches its spec", func() {
+				changes, err := instanceChanges(smInstance, instance, "plan-id", []byte(`{"key":"value"}`), []byte(`{"key":"value"}`))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changes).To(BeEmpty())
+			})
+
+			It("should report the drifted fields", func() {
+				smInstance.Name = "renamed"
+				smInstance.Shared = false
+				changes, err := instanceChanges(smInstance, instance, "plan-id", []byte(`{"key":"other"}`), []byte(`{"key":"value"}`))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changes).To(ConsistOf(`name: "renamed" -> "name"`, "shared: false -> true", "parameters.key: changed"))
+			})
+
+			It("should not compare parameters that can't be retrieved", func() {
+				changes, err := instanceChanges(smInstance, instance, "plan-id", nil, []byte(`{"key":"value"}`))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(changes).To(BeEmpty())
+			})
+
+			It("should reshare the instance when the shared state drifted", func() {
+				smInstance.Shared = false
+				(&ServiceInstanceReconciler{}).reapplySpec(ctx, instance, smInstance, 1)
+				Expect(instance.Status.ForceReconcile).To(BeFalse())
+				Expect(shareOrUnshareRequired(instance)).To(BeTrue())
+			})
+
+			It("should update the instance when other fields drifted", func() {
+				smInstance.Name = "renamed"
+				(&ServiceInstanceReconciler{}).reapplySpec(ctx, instance, smInstance, 1)
+				Expect(instance.Status.ForceReconcile).To(BeTrue())
+				Expect(shareOrUnshareRequired(instance)).To(BeFalse())
+			})
+
+			It("should not re-apply parameters the broker added to the instance", func() {
+				instance.ObjectMeta = metav1.ObjectMeta{Name: "drifted-instance", Namespace: testNamespace}
+				instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"key":"value"}`)}
+				instance.Spec.DriftPolicy = v1.DriftPolicyReapply
+				instance.Status.InstanceID = fakeInstanceID
+				scheme := runtime.NewScheme()
+				Expect(v1.AddToScheme(scheme)).To(Succeed())
+				reconciler := &ServiceInstanceReconciler{
+					Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).WithStatusSubresource(instance).Build(),
+					Config:   config.Config{DriftCheckInterval: time.Minute},
+					Recorder: events.NewFakeRecorder(10),
+				}
+				smClient := &smfakes.FakeClient{}
+				smClient.GetPlanReturns(&smclientTypes.ServicePlan{ID: "plan-id"}, nil, nil)
+				smClient.GetInstanceParametersReturns(map[string]interface{}{"key": "value", "defaulted": "by-broker"}, nil)
+
+				_, err := reconciler.checkDrift(ctx, smClient, instance, smInstance)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(meta.IsStatusConditionFalse(instance.GetConditions(), common.ConditionDrifted)).To(BeTrue())
+				Expect(instance.Status.ForceReconcile).To(BeFalse())
+			})
+		})
 	})
 
 	Context("secret watcher", func() {
@@ -1368,7 +1686,7 @@ var _ = Describe("ServiceInstance controller", func() {
 			})
 			It("should update instance with the secret change", func() {
 				serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, true)
-				smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
+				_, smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"secret-value\""})
 
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, paramsSecret, []*v1.ServiceInstance{serviceInstance})
@@ -1381,7 +1699,7 @@ var _ = Describe("ServiceInstance controller", func() {
 					return fakeClient.UpdateInstanceCallCount() >= 1
 				}, timeout, interval).Should(BeTrue(), "expected condition was not met")
 
-				_, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
+				_, _, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"new-secret-value\""})
 				deleteAndWait(ctx, serviceInstance)
 
@@ -1392,7 +1710,7 @@ var _ = Describe("ServiceInstance controller", func() {
 				Expect(k8sClient.Update(ctx, paramsSecret)).To(Succeed())
 
 				serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, true)
-				smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
+				_, smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"secret-value\""})
 
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, paramsSecret, []*v1.ServiceInstance{serviceInstance})
@@ -1405,7 +1723,7 @@ var _ = Describe("ServiceInstance controller", func() {
 					return fakeClient.UpdateInstanceCallCount() >= 1
 				}, timeout, interval).Should(BeTrue(), "expected condition was not met")
 
-				_, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
+				_, _, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"new-secret-value\""})
 				deleteAndWait(ctx, serviceInstance)
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, paramsSecret, []*v1.ServiceInstance{})
@@ -1436,7 +1754,7 @@ var _ = Describe("ServiceInstance controller", func() {
 
 				anotherSecret = createParamsSecret(ctx, "instance-params-secret-new", testNamespace)
 				waitForResourceToBeReady(ctx, serviceInstance)
-				smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
+				_, smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"secret-value\""})
 
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, anotherSecret, []*v1.ServiceInstance{serviceInstance})
@@ -1449,7 +1767,7 @@ var _ = Describe("ServiceInstance controller", func() {
 					return fakeClient.UpdateInstanceCallCount() == 1
 				}, timeout, interval).Should(BeTrue(), "expected condition was not met")
 
-				_, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
+				_, _, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"new-secret-value\""})
 				deleteAndWait(ctx, serviceInstance)
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, anotherSecret, []*v1.ServiceInstance{})
@@ -1460,7 +1778,7 @@ var _ = Describe("ServiceInstance controller", func() {
 				anotherSecret = createSecret(ctx, "instance-params-secret-new", testNamespace, credentialsMap)
 
 				serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, true)
-				smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
+				_, smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"secret-value\""})
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, paramsSecret, []*v1.ServiceInstance{serviceInstance})
 
@@ -1481,7 +1799,7 @@ var _ = Describe("ServiceInstance controller", func() {
 				}
 				serviceInstance = updateInstance(ctx, serviceInstance)
 				waitForResourceCondition(ctx, serviceInstance, common.ConditionSucceeded, metav1.ConditionTrue, common.Updated, "")
-				_, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
+				_, _, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"secret-value\"", "\"secret-key2\":\"secret-value2\""})
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, anotherSecret, []*v1.ServiceInstance{serviceInstance})
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, paramsSecret, []*v1.ServiceInstance{serviceInstance})
@@ -1499,7 +1817,7 @@ var _ = Describe("ServiceInstance controller", func() {
 				Eventually(func() bool {
 					return fakeClient.UpdateInstanceCallCount() > 1
 				}, timeout, interval).Should(BeTrue())
-				_, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(1)
+				_, _, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(1)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"secret-value\""})
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, anotherSecret, []*v1.ServiceInstance{})
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, paramsSecret, []*v1.ServiceInstance{serviceInstance})
@@ -1509,11 +1827,11 @@ var _ = Describe("ServiceInstance controller", func() {
 			})
 			It("when watched secret changed, referencing instances should be updated", func() {
 				serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, true)
-				smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
+				_, smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"secret-value\""})
 
 				anotherInstance = createInstance(ctx, anotherInstanceName, instanceSpec, nil, true)
-				smInstance, _, _, _, _, _ = fakeClient.ProvisionArgsForCall(1)
+				_, smInstance, _, _, _, _, _ = fakeClient.ProvisionArgsForCall(1)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"secret-value\""})
 
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, paramsSecret, []*v1.ServiceInstance{serviceInstance, anotherInstance})
@@ -1526,10 +1844,10 @@ var _ = Describe("ServiceInstance controller", func() {
 					return fakeClient.UpdateInstanceCallCount() == 2
 				}, timeout, interval).Should(BeTrue(), "expected condition was not met")
 
-				_, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
+				_, _, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"new-secret-value\""})
 
-				_, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(1)
+				_, _, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(1)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"new-secret-value\""})
 
 				deleteAndWait(ctx, anotherInstance)
@@ -1556,7 +1874,7 @@ var _ = Describe("ServiceInstance controller", func() {
 			})
 			It("should not update instance with the secret change", func() {
 				serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, true)
-				smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
+				_, smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"secret-value\""})
 
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, paramsSecret, []*v1.ServiceInstance{})
@@ -1570,7 +1888,7 @@ var _ = Describe("ServiceInstance controller", func() {
 			It("should not update instance with the secret change after removing WatchParametersFromChanges", func() {
 				instanceSpec.WatchParametersFromChanges = pointer.Bool(true)
 				serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, true)
-				smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
+				_, smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
 				checkParams(string(smInstance.Parameters), []string{"\"key\":\"value\"", "\"secret-key\":\"secret-value\""})
 				checkSecretAnnotationsAndLabels(ctx, k8sClient, paramsSecret, []*v1.ServiceInstance{serviceInstance})
 
@@ -1589,6 +1907,54 @@ var _ = Describe("ServiceInstance controller", func() {
 
 	})
 
+	Context("config map watcher", func() {
+		var paramsConfigMap *corev1.ConfigMap
+		BeforeEach(func() {
+			paramsConfigMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance-params-configmap", Namespace: testNamespace},
+				Data:       map[string]string{"configmap-parameter": "{\"configmap-key\":\"configmap-value\"}"},
+			}
+			Expect(k8sClient.Create(ctx, paramsConfigMap)).To(Succeed())
+			instanceSpec.WatchParametersFromChanges = pointer.Bool(true)
+			instanceSpec.ParametersFrom = append(instanceSpec.ParametersFrom, v1.ParametersFromSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeyReference{
+					Name: paramsConfigMap.Name,
+					Key:  "configmap-parameter",
+				},
+			})
+		})
+		AfterEach(func() {
+			instanceSpec.WatchParametersFromChanges = pointer.Bool(false)
+			instanceSpec.ParametersFrom = instanceSpec.ParametersFrom[:len(instanceSpec.ParametersFrom)-1]
+			deleteAndWait(ctx, paramsConfigMap)
+		})
+		It("should update instance with the config map change", func() {
+			serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, true)
+			_, smInstance, _, _, _, _, _ := fakeClient.ProvisionArgsForCall(0)
+			checkParams(string(smInstance.Parameters), []string{"\"secret-key\":\"secret-value\"", "\"configmap-key\":\"configmap-value\""})
+
+			Expect(k8sClient.Get(ctx, getResourceNamespacedName(paramsConfigMap), paramsConfigMap)).To(Succeed())
+			Expect(utils.IsConfigMapWatched(paramsConfigMap)).To(BeTrue())
+			Expect(paramsConfigMap.Annotations[common.WatchConfigMapAnnotation+string(serviceInstance.UID)]).To(Equal("true"))
+			Expect(serviceInstance.Labels[utils.GetLabelKeyForInstanceConfigMap(paramsConfigMap.Name)]).To(Equal(paramsConfigMap.Name))
+
+			paramsConfigMap.Data = map[string]string{"configmap-parameter": "{\"configmap-key\":\"new-configmap-value\"}"}
+			Expect(k8sClient.Update(ctx, paramsConfigMap)).To(Succeed())
+			Eventually(func() bool {
+				return fakeClient.UpdateInstanceCallCount() >= 1
+			}, timeout, interval).Should(BeTrue(), "expected condition was not met")
+
+			_, _, smInstance, _, _, _, _, _ = fakeClient.UpdateInstanceArgsForCall(0)
+			checkParams(string(smInstance.Parameters), []string{"\"configmap-key\":\"new-configmap-value\""})
+
+			deleteAndWait(ctx, serviceInstance)
+			Eventually(func() bool {
+				Expect(k8sClient.Get(ctx, getResourceNamespacedName(paramsConfigMap), paramsConfigMap)).To(Succeed())
+				return !utils.IsConfigMapWatched(paramsConfigMap) && len(paramsConfigMap.Finalizers) == 0
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
 	When("instance id exist on resource but not found in sm", func() {
 		BeforeEach(func() {
 			serviceInstance = createInstance(ctx, fakeInstanceName, instanceSpec, nil, true)
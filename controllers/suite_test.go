@@ -144,6 +144,7 @@ var _ = BeforeSuite(func(done Done) {
 	testConfig.PollInterval = pollInterval
 	testConfig.RetryBaseDelay = time.Millisecond * 50
 	testConfig.RetryMaxDelay = time.Second * 2
+	testConfig.ParamsSchemaValidation = config.SchemaValidationEnforce
 
 	By("registering webhooks")
 	k8sManager.GetWebhookServer().Register("/mutate-services-cloud-sap-com-v1-serviceinstance", &webhook.Admission{Handler: &webhooks.ServiceInstanceDefaulter{Decoder: admission.NewDecoder(k8sManager.GetScheme())}})
@@ -189,6 +190,21 @@ var _ = BeforeSuite(func(done Done) {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&ConfigMapReconciler{
+		Client: k8sManager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("ConfigMap"),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&VCAPServicesReconciler{
+		Client:   k8sManager.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("VCAPServices"),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorder("VCAPServices"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	// +kubebuilder:scaffold:webhook
 	ctx, cancel = context.WithCancel(context.TODO())
 
@@ -0,0 +1,339 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/internal/utils"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// VCAPServicesReconciler reconciles a VCAPServices object
+type VCAPServicesReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+}
+
+// vcapService is an entry of the VCAP_SERVICES document, the entries are grouped by the offering label
+type vcapService struct {
+	Name         string                 `json:"name"`
+	InstanceName string                 `json:"instance_name"`
+	InstanceGUID string                 `json:"instance_guid"`
+	BindingName  string                 `json:"binding_name"`
+	BindingGUID  string                 `json:"binding_guid"`
+	Label        string                 `json:"label"`
+	Plan         string                 `json:"plan"`
+	Tags         []string               `json:"tags"`
+	Credentials  map[string]interface{} `json:"credentials"`
+}
+
+// +kubebuilder:rbac:groups=services.cloud.sap.com,resources=vcapservices,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=services.cloud.sap.com,resources=vcapservices/status,verbs=get;update;patch
+
+// Reconcile regenerates the VCAP_SERVICES secret from the selected bindings, it runs when the resource, the selected
+// bindings or their secrets change, so rotated credentials reach the secret
+func (r *VCAPServicesReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	correlationID := uuid.New().String()
+	log := r.Log.WithValues("vcapservices", req.NamespacedName).WithValues("correlation_id", correlationID)
+	ctx = context.WithValue(ctx, logutils.LogKey, log)
+	ctx = context.WithValue(ctx, logutils.CorrelationIDKey, correlationID)
+
+	vcapServices := &v1.VCAPServices{}
+	if err := r.Client.Get(ctx, req.NamespacedName, vcapServices); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if utils.IsMarkedForDeletion(vcapServices.ObjectMeta) {
+		// the secret is owned by the resource and deleted with it
+		return ctrl.Result{}, nil
+	}
+	vcapServices = vcapServices.DeepCopy()
+	status := vcapServices.Status.DeepCopy()
+
+	bindings, err := r.getSelectedBindings(ctx, vcapServices)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	services := make(map[string][]vcapService)
+	var included, pending, failures []string
+	for i := range bindings {
+		binding := &bindings[i]
+		service, err := r.getVCAPService(ctx, binding)
+		if err != nil {
+			log.Info(fmt.Sprintf("binding %s can't be aggregated yet: %s", binding.Name, err.Error()))
+			pending = append(pending, binding.Name)
+			failures = append(failures, err.Error())
+			continue
+		}
+		services[service.Label] = append(services[service.Label], *service)
+		included = append(included, binding.Name)
+	}
+	for label := range services {
+		sort.Slice(services[label], func(i, j int) bool {
+			return services[label][i].BindingName < services[label][j].BindingName
+		})
+	}
+
+	if err := r.storeSecret(ctx, vcapServices, services, len(included)); err != nil {
+		log.Error(err, "failed to store the VCAP_SERVICES secret")
+		r.setCondition(vcapServices, metav1.ConditionFalse, common.Failed, err.Error())
+		if updateErr := r.updateStatus(ctx, vcapServices, status); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	vcapServices.Status.Bindings = included
+	vcapServices.Status.PendingBindings = pending
+	if len(pending) > 0 {
+		r.setCondition(vcapServices, metav1.ConditionFalse, common.BindingsPending, strings.Join(failures, "; "))
+	} else {
+		r.setCondition(vcapServices, metav1.ConditionTrue, common.Aggregated, fmt.Sprintf("secret %s holds %d bindings", vcapServices.GetSecretName(), len(included)))
+	}
+	return ctrl.Result{}, r.updateStatus(ctx, vcapServices, status)
+}
+
+// getSelectedBindings returns the bindings in the namespace that are listed by name or match the selector, the bindings
+// replaced by a credentials rotation are skipped
+func (r *VCAPServicesReconciler) getSelectedBindings(ctx context.Context, vcapServices *v1.VCAPServices) ([]v1.ServiceBinding, error) {
+	bindingList := &v1.ServiceBindingList{}
+	if err := r.Client.List(ctx, bindingList, client.InNamespace(vcapServices.Namespace)); err != nil {
+		return nil, err
+	}
+	var bindings []v1.ServiceBinding
+	for _, binding := range bindingList.Items {
+		if _, isStale := binding.Labels[common.StaleBindingIDLabel]; isStale {
+			continue
+		}
+		selected, err := isBindingSelected(vcapServices, &binding)
+		if err != nil {
+			return nil, err
+		}
+		if selected {
+			bindings = append(bindings, binding)
+		}
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].Name < bindings[j].Name
+	})
+	return bindings, nil
+}
+
+func isBindingSelected(vcapServices *v1.VCAPServices, binding *v1.ServiceBinding) (bool, error) {
+	if slices.Contains(vcapServices.Spec.Bindings, binding.Name) {
+		return true, nil
+	}
+	if vcapServices.Spec.BindingSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(vcapServices.Spec.BindingSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(binding.Labels)), nil
+}
+
+// getVCAPService builds the VCAP_SERVICES entry of a ready binding from its secret and instance
+func (r *VCAPServicesReconciler) getVCAPService(ctx context.Context, binding *v1.ServiceBinding) (*vcapService, error) {
+	if !meta.IsStatusConditionTrue(binding.Status.Conditions, common.ConditionReady) || !binding.DeletionTimestamp.IsZero() {
+		return nil, fmt.Errorf("binding %s is not ready", binding.Name)
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: binding.Spec.SecretName, Namespace: binding.Namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s of binding %s: %w", binding.Spec.SecretName, binding.Name, err)
+	}
+	credentials, err := bindingCredentials(binding, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &v1.ServiceInstance{}
+	instanceNamespace := binding.Namespace
+	if len(binding.Spec.ServiceInstanceNamespace) > 0 {
+		instanceNamespace = binding.Spec.ServiceInstanceNamespace
+	}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: binding.Spec.ServiceInstanceName, Namespace: instanceNamespace}, instance); err != nil {
+		return nil, fmt.Errorf("failed to get instance %s of binding %s: %w", binding.Spec.ServiceInstanceName, binding.Name, err)
+	}
+
+	instanceName := string(getInstanceNameForSecretCredentials(instance))
+	tags := mergeInstanceTags(instance.Status.Tags, instance.Spec.CustomTags)
+	if tags == nil {
+		tags = []string{}
+	}
+	return &vcapService{
+		Name:         instanceName,
+		InstanceName: instanceName,
+		InstanceGUID: instance.Status.InstanceID,
+		BindingName:  binding.Name,
+		BindingGUID:  binding.Status.BindingID,
+		Label:        instance.Spec.ServiceOfferingName,
+		Plan:         instance.Spec.ServicePlanName,
+		Tags:         tags,
+		Credentials:  credentials,
+	}, nil
+}
+
+// bindingCredentials restores the credentials returned by the broker from the binding secret, using the .metadata key to
+// tell JSON values from text values
+func bindingCredentials(binding *v1.ServiceBinding, secret *corev1.Secret) (map[string]interface{}, error) {
+	metadataBytes, ok := secret.Data[".metadata"]
+	if binding.Spec.SecretRootKey != nil || !ok {
+		return nil, fmt.Errorf("the credentials of binding %s can't be restored from its secret, bindings with secretRootKey or a secretTemplate with custom data can't be aggregated", binding.Name)
+	}
+	metadata := make(map[string][]utils.SecretMetadataProperty)
+	if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to read the .metadata of the secret of binding %s: %w", binding.Name, err)
+	}
+
+	credentials := make(map[string]interface{})
+	for _, property := range metadata["credentialProperties"] {
+		value := secret.Data[property.Name]
+		if property.Container {
+			if err := json.Unmarshal(value, &credentials); err != nil {
+				return nil, fmt.Errorf("failed to read credentials %s of binding %s: %w", property.Name, binding.Name, err)
+			}
+			continue
+		}
+		if property.Format != string(utils.JSON) {
+			credentials[property.Name] = string(value)
+			continue
+		}
+		var jsonValue interface{}
+		if err := json.Unmarshal(value, &jsonValue); err != nil {
+			return nil, fmt.Errorf("failed to read credentials %s of binding %s: %w", property.Name, binding.Name, err)
+		}
+		credentials[property.Name] = jsonValue
+	}
+	return credentials, nil
+}
+
+func (r *VCAPServicesReconciler) storeSecret(ctx context.Context, vcapServices *v1.VCAPServices, services map[string][]vcapService, bindingCount int) error {
+	log := logutils.GetLogger(ctx)
+	document, err := json.Marshal(services)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: vcapServices.GetSecretName(), Namespace: vcapServices.Namespace}}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if len(secret.ResourceVersion) > 0 && !metav1.IsControlledBy(secret, vcapServices) {
+			return fmt.Errorf("secret %s already exists and is not owned by VCAPServices %s", secret.Name, vcapServices.Name)
+		}
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[common.ManagedByBTPOperatorLabel] = "true"
+		secret.Data = map[string][]byte{v1.VCAPServicesKey: document}
+		return controllerutil.SetControllerReference(vcapServices, secret, r.Scheme)
+	})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("secret %s already exists and is not owned by VCAPServices %s", secret.Name, vcapServices.Name)
+		}
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info(fmt.Sprintf("VCAP_SERVICES secret %s %s", secret.Name, result))
+		r.Recorder.Eventf(vcapServices, nil, corev1.EventTypeNormal, common.Aggregated, actionStoreSecret, "secret %s %s with %d bindings", secret.Name, result, bindingCount)
+	}
+	return r.deleteRenamedSecrets(ctx, vcapServices)
+}
+
+// deleteRenamedSecrets deletes the secrets the resource stored before its secret name was changed
+func (r *VCAPServicesReconciler) deleteRenamedSecrets(ctx context.Context, vcapServices *v1.VCAPServices) error {
+	log := logutils.GetLogger(ctx)
+	secrets := &corev1.SecretList{}
+	if err := r.Client.List(ctx, secrets, client.InNamespace(vcapServices.Namespace), client.MatchingLabels{common.ManagedByBTPOperatorLabel: "true"}); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if secret.Name == vcapServices.GetSecretName() || !metav1.IsControlledBy(secret, vcapServices) {
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting secret %s, the secret name was changed", secret.Name))
+		if err := r.Client.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *VCAPServicesReconciler) setCondition(vcapServices *v1.VCAPServices, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&vcapServices.Status.Conditions, metav1.Condition{
+		Type:               common.ConditionReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: vcapServices.Generation,
+	})
+	vcapServices.Status.Ready = status
+	vcapServices.Status.ObservedGeneration = vcapServices.Generation
+}
+
+// updateStatus updates the status only when it changed, the controller is triggered by every change of the bindings in
+// the namespace
+func (r *VCAPServicesReconciler) updateStatus(ctx context.Context, vcapServices *v1.VCAPServices, previous *v1.VCAPServicesStatus) error {
+	if reflect.DeepEqual(&vcapServices.Status, previous) {
+		return nil
+	}
+	return r.Client.Status().Update(ctx, vcapServices)
+}
+
+// requestsInNamespace wakes up the VCAPServices resources in the namespace of a binding or a binding secret, selecting
+// the affected ones is as expensive as reconciling them
+func (r *VCAPServicesReconciler) requestsInNamespace(ctx context.Context, object client.Object) []reconcile.Request {
+	vcapServicesList := &v1.VCAPServicesList{}
+	if err := r.Client.List(ctx, vcapServicesList, client.InNamespace(object.GetNamespace())); err != nil {
+		r.Log.Error(err, "failed to list VCAPServices")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(vcapServicesList.Items))
+	for _, vcapServices := range vcapServicesList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&vcapServices)})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VCAPServicesReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	bindingSecretPredicate := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		_, isBindingSecret := object.GetAnnotations()["binding"]
+		return isBindingSecret
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1.VCAPServices{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&corev1.Secret{}).
+		Watches(&v1.ServiceBinding{}, handler.EnqueueRequestsFromMapFunc(r.requestsInNamespace)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsInNamespace), builder.WithPredicates(bindingSecretPredicate)).
+		Complete(r)
+}
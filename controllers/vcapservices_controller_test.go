@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("VCAP_SERVICES", func() {
+	var binding *v1.ServiceBinding
+
+	BeforeEach(func() {
+		binding = &v1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "binding", Labels: map[string]string{"app": "sample"}},
+			Spec:       v1.ServiceBindingSpec{SecretName: "binding"},
+		}
+	})
+
+	Context("binding credentials", func() {
+		It("restores the types of the credentials from the metadata", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{
+				".metadata":   []byte(`{"credentialProperties":[{"name":"url","format":"text"},{"name":"auth","format":"json"}],"metaDataProperties":[{"name":"plan","format":"text"}]}`),
+				"url":         []byte("https://example.com"),
+				"auth":        []byte(`{"user":"name","port":443}`),
+				"plan":        []byte("a-plan"),
+				"unknown_key": []byte("ignored"),
+			}}
+			credentials, err := bindingCredentials(binding, secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials).To(Equal(map[string]interface{}{
+				"url":  "https://example.com",
+				"auth": map[string]interface{}{"user": "name", "port": float64(443)},
+			}))
+		})
+
+		It("restores the credentials of a secret key", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{
+				".metadata":   []byte(`{"credentialProperties":[{"name":"credentials","format":"json","container":true}]}`),
+				"credentials": []byte(`{"url":"https://example.com"}`),
+			}}
+			credentials, err := bindingCredentials(binding, secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials).To(Equal(map[string]interface{}{"url": "https://example.com"}))
+		})
+
+		It("fails for secrets without metadata", func() {
+			_, err := bindingCredentials(binding, &corev1.Secret{Data: map[string][]byte{"custom": []byte("value")}})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("fails for secrets with a root key", func() {
+			rootKey := "root"
+			binding.Spec.SecretRootKey = &rootKey
+			_, err := bindingCredentials(binding, &corev1.Secret{Data: map[string][]byte{".metadata": []byte(`{}`)}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("binding selection", func() {
+		It("selects bindings by name or by labels", func() {
+			vcapServices := &v1.VCAPServices{Spec: v1.VCAPServicesSpec{Bindings: []string{"binding"}}}
+			Expect(isBindingSelected(vcapServices, binding)).To(BeTrue())
+
+			vcapServices.Spec = v1.VCAPServicesSpec{BindingSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "sample"}}}
+			Expect(isBindingSelected(vcapServices, binding)).To(BeTrue())
+
+			vcapServices.Spec = v1.VCAPServicesSpec{BindingSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}}
+			Expect(isBindingSelected(vcapServices, binding)).To(BeFalse())
+		})
+
+		It("selects no bindings by default", func() {
+			Expect(isBindingSelected(&v1.VCAPServices{}, binding)).To(BeFalse())
+		})
+	})
+})
@@ -5,8 +5,10 @@ import (
 	"crypto/x509"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/SAP/sap-btp-service-operator/internal/httputil"
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
 	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
@@ -40,7 +42,7 @@ func NewAuthClientWithTLS(ctx context.Context, ccConfig *clientcredentials.Confi
 
 func newHTTPClient(ctx context.Context, ccConfig *clientcredentials.Config) (HTTPClient, error) {
 	log := logutils.GetLogger(ctx)
-	client := oauth2.NewClient(ctx, ccConfig.TokenSource(ctx))
+	client := oauth2.NewClient(ctx, oauth2.ReuseTokenSource(nil, &instrumentedTokenSource{ctx: ctx, ccConfig: ccConfig}))
 	if caPEM, err := os.ReadFile(CustomCAPath); err == nil {
 		log.Info("found custom CA, loading it..")
 		certPool, certPoolErr := x509.SystemCertPool()
@@ -79,3 +81,19 @@ func newHTTPClient(ctx context.Context, ccConfig *clientcredentials.Config) (HTT
 
 	return client, nil
 }
+
+// instrumentedTokenSource fetches client credentials tokens while recording their latency and failures
+type instrumentedTokenSource struct {
+	ctx      context.Context
+	ccConfig *clientcredentials.Config
+}
+
+func (s *instrumentedTokenSource) Token() (*oauth2.Token, error) {
+	start := time.Now()
+	token, err := s.ccConfig.Token(s.ctx)
+	metrics.OAuthTokenFetchDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.OAuthTokenFetchFailures.Inc()
+	}
+	return token, err
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -12,20 +13,53 @@ var (
 	config   Config
 )
 
+// Modes of validating parameters against the schemas of the service plan
+const (
+	SchemaValidationEnforce  = "enforce"
+	SchemaValidationWarn     = "warn"
+	SchemaValidationDisabled = "disabled"
+)
+
+// Policies for SM resources labeled with the cluster ID that no k8s resource manages
+const (
+	OrphanPolicyReport = "report"
+	OrphanPolicyDelete = "delete"
+	OrphanPolicyAdopt  = "adopt"
+)
+
 type Config struct {
-	SyncPeriod             time.Duration `envconfig:"sync_period"`
-	PollInterval           time.Duration `envconfig:"poll_interval"`
-	LongPollInterval       time.Duration `envconfig:"long_poll_interval"`
-	ManagementNamespace    string        `envconfig:"management_namespace"`
-	ReleaseNamespace       string        `envconfig:"release_namespace"`
-	AllowClusterAccess     bool          `envconfig:"allow_cluster_access"`
-	AllowedNamespaces      []string      `envconfig:"allowed_namespaces"`
-	EnableNamespaceSecrets bool          `envconfig:"enable_namespace_secrets"`
-	EnableLimitedCache     bool          `envconfig:"enable_limited_cache"`
-	ClusterID              string        `envconfig:"cluster_id"`
-	InitialClusterID       string        `envconfig:"initial_cluster_id"`
-	RetryBaseDelay         time.Duration `envconfig:"retry_base_delay"`
-	RetryMaxDelay          time.Duration `envconfig:"retry_max_delay"`
+	SyncPeriod             time.Duration            `envconfig:"sync_period"`
+	PollInterval           time.Duration            `envconfig:"poll_interval"`
+	LongPollInterval       time.Duration            `envconfig:"long_poll_interval"`
+	ManagementNamespace    string                   `envconfig:"management_namespace"`
+	ReleaseNamespace       string                   `envconfig:"release_namespace"`
+	AllowClusterAccess     bool                     `envconfig:"allow_cluster_access"`
+	AllowedNamespaces      []string                 `envconfig:"allowed_namespaces"`
+	EnableNamespaceSecrets bool                     `envconfig:"enable_namespace_secrets"`
+	EnableLimitedCache     bool                     `envconfig:"enable_limited_cache"`
+	ClusterID              string                   `envconfig:"cluster_id"`
+	InitialClusterID       string                   `envconfig:"initial_cluster_id"`
+	ClusterIDMigration     bool                     `envconfig:"cluster_id_migration"`
+	RetryBaseDelay         time.Duration            `envconfig:"retry_base_delay"`
+	RetryMaxDelay          time.Duration            `envconfig:"retry_max_delay"`
+	SMRequestTimeout       time.Duration            `envconfig:"sm_request_timeout"`
+	SMOperationTimeouts    map[string]time.Duration `envconfig:"sm_operation_timeouts"`
+	SMClientIdleTimeout    time.Duration            `envconfig:"sm_client_idle_timeout"`
+	SMCatalogCacheTTL      time.Duration            `envconfig:"sm_catalog_cache_ttl"`
+	SMRateLimit            float64                  `envconfig:"sm_rate_limit"`
+	SMRateLimitBurst       int                      `envconfig:"sm_rate_limit_burst"`
+	SMRequestRetries       int                      `envconfig:"sm_request_retries"`
+	SMRetryBaseDelay       time.Duration            `envconfig:"sm_retry_base_delay"`
+	SMRetryMaxDelay        time.Duration            `envconfig:"sm_retry_max_delay"`
+	SMBreakerThreshold     int                      `envconfig:"sm_breaker_threshold"`
+	SMBreakerCooldown      time.Duration            `envconfig:"sm_breaker_cooldown"`
+	TracingEndpoint        string                   `envconfig:"tracing_endpoint"`
+	TracingInsecure        bool                     `envconfig:"tracing_insecure"`
+	TracingSampleRatio     float64                  `envconfig:"tracing_sample_ratio"`
+	ParamsSchemaValidation string                   `envconfig:"params_schema_validation"`
+	DriftCheckInterval     time.Duration            `envconfig:"drift_check_interval"`
+	OrphanCheckInterval    time.Duration            `envconfig:"orphan_check_interval"`
+	OrphanPolicy           string                   `envconfig:"orphan_policy"`
 }
 
 func Get() Config {
@@ -40,8 +74,33 @@ func Get() Config {
 			AllowClusterAccess:     true,
 			RetryBaseDelay:         10 * time.Second,
 			RetryMaxDelay:          3 * time.Hour,
+			SMRequestTimeout:       time.Minute,
+			SMOperationTimeouts:    map[string]time.Duration{},
+			SMClientIdleTimeout:    30 * time.Minute,
+			SMCatalogCacheTTL:      5 * time.Minute,
+			SMRateLimit:            20,
+			SMRateLimitBurst:       40,
+			SMRequestRetries:       3,
+			SMRetryBaseDelay:       500 * time.Millisecond,
+			SMRetryMaxDelay:        10 * time.Second,
+			SMBreakerThreshold:     5,
+			SMBreakerCooldown:      30 * time.Second,
+			TracingSampleRatio:     1,
+			ParamsSchemaValidation: SchemaValidationWarn,
+			OrphanPolicy:           OrphanPolicyReport,
 		}
 		envconfig.MustProcess("", &config)
 	})
 	return config
 }
+
+// Validate returns an error for settings that would be interpreted differently by the webhooks and the controllers
+func (c Config) Validate() error {
+	switch c.ParamsSchemaValidation {
+	case SchemaValidationEnforce, SchemaValidationWarn, SchemaValidationDisabled:
+	default:
+		return fmt.Errorf("invalid params_schema_validation %q, expected %s, %s or %s", c.ParamsSchemaValidation,
+			SchemaValidationEnforce, SchemaValidationWarn, SchemaValidationDisabled)
+	}
+	return nil
+}
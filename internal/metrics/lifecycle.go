@@ -0,0 +1,204 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const collectTimeout = 10 * time.Second
+
+var (
+	InstanceTimeToReady = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "service_instance",
+		Name:      "time_to_ready_seconds",
+		Help:      "Time from an async provision request until its operation succeeded, by offering and plan",
+		Buckets:   []float64{5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200},
+	}, []string{"offering", "plan"})
+
+	CredentialRotations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "service_binding",
+		Name:      "credential_rotations_total",
+		Help:      "Number of service binding credential rotations, by result",
+	}, []string{"result"})
+
+	StaleBindingDeletions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "service_binding",
+		Name:      "stale_deletions_total",
+		Help:      "Number of stale service bindings deleted after credential rotation, by reason",
+	}, []string{"reason"})
+
+	instancesByConditionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "service_instance", "conditions"),
+		"Number of service instances by condition type, status and reason",
+		[]string{"condition", "status", "reason"}, nil)
+
+	instancesByPlanDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "service_instance", "count"),
+		"Number of service instances by offering and plan",
+		[]string{"offering", "plan"}, nil)
+
+	bindingsByConditionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "service_binding", "conditions"),
+		"Number of service bindings by condition type, status and reason",
+		[]string{"condition", "status", "reason"}, nil)
+
+	bindingsByPlanDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "service_binding", "count"),
+		"Number of service bindings by offering and plan of their service instance",
+		[]string{"offering", "plan"}, nil)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		InstanceTimeToReady,
+		CredentialRotations,
+		StaleBindingDeletions,
+	)
+}
+
+// RegisterResourceCollector exposes gauges of the service instances and bindings visible to the reader,
+// they are computed from the reader (usually the manager cache) on every scrape
+func RegisterResourceCollector(reader client.Reader) error {
+	return metrics.Registry.Register(&resourceCollector{reader: reader})
+}
+
+type resourceCollector struct {
+	reader client.Reader
+}
+
+type conditionKey struct {
+	condition, status, reason string
+}
+
+type planKey struct {
+	offering, plan string
+}
+
+func (c *resourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- instancesByConditionDesc
+	ch <- instancesByPlanDesc
+	ch <- bindingsByConditionDesc
+	ch <- bindingsByPlanDesc
+}
+
+func (c *resourceCollector) Collect(ch chan<- prometheus.Metric) {
+	log := ctrl.Log.WithName("metrics")
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	instances := &v1.ServiceInstanceList{}
+	if err := c.reader.List(ctx, instances); err != nil {
+		log.Error(err, "failed to list service instances for metrics")
+		return
+	}
+	bindings := &v1.ServiceBindingList{}
+	if err := c.reader.List(ctx, bindings); err != nil {
+		log.Error(err, "failed to list service bindings for metrics")
+		return
+	}
+
+	instanceConditions := make(map[conditionKey]float64)
+	instancePlans := make(map[planKey]float64)
+	plansByInstance := make(map[types.NamespacedName]planKey)
+	for _, instance := range instances.Items {
+		countConditions(instanceConditions, instance.GetConditions())
+		key := planKey{offering: instance.Spec.ServiceOfferingName, plan: instance.Spec.ServicePlanName}
+		instancePlans[key]++
+		plansByInstance[types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}] = key
+	}
+
+	bindingConditions := make(map[conditionKey]float64)
+	bindingPlans := make(map[planKey]float64)
+	for _, binding := range bindings.Items {
+		countConditions(bindingConditions, binding.GetConditions())
+		namespace := binding.Spec.ServiceInstanceNamespace
+		if len(namespace) == 0 {
+			namespace = binding.Namespace
+		}
+		// bindings of missing instances are counted with empty offering and plan
+		bindingPlans[plansByInstance[types.NamespacedName{Namespace: namespace, Name: binding.Spec.ServiceInstanceName}]]++
+	}
+
+	emitConditions(ch, instancesByConditionDesc, instanceConditions)
+	emitPlans(ch, instancesByPlanDesc, instancePlans)
+	emitConditions(ch, bindingsByConditionDesc, bindingConditions)
+	emitPlans(ch, bindingsByPlanDesc, bindingPlans)
+}
+
+func countConditions(counts map[conditionKey]float64, conditions []metav1.Condition) {
+	for _, condition := range conditions {
+		counts[conditionKey{condition: condition.Type, status: string(condition.Status), reason: condition.Reason}]++
+	}
+}
+
+func emitConditions(ch chan<- prometheus.Metric, desc *prometheus.Desc, counts map[conditionKey]float64) {
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, count, key.condition, key.status, key.reason)
+	}
+}
+
+func emitPlans(ch chan<- prometheus.Metric, desc *prometheus.Desc, counts map[planKey]float64) {
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, count, key.offering, key.plan)
+	}
+}
+
+// ProvisionTracker measures the time between an async provision request and the success of its operation.
+// Start times are kept in memory, provisions that were started before a restart of the operator are not observed.
+type ProvisionTracker struct {
+	mu     sync.Mutex
+	starts map[types.UID]time.Time
+}
+
+// Provisions tracks the async provisions of all service instances
+var Provisions = NewProvisionTracker()
+
+func NewProvisionTracker() *ProvisionTracker {
+	return &ProvisionTracker{starts: make(map[types.UID]time.Time)}
+}
+
+// Started records the start of an async provision of the instance
+func (t *ProvisionTracker) Started(uid types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.starts[uid] = time.Now()
+}
+
+// Succeeded observes the time to ready of the instance if its provision start is known
+func (t *ProvisionTracker) Succeeded(uid types.UID, offering, plan string) {
+	t.mu.Lock()
+	start, ok := t.starts[uid]
+	delete(t.starts, uid)
+	t.mu.Unlock()
+
+	if ok {
+		InstanceTimeToReady.WithLabelValues(offering, plan).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Forget drops the provision start of the instance, e.g. when the provision failed
+func (t *ProvisionTracker) Forget(uid types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.starts, uid)
+}
+
+// InProgress reports whether a provision start of the instance is tracked
+func (t *ProvisionTracker) InProgress(uid types.UID) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.starts[uid]
+	return ok
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Lifecycle metrics", func() {
+	Describe("resourceCollector", func() {
+		It("counts instances and bindings by condition and plan", func() {
+			scheme := runtime.NewScheme()
+			Expect(v1.AddToScheme(scheme)).To(Succeed())
+
+			instance := &v1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "default"},
+				Spec:       v1.ServiceInstanceSpec{ServiceOfferingName: "offering", ServicePlanName: "plan"},
+				Status: v1.ServiceInstanceStatus{Conditions: []metav1.Condition{
+					{Type: common.ConditionSucceeded, Status: metav1.ConditionFalse, Reason: common.CreateInProgress},
+				}},
+			}
+			binding := &v1.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "default"},
+				Spec:       v1.ServiceBindingSpec{ServiceInstanceName: "instance"},
+				Status: v1.ServiceBindingStatus{Conditions: []metav1.Condition{
+					{Type: common.ConditionReady, Status: metav1.ConditionTrue, Reason: common.Provisioned},
+				}},
+			}
+			reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance, binding).Build()
+
+			expected := `
+# HELP sap_btp_operator_service_binding_conditions Number of service bindings by condition type, status and reason
+# TYPE sap_btp_operator_service_binding_conditions gauge
+sap_btp_operator_service_binding_conditions{condition="Ready",reason="Provisioned",status="True"} 1
+# HELP sap_btp_operator_service_binding_count Number of service bindings by offering and plan of their service instance
+# TYPE sap_btp_operator_service_binding_count gauge
+sap_btp_operator_service_binding_count{offering="offering",plan="plan"} 1
+# HELP sap_btp_operator_service_instance_conditions Number of service instances by condition type, status and reason
+# TYPE sap_btp_operator_service_instance_conditions gauge
+sap_btp_operator_service_instance_conditions{condition="Succeeded",reason="CreateInProgress",status="False"} 1
+# HELP sap_btp_operator_service_instance_count Number of service instances by offering and plan
+# TYPE sap_btp_operator_service_instance_count gauge
+sap_btp_operator_service_instance_count{offering="offering",plan="plan"} 1
+`
+			Expect(testutil.CollectAndCompare(&resourceCollector{reader: reader}, strings.NewReader(expected))).To(Succeed())
+		})
+	})
+
+	Describe("ProvisionTracker", func() {
+		It("observes the time to ready of started provisions only", func() {
+			tracker := NewProvisionTracker()
+			before := testutil.CollectAndCount(InstanceTimeToReady)
+
+			tracker.Succeeded(types.UID("unknown"), "tracker-offering", "tracker-plan")
+			Expect(testutil.CollectAndCount(InstanceTimeToReady)).To(Equal(before))
+
+			tracker.Started(types.UID("uid"))
+			tracker.Succeeded(types.UID("uid"), "tracker-offering", "tracker-plan")
+			Expect(testutil.CollectAndCount(InstanceTimeToReady)).To(Equal(before + 1))
+		})
+
+		It("does not observe forgotten provisions", func() {
+			tracker := NewProvisionTracker()
+			tracker.Started(types.UID("uid"))
+			Expect(tracker.InProgress(types.UID("uid"))).To(BeTrue())
+			tracker.Forget(types.UID("uid"))
+			Expect(tracker.InProgress(types.UID("uid"))).To(BeFalse())
+			Expect(tracker.starts).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "sap_btp_operator"
+
+var (
+	SMClientPoolHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sm_client_pool",
+		Name:      "hits_total",
+		Help:      "Number of times a cached Service Manager client was reused",
+	})
+
+	SMClientPoolMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sm_client_pool",
+		Name:      "misses_total",
+		Help:      "Number of times a new Service Manager client had to be created",
+	})
+
+	SMClientPoolEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sm_client_pool",
+		Name:      "evictions_total",
+		Help:      "Number of cached Service Manager clients evicted from the pool, by reason",
+	}, []string{"reason"})
+
+	SMClientPoolSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sm_client_pool",
+		Name:      "size",
+		Help:      "Number of Service Manager clients currently cached",
+	})
+)
+
+var (
+	SMRateLimiterWaitSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "sm_rate_limiter",
+		Name:      "wait_seconds",
+		Help:      "Time calls to Service Manager waited for the client side rate limiter",
+		Buckets:   []float64{0.001, 0.01, 0.1, 0.5, 1, 5, 10, 30},
+	})
+
+	SMRateLimiterPauses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sm_rate_limiter",
+		Name:      "pauses_total",
+		Help:      "Number of times calls to Service Manager were paused due to a 429 response",
+	})
+
+	SMRateLimiterRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sm_rate_limiter",
+		Name:      "rejected_total",
+		Help:      "Number of calls to Service Manager rejected while calls were paused",
+	})
+)
+
+var (
+	SMCircuitBreakerOpen = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sm_circuit_breaker",
+		Name:      "open",
+		Help:      "Whether calls to the Service Manager endpoint are suspended (1) or not (0)",
+	}, []string{"endpoint"})
+
+	SMCircuitBreakerRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sm_circuit_breaker",
+		Name:      "rejected_total",
+		Help:      "Number of calls to Service Manager rejected while the circuit breaker was open",
+	}, []string{"endpoint"})
+)
+
+var (
+	SMRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sm",
+		Name:      "requests_total",
+		Help:      "Number of calls to Service Manager by operation, status code and whether the operation is async",
+	}, []string{"operation", "code", "async"})
+
+	SMRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "sm",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of calls to Service Manager including retries, by operation, status code and whether the operation is async",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"operation", "code", "async"})
+
+	OAuthTokenFetchDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "oauth",
+		Name:      "token_fetch_duration_seconds",
+		Help:      "Latency of fetching OAuth tokens for Service Manager",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	})
+
+	OAuthTokenFetchFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "oauth",
+		Name:      "token_fetch_failures_total",
+		Help:      "Number of failed OAuth token fetches for Service Manager",
+	})
+)
+
+var (
+	Orphans = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "orphans",
+		Name:      "count",
+		Help:      "Number of Service Manager resources labeled with the cluster ID that no k8s resource manages, by kind",
+	}, []string{"kind"})
+
+	OrphanActions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "orphans",
+		Name:      "actions_total",
+		Help:      "Number of orphaned Service Manager resources deleted or adopted, by kind, action and result",
+	}, []string{"kind", "action", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		SMClientPoolHits,
+		SMClientPoolMisses,
+		SMClientPoolEvictions,
+		SMClientPoolSize,
+		SMRateLimiterWaitSeconds,
+		SMRateLimiterPauses,
+		SMRateLimiterRejected,
+		SMCircuitBreakerOpen,
+		SMCircuitBreakerRejected,
+		SMRequests,
+		SMRequestDuration,
+		OAuthTokenFetchDuration,
+		OAuthTokenFetchFailures,
+		Orphans,
+		OrphanActions,
+	)
+}
@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	tracerName  = "github.com/SAP/sap-btp-service-operator"
+	serviceName = "sap-btp-service-operator"
+
+	// CorrelationIDKey is the span attribute holding the correlation ID that is logged and sent to Service Manager
+	CorrelationIDKey = attribute.Key("sap.correlation_id")
+)
+
+// Options configures the export of traces
+type Options struct {
+	// Endpoint of the OTLP/HTTP collector, either host:port or a URL, tracing is disabled when empty
+	Endpoint string
+	// Insecure disables TLS towards the collector
+	Insecure bool
+	// SampleRatio is the fraction of reconciles that are traced unless the parent span was sampled
+	SampleRatio float64
+}
+
+// Setup installs the W3C trace context propagator and, if an endpoint is configured, a tracer provider
+// exporting spans over OTLP/HTTP. The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	if len(opts.Endpoint) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var exporterOpts []otlptracehttp.Option
+	if strings.Contains(opts.Endpoint, "://") {
+		if _, err := url.Parse(opts.Endpoint); err != nil {
+			return nil, err
+		}
+		exporterOpts = append(exporterOpts, otlptracehttp.WithEndpointURL(opts.Endpoint))
+	} else {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithEndpoint(opts.Endpoint))
+	}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span as child of the span in the context, if any
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, on the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartReconcile starts the root span of a reconcile of the given resource
+func StartReconcile(ctx context.Context, kind string, key types.NamespacedName, correlationID string) (context.Context, trace.Span) {
+	return StartSpan(ctx, kind+" reconcile",
+		attribute.String("k8s.namespace.name", key.Namespace),
+		attribute.String("k8s.resource.kind", kind),
+		attribute.String("k8s.resource.name", key.Name),
+		CorrelationIDKey.String(correlationID))
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		previous       trace.TracerProvider
+		prevPropagator propagation.TextMapPropagator
+	)
+
+	BeforeEach(func() {
+		previous = otel.GetTracerProvider()
+		prevPropagator = otel.GetTextMapPropagator()
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	When("no endpoint is configured", func() {
+		It("does not record spans", func() {
+			shutdown, err := Setup(context.TODO(), Options{})
+			Expect(err).ToNot(HaveOccurred())
+			_, span := StartReconcile(context.TODO(), "ServiceInstance", types.NamespacedName{Namespace: "ns", Name: "name"}, "id")
+			Expect(span.IsRecording()).To(BeFalse())
+			span.End()
+			Expect(shutdown(context.TODO())).To(Succeed())
+		})
+	})
+
+	When("an endpoint is configured", func() {
+		var (
+			collector *httptest.Server
+			exports   int32
+		)
+
+		BeforeEach(func() {
+			exports = 0
+			collector = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/traces" {
+					atomic.AddInt32(&exports, 1)
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+		})
+
+		AfterEach(func() {
+			collector.Close()
+		})
+
+		It("exports spans to the collector", func() {
+			shutdown, err := Setup(context.TODO(), Options{Endpoint: collector.URL, Insecure: true, SampleRatio: 1})
+			Expect(err).ToNot(HaveOccurred())
+			_, span := StartReconcile(context.TODO(), "ServiceInstance", types.NamespacedName{Namespace: "ns", Name: "name"}, "id")
+			Expect(span.IsRecording()).To(BeTrue())
+			End(span, nil)
+			Expect(shutdown(context.TODO())).To(Succeed())
+			Expect(atomic.LoadInt32(&exports)).To(Equal(int32(1)))
+		})
+	})
+})
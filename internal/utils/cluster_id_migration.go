@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ClusterIDSecretName   = "sap-btp-operator-clusterid"
+	InitialClusterIDKey   = "INITIAL_CLUSTER_ID"
+	migrationFailureLimit = 10
+)
+
+// ClusterIDMigration moves the SM instances and bindings labeled with the previous cluster ID to the new one, and then
+// records the new ID as the initial cluster ID so the operator starts with it
+type ClusterIDMigration struct {
+	Client           client.Client
+	GetSMClient      func(ctx context.Context, instance *v1.ServiceInstance) (sm.Client, error)
+	ReleaseNamespace string
+	FromID           string
+	ToID             string
+	Log              logr.Logger
+	// OnComplete is called by Start once the migration succeeded, the controllers are set up by it so they don't act on
+	// the resources of the previous cluster ID
+	OnComplete func() error
+}
+
+// ClusterIDMigrationResult counts the SM resources that were moved to the new cluster ID and lists the failures
+type ClusterIDMigrationResult struct {
+	Instances int
+	Bindings  int
+	Failures  []string
+}
+
+func (m *ClusterIDMigration) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(m)
+}
+
+// NeedLeaderElection makes only the leader migrate, replicas would relabel the same resources concurrently and fail each
+// other's label changes
+func (m *ClusterIDMigration) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the migration once this replica is the leader, a failed migration stops the manager and is run again when
+// the operator restarts
+func (m *ClusterIDMigration) Start(ctx context.Context) error {
+	if _, err := m.Run(ctx); err != nil {
+		return fmt.Errorf("cluster ID migration failed, restart the operator to retry: %w", err)
+	}
+	if m.OnComplete != nil {
+		return m.OnComplete()
+	}
+	return nil
+}
+
+// Run relabels the SM resources of every set of credentials in use, the cluster ID secret is updated only when all of them
+// were relabeled. A failed migration can be run again, the resources that were already relabeled are not listed again.
+func (m *ClusterIDMigration) Run(ctx context.Context) (*ClusterIDMigrationResult, error) {
+	result := &ClusterIDMigrationResult{}
+	m.Log.Info(fmt.Sprintf("migrating the resources of cluster %s to cluster %s", m.FromID, m.ToID))
+
+	credentials, errs := GetCredentialsInUse(ctx, m.Client, m.GetSMClient, m.ReleaseNamespace)
+	for _, err := range errs {
+		result.Failures = append(result.Failures, err.Error())
+	}
+	for i, creds := range credentials {
+		m.migrateInstances(ctx, creds.Client, result)
+		m.migrateBindings(ctx, creds.Client, result)
+		m.Log.Info(fmt.Sprintf("migrated the resources of %d/%d credentials: %d instances, %d bindings, %d failures", i+1, len(credentials), result.Instances, result.Bindings, len(result.Failures)))
+	}
+
+	if len(result.Failures) > 0 {
+		failures := result.Failures
+		if len(failures) > migrationFailureLimit {
+			failures = failures[:migrationFailureLimit]
+		}
+		return result, fmt.Errorf("cluster ID migration failed for %d resources, first failures: %v", len(result.Failures), failures)
+	}
+	if err := m.updateClusterIDSecret(ctx); err != nil {
+		return result, err
+	}
+	m.Log.Info(fmt.Sprintf("cluster ID migration completed: %d instances, %d bindings", result.Instances, result.Bindings))
+	return result, nil
+}
+
+func (m *ClusterIDMigration) migrateInstances(ctx context.Context, smClient sm.Client, result *ClusterIDMigrationResult) {
+	instances, err := smClient.ListInstances(ctx, &sm.Parameters{LabelQuery: []string{m.clusterIDQuery()}})
+	if err != nil {
+		result.Failures = append(result.Failures, fmt.Sprintf("list instances: %s", err.Error()))
+		return
+	}
+	if instances == nil {
+		return
+	}
+	for _, instance := range instances.ServiceInstances {
+		err := ApplyLabelChanges(instance.Labels, m.desiredLabels(), func(changes []*smClientTypes.LabelChange) error {
+			_, err := smClient.UpdateInstanceLabels(ctx, instance.ID, changes)
+			return err
+		})
+		if err != nil {
+			m.Log.Error(err, fmt.Sprintf("failed to migrate instance %s", instance.ID))
+			result.Failures = append(result.Failures, fmt.Sprintf("instance %s: %s", instance.ID, err.Error()))
+			continue
+		}
+		m.Log.Info(fmt.Sprintf("migrated instance %s (%s)", instance.ID, instance.Name))
+		result.Instances++
+	}
+}
+
+func (m *ClusterIDMigration) migrateBindings(ctx context.Context, smClient sm.Client, result *ClusterIDMigrationResult) {
+	bindings, err := smClient.ListBindings(ctx, &sm.Parameters{LabelQuery: []string{m.clusterIDQuery()}})
+	if err != nil {
+		result.Failures = append(result.Failures, fmt.Sprintf("list bindings: %s", err.Error()))
+		return
+	}
+	if bindings == nil {
+		return
+	}
+	for _, binding := range bindings.ServiceBindings {
+		err := ApplyLabelChanges(binding.Labels, m.desiredLabels(), func(changes []*smClientTypes.LabelChange) error {
+			_, err := smClient.UpdateBindingLabels(ctx, binding.ID, changes)
+			return err
+		})
+		if err != nil {
+			m.Log.Error(err, fmt.Sprintf("failed to migrate binding %s", binding.ID))
+			result.Failures = append(result.Failures, fmt.Sprintf("binding %s: %s", binding.ID, err.Error()))
+			continue
+		}
+		m.Log.Info(fmt.Sprintf("migrated binding %s (%s)", binding.ID, binding.Name))
+		result.Bindings++
+	}
+}
+
+func (m *ClusterIDMigration) updateClusterIDSecret(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	if err := m.Client.Get(ctx, types.NamespacedName{Namespace: m.ReleaseNamespace, Name: ClusterIDSecretName}, secret); err != nil {
+		return err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[InitialClusterIDKey] = []byte(m.ToID)
+	if err := m.Client.Update(ctx, secret); err != nil {
+		return fmt.Errorf("resources were migrated but the cluster ID secret was not updated: %w", err)
+	}
+	return nil
+}
+
+func (m *ClusterIDMigration) clusterIDQuery() string {
+	return fmt.Sprintf("%s eq '%s'", common.ClusterIDLabel, m.FromID)
+}
+
+func (m *ClusterIDMigration) desiredLabels() smClientTypes.Labels {
+	return smClientTypes.Labels{common.ClusterIDLabel: []string{m.ToID}}
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/client/sm/smfakes"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Cluster ID migration", func() {
+	var (
+		migration     *ClusterIDMigration
+		smClient      *smfakes.FakeClient
+		clusterSecret *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1.AddToScheme(scheme)).To(Succeed())
+		clusterSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: ClusterIDSecretName, Namespace: "release-namespace"},
+			Data:       map[string][]byte{InitialClusterIDKey: []byte("old-cluster")},
+		}
+		instance := &v1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: testNamespace}}
+
+		smClient = &smfakes.FakeClient{}
+		smClient.ListInstancesReturns(&smClientTypes.ServiceInstances{ServiceInstances: []smClientTypes.ServiceInstance{
+			{ID: "instance-id", Labels: smClientTypes.Labels{common.ClusterIDLabel: []string{"old-cluster"}}},
+		}}, nil)
+		smClient.ListBindingsReturns(&smClientTypes.ServiceBindings{ServiceBindings: []smClientTypes.ServiceBinding{
+			{ID: "binding-id", Labels: smClientTypes.Labels{common.ClusterIDLabel: []string{"old-cluster"}}},
+		}}, nil)
+
+		migration = &ClusterIDMigration{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clusterSecret, instance).Build(),
+			GetSMClient: func(_ context.Context, _ *v1.ServiceInstance) (sm.Client, error) {
+				return smClient, nil
+			},
+			ReleaseNamespace: "release-namespace",
+			FromID:           "old-cluster",
+			ToID:             "new-cluster",
+			Log:              logr.Discard(),
+		}
+	})
+
+	It("should relabel the resources of the old cluster and update the cluster ID secret", func() {
+		result, err := migration.Run(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Instances).To(Equal(1))
+		Expect(result.Bindings).To(Equal(1))
+
+		// instances sharing credentials are migrated once
+		Expect(smClient.ListInstancesCallCount()).To(Equal(1))
+		_, params := smClient.ListInstancesArgsForCall(0)
+		Expect(params.LabelQuery).To(ConsistOf("_clusterid eq 'old-cluster'"))
+		Expect(smClient.UpdateInstanceLabelsCallCount()).To(Equal(2))
+		_, _, additions := smClient.UpdateInstanceLabelsArgsForCall(1)
+		Expect(additions).To(ConsistOf(&smClientTypes.LabelChange{Operation: smClientTypes.AddLabelOperation, Key: common.ClusterIDLabel, Values: []string{"new-cluster"}}))
+		Expect(smClient.UpdateBindingLabelsCallCount()).To(Equal(2))
+
+		secret := &corev1.Secret{}
+		Expect(migration.Client.Get(context.Background(), client.ObjectKeyFromObject(clusterSecret), secret)).To(Succeed())
+		Expect(string(secret.Data[InitialClusterIDKey])).To(Equal("new-cluster"))
+	})
+
+	It("should report failures and keep the cluster ID secret", func() {
+		smClient.UpdateBindingLabelsReturns(nil, fmt.Errorf("label update failed"))
+		result, err := migration.Run(context.Background())
+		Expect(err).To(MatchError(ContainSubstring("failed for 1 resources")))
+		Expect(result.Instances).To(Equal(1))
+		Expect(result.Failures).To(ConsistOf(ContainSubstring("binding binding-id")))
+
+		secret := &corev1.Secret{}
+		Expect(migration.Client.Get(context.Background(), types.NamespacedName{Name: ClusterIDSecretName, Namespace: "release-namespace"}, secret)).To(Succeed())
+		Expect(string(secret.Data[InitialClusterIDKey])).To(Equal("old-cluster"))
+	})
+
+	It("should run on the leader and complete only after a successful migration", func() {
+		completed := false
+		migration.OnComplete = func() error {
+			completed = true
+			return nil
+		}
+		Expect(migration.NeedLeaderElection()).To(BeTrue())
+
+		smClient.UpdateBindingLabelsReturns(nil, fmt.Errorf("label update failed"))
+		Expect(migration.Start(context.Background())).To(MatchError(ContainSubstring("restart the operator to retry")))
+		Expect(completed).To(BeFalse())
+
+		smClient.UpdateBindingLabelsReturns(nil, nil)
+		Expect(migration.Start(context.Background())).To(Succeed())
+		Expect(completed).To(BeTrue())
+	})
+})
@@ -2,9 +2,12 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/SAP/sap-btp-service-operator/api/common"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
 	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
 	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -70,6 +73,7 @@ func SetInProgressConditions(ctx context.Context, operationType smClientTypes.Op
 	conditions := object.GetConditions()
 	if len(conditions) > 0 {
 		meta.RemoveStatusCondition(&conditions, common.ConditionFailed) // backward compatibility
+		meta.RemoveStatusCondition(&conditions, common.ConditionDegraded)
 	}
 	observedGen := object.GetGeneration()
 	if isAsyncOperation {
@@ -102,6 +106,7 @@ func SetSuccessConditions(operationType smClientTypes.OperationCategory, object
 	conditions := object.GetConditions()
 	if len(conditions) > 0 {
 		meta.RemoveStatusCondition(&conditions, common.ConditionFailed) // backward compatibility
+		meta.RemoveStatusCondition(&conditions, common.ConditionDegraded)
 	}
 	observedGen := object.GetGeneration()
 	if isAsyncOperation {
@@ -166,6 +171,7 @@ func SetFailureConditions(operationType smClientTypes.OperationCategory, errorMe
 		observedGen = getLastObservedGen(object)
 	}
 	conditions := object.GetConditions()
+	meta.RemoveStatusCondition(&conditions, common.ConditionDegraded)
 	lastOpCondition := metav1.Condition{
 		Type:               common.ConditionSucceeded,
 		Status:             metav1.ConditionFalse,
@@ -182,10 +188,15 @@ func SetFailureConditions(operationType smClientTypes.OperationCategory, errorMe
 
 func HandleOperationFailure(ctx context.Context, k8sClient client.Client, object common.SAPBTPResource, operationType smClientTypes.OperationCategory, err error) (ctrl.Result, error) {
 	log := logutils.GetLogger(ctx)
+	var unavailableErr *sm.ServiceManagerUnavailableError
+	if errors.As(err, &unavailableErr) {
+		return HandleServiceManagerUnavailable(ctx, k8sClient, object, unavailableErr)
+	}
 	log.Info(fmt.Sprintf("operation %s of %s encountered a transient error %s, retrying operation :)", operationType, object.GetControllerName(), err.Error()))
 
 	conditions := object.GetConditions()
 	meta.RemoveStatusCondition(&conditions, common.ConditionFailed) //backward compatible
+	meta.RemoveStatusCondition(&conditions, common.ConditionDegraded)
 	lastOpCondition := metav1.Condition{
 		Type:               common.ConditionSucceeded,
 		Status:             metav1.ConditionFalse,
@@ -206,6 +217,78 @@ func HandleOperationFailure(ctx context.Context, k8sClient client.Client, object
 	return ctrl.Result{}, err
 }
 
+// HandleServiceManagerUnavailable marks the resource as degraded while Service Manager is unavailable.
+// The last operation and ready conditions are kept as they are, so provisioned resources stay ready.
+func HandleServiceManagerUnavailable(ctx context.Context, k8sClient client.Client, object common.SAPBTPResource, err *sm.ServiceManagerUnavailableError) (ctrl.Result, error) {
+	log := logutils.GetLogger(ctx)
+	log.Info(fmt.Sprintf("%s, requeueing", err.Error()))
+
+	if SetDegradedCondition(object, err.Error()) {
+		if updateErr := UpdateStatus(ctx, k8sClient, object); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: max(time.Until(err.RetryAt), time.Second)}, nil
+}
+
+// SetDegradedCondition sets the degraded condition and reports whether the conditions were changed
+func SetDegradedCondition(object common.SAPBTPResource, message string) bool {
+	conditions := object.GetConditions()
+	if meta.IsStatusConditionTrue(conditions, common.ConditionDegraded) {
+		return false
+	}
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               common.ConditionDegraded,
+		Status:             metav1.ConditionTrue,
+		Reason:             common.ServiceManagerUnavailable,
+		Message:            message,
+		ObservedGeneration: object.GetGeneration(),
+	})
+	object.SetConditions(conditions)
+	return true
+}
+
+// RemoveDegradedCondition removes the degraded condition and reports whether it was present
+func RemoveDegradedCondition(object common.SAPBTPResource) bool {
+	conditions := object.GetConditions()
+	if meta.FindStatusCondition(conditions, common.ConditionDegraded) == nil {
+		return false
+	}
+	meta.RemoveStatusCondition(&conditions, common.ConditionDegraded)
+	object.SetConditions(conditions)
+	return true
+}
+
+// SetDriftedCondition sets the drifted condition and reports whether its status or message changed
+func SetDriftedCondition(object common.SAPBTPResource, status metav1.ConditionStatus, reason, message string) bool {
+	conditions := object.GetConditions()
+	current := meta.FindStatusCondition(conditions, common.ConditionDrifted)
+	if current != nil && current.Status == status && current.Message == message {
+		return false
+	}
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               common.ConditionDrifted,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: object.GetGeneration(),
+	})
+	object.SetConditions(conditions)
+	return true
+}
+
+// RemoveDriftedCondition removes the drifted condition and reports whether it was present
+func RemoveDriftedCondition(object common.SAPBTPResource) bool {
+	conditions := object.GetConditions()
+	if meta.FindStatusCondition(conditions, common.ConditionDrifted) == nil {
+		return false
+	}
+	meta.RemoveStatusCondition(&conditions, common.ConditionDrifted)
+	object.SetConditions(conditions)
+	return true
+}
+
 // blocked condition marks to the user that action from his side is required, this is considered as in progress operation
 func SetBlockedCondition(ctx context.Context, message string, object common.SAPBTPResource) {
 	SetInProgressConditions(ctx, common.Unknown, message, object, false)
@@ -213,15 +296,36 @@ func SetBlockedCondition(ctx context.Context, message string, object common.SAPB
 	lastOpCondition.Reason = common.Blocked
 }
 
-func SetSharedCondition(object common.SAPBTPResource, status metav1.ConditionStatus, reason, msg string) {
+// SetPlanNotUpdatableCondition marks the update as failed because the requested plan change is not allowed
+func SetPlanNotUpdatableCondition(message string, object common.SAPBTPResource) {
+	SetFailureConditions(smClientTypes.UPDATE, message, object, false)
+	lastOpCondition := meta.FindStatusCondition(object.GetConditions(), common.ConditionSucceeded)
+	lastOpCondition.Reason = common.PlanNotUpdatable
+}
+
+// AlignConditionsGeneration marks the conditions as observed for the latest generation, used when a spec change requires no operation in SM
+func AlignConditionsGeneration(object common.SAPBTPResource) {
 	conditions := object.GetConditions()
-	// align all conditions to latest generation
 	for _, cond := range object.GetConditions() {
+		// shared condition does not contain observed generation
 		if cond.Type != common.ConditionShared {
 			cond.ObservedGeneration = object.GetGeneration()
 			meta.SetStatusCondition(&conditions, cond)
 		}
 	}
+	object.SetConditions(conditions)
+}
+
+// SetAdoptionRefusedCondition marks the creation as failed because the instance to adopt can't be taken over by this cluster
+func SetAdoptionRefusedCondition(message string, object common.SAPBTPResource) {
+	SetFailureConditions(smClientTypes.CREATE, message, object, false)
+	lastOpCondition := meta.FindStatusCondition(object.GetConditions(), common.ConditionSucceeded)
+	lastOpCondition.Reason = common.AdoptionRefused
+}
+
+func SetSharedCondition(object common.SAPBTPResource, status metav1.ConditionStatus, reason, msg string) {
+	AlignConditionsGeneration(object)
+	conditions := object.GetConditions()
 
 	shareCondition := metav1.Condition{
 		Type:    common.ConditionShared,
@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"time"
+
 	"github.com/SAP/sap-btp-service-operator/api/common"
 	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
 	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -174,6 +177,40 @@ var _ = Describe("Condition Utils", func() {
 		})
 	})
 
+	Context("HandleServiceManagerUnavailable", func() {
+		It("should set degraded condition without touching ready", func() {
+			SetSuccessConditions(smClientTypes.CREATE, resource, false)
+			Expect(UpdateStatus(ctx, k8sClient, resource)).To(Succeed())
+
+			unavailableErr := &sm.ServiceManagerUnavailableError{URL: "https://sm.url", RetryAt: time.Now().Add(time.Minute)}
+			result, err := HandleServiceManagerUnavailable(ctx, k8sClient, resource, unavailableErr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Minute, time.Second))
+			Expect(meta.IsStatusConditionTrue(resource.GetConditions(), common.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(resource.GetConditions(), common.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(resource.GetConditions(), common.ConditionSucceeded)).To(BeTrue())
+		})
+
+		It("should be removed once the resource is reconciled with Service Manager", func() {
+			Expect(SetDegradedCondition(resource, "unavailable")).To(BeTrue())
+			Expect(SetDegradedCondition(resource, "unavailable")).To(BeFalse())
+			SetSuccessConditions(smClientTypes.UPDATE, resource, false)
+			Expect(meta.FindStatusCondition(resource.GetConditions(), common.ConditionDegraded)).To(BeNil())
+			Expect(RemoveDegradedCondition(resource)).To(BeFalse())
+		})
+	})
+
+	Context("SetDriftedCondition", func() {
+		It("should report only changes of the drifted condition", func() {
+			Expect(SetDriftedCondition(resource, metav1.ConditionTrue, common.DriftDetected, "name drifted")).To(BeTrue())
+			Expect(SetDriftedCondition(resource, metav1.ConditionTrue, common.DriftDetected, "name drifted")).To(BeFalse())
+			Expect(SetDriftedCondition(resource, metav1.ConditionFalse, common.NoDrift, "no drift")).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(resource.GetConditions(), common.ConditionDrifted)).To(BeTrue())
+			Expect(RemoveDriftedCondition(resource)).To(BeTrue())
+			Expect(RemoveDriftedCondition(resource)).To(BeFalse())
+		})
+	})
+
 	Context("IsFailed", func() {
 		It("Should return false when no conditions available", func() {
 			sb := &v1.ServiceBinding{Status: v1.ServiceBindingStatus{Conditions: []metav1.Condition{}}}
@@ -123,6 +123,11 @@ func HandleServiceManagerError(ctx context.Context, k8sClient client.Client, res
 		}
 	}
 
+	var unavailableErr *sm.ServiceManagerUnavailableError
+	if errors.As(err, &unavailableErr) {
+		return HandleServiceManagerUnavailable(ctx, k8sClient, resource, unavailableErr)
+	}
+
 	if updateStatus {
 		return HandleOperationFailure(ctx, k8sClient, resource, operationType, err)
 	}
@@ -131,6 +136,10 @@ func HandleServiceManagerError(ctx context.Context, k8sClient client.Client, res
 
 func HandleCredRotationError(ctx context.Context, k8sClient client.Client, binding common.SAPBTPResource, err error) (ctrl.Result, error) {
 	log := logutils.GetLogger(ctx)
+	var unavailableErr *sm.ServiceManagerUnavailableError
+	if errors.As(err, &unavailableErr) {
+		return HandleServiceManagerUnavailable(ctx, k8sClient, binding, unavailableErr)
+	}
 	var smError *sm.ServiceManagerError
 	if ok := errors.As(err, &smError); ok {
 		if smError.StatusCode == http.StatusTooManyRequests {
@@ -179,27 +188,39 @@ func RemoveAnnotations(ctx context.Context, k8sClient client.Client, object comm
 }
 
 func AddWatchForSecretIfNeeded(ctx context.Context, k8sClient client.Client, secret *corev1.Secret, instanceUID string) error {
+	return addWatchIfNeeded(ctx, k8sClient, secret, common.WatchSecretAnnotation, common.WatchSecretLabel, instanceUID)
+}
+
+func AddWatchForConfigMapIfNeeded(ctx context.Context, k8sClient client.Client, configMap *corev1.ConfigMap, instanceUID string) error {
+	return addWatchIfNeeded(ctx, k8sClient, configMap, common.WatchConfigMapAnnotation, common.WatchConfigMapLabel, instanceUID)
+}
+
+func addWatchIfNeeded(ctx context.Context, k8sClient client.Client, object client.Object, watchAnnotation, watchLabel, instanceUID string) error {
 	log := logutils.GetLogger(ctx)
 	updateRequired := false
-	if secret.Annotations == nil {
-		secret.Annotations = make(map[string]string)
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
 	}
-	if len(secret.Annotations[common.WatchSecretAnnotation+string(instanceUID)]) == 0 {
-		log.Info(fmt.Sprintf("adding secret watch annotation for instance %s on secret %s", instanceUID, secret.Name))
-		secret.Annotations[common.WatchSecretAnnotation+instanceUID] = "true"
+	if len(annotations[watchAnnotation+instanceUID]) == 0 {
+		log.Info(fmt.Sprintf("adding watch annotation for instance %s on %s", instanceUID, object.GetName()))
+		annotations[watchAnnotation+instanceUID] = "true"
+		object.SetAnnotations(annotations)
 		updateRequired = true
 	}
-	if secret.Labels == nil {
-		secret.Labels = make(map[string]string)
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
 	}
-	if secret.Labels[common.WatchSecretLabel] != "true" {
-		log.Info(fmt.Sprintf("adding watch label for secret %s", secret.Name))
-		secret.Labels[common.WatchSecretLabel] = "true"
-		controllerutil.AddFinalizer(secret, common.FinalizerName)
+	if labels[watchLabel] != "true" {
+		log.Info(fmt.Sprintf("adding watch label for %s", object.GetName()))
+		labels[watchLabel] = "true"
+		object.SetLabels(labels)
+		controllerutil.AddFinalizer(object, common.FinalizerName)
 		updateRequired = true
 	}
 	if updateRequired {
-		return k8sClient.Update(ctx, secret)
+		return k8sClient.Update(ctx, object)
 	}
 
 	return nil
@@ -214,30 +235,58 @@ func RemoveWatchForSecret(ctx context.Context, k8sClient client.Client, secretKe
 		return client.IgnoreNotFound(err)
 	}
 
-	delete(secret.Annotations, common.WatchSecretAnnotation+instanceUID)
+	return removeWatch(ctx, k8sClient, secret, common.WatchSecretAnnotation, common.WatchSecretLabel, instanceUID)
+}
+
+func RemoveWatchForConfigMap(ctx context.Context, k8sClient client.Client, configMapKey apimachinerytypes.NamespacedName, instanceUID string) error {
+	log := logutils.GetLogger(ctx)
+	log.Info(fmt.Sprintf("removing config map watch annotation for instance %s, config map key: %v", instanceUID, configMapKey))
+	configMap := &corev1.ConfigMap{}
+	if err := GetConfigMapWithFallback(ctx, configMapKey, configMap); err != nil {
+		log.Error(err, fmt.Sprintf("failed to get config map %s, unable to remove watch", configMapKey.Name))
+		return client.IgnoreNotFound(err)
+	}
+
+	return removeWatch(ctx, k8sClient, configMap, common.WatchConfigMapAnnotation, common.WatchConfigMapLabel, instanceUID)
+}
+
+func removeWatch(ctx context.Context, k8sClient client.Client, object client.Object, watchAnnotation, watchLabel, instanceUID string) error {
+	annotations := object.GetAnnotations()
+	delete(annotations, watchAnnotation+instanceUID)
 	existInstanceAnnotation := false
-	for key := range secret.Annotations {
-		if strings.HasPrefix(key, common.WatchSecretAnnotation) {
+	for key := range annotations {
+		if strings.HasPrefix(key, watchAnnotation) {
 			existInstanceAnnotation = true
 			break
 		}
 	}
+	object.SetAnnotations(annotations)
 	if !existInstanceAnnotation {
-		delete(secret.Labels, common.WatchSecretLabel)
-		controllerutil.RemoveFinalizer(secret, common.FinalizerName)
+		labels := object.GetLabels()
+		delete(labels, watchLabel)
+		object.SetLabels(labels)
+		controllerutil.RemoveFinalizer(object, common.FinalizerName)
 	}
 
-	return k8sClient.Update(ctx, secret)
+	return k8sClient.Update(ctx, object)
 }
 
 func IsSecretWatched(secret client.Object) bool {
 	return secret.GetLabels() != nil && secret.GetLabels()[common.WatchSecretLabel] == "true"
 }
 
+func IsConfigMapWatched(configMap client.Object) bool {
+	return configMap.GetLabels() != nil && configMap.GetLabels()[common.WatchConfigMapLabel] == "true"
+}
+
 func GetLabelKeyForInstanceSecret(secretName string) string {
 	return common.InstanceSecretRefLabel + secretName
 }
 
+func GetLabelKeyForInstanceConfigMap(configMapName string) string {
+	return common.InstanceConfigMapRefLabel + configMapName
+}
+
 func HandleInstanceSharingError(ctx context.Context, k8sClient client.Client, object common.SAPBTPResource, status metav1.ConditionStatus, reason string, err error) (ctrl.Result, error) {
 	log := logutils.GetLogger(ctx)
 
@@ -277,8 +326,8 @@ func handleRateLimitError(ctx context.Context, sClient client.Client, resource c
 	retryAfterStr := smError.ResponseHeaders.Get("Retry-After")
 	if len(retryAfterStr) > 0 {
 		log.Info(fmt.Sprintf("SM returned 429 with Retry-After: %s, requeueing after it...", retryAfterStr))
-		if retryAfter, err := time.Parse(time.DateTime, retryAfterStr[:len(time.DateTime)]); err != nil { // format 2024-11-11 14:59:33 +0000 UTC
-			log.Error(err, "failed to parse Retry-After header, using default requeue time")
+		if retryAfter, ok := sm.ParseRetryAfter(retryAfterStr, time.Now()); !ok {
+			log.Error(fmt.Errorf("invalid Retry-After header value %q", retryAfterStr), "failed to parse Retry-After header, using default requeue time")
 		} else {
 			timeToRequeue := time.Until(retryAfter)
 			log.Info(fmt.Sprintf("requeueing after %d minutes, %d seconds", int(timeToRequeue.Minutes()), int(timeToRequeue.Seconds())%60))
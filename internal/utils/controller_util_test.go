@@ -2,6 +2,8 @@ package utils
 
 import (
 	"encoding/json"
+	"net/http"
+	"time"
 
 	"github.com/SAP/sap-btp-service-operator/api/common"
 	"github.com/SAP/sap-btp-service-operator/client/sm"
@@ -210,6 +212,21 @@ var _ = Describe("Controller Util", func() {
 			Expect(succeededCond.Status).To(Equal(metav1.ConditionFalse))
 			Expect(succeededCond.Reason).To(Equal(common.CreateInProgress))
 		})
+
+		It("should requeue after delta-seconds Retry-After", func() {
+			headers := map[string][]string{"Retry-After": {"30"}}
+			result, err := handleRateLimitError(ctx, k8sClient, resource, smclientTypes.CREATE, &sm.ServiceManagerError{ResponseHeaders: headers})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", 30*time.Second, time.Second))
+		})
+
+		It("should requeue after HTTP-date Retry-After", func() {
+			retryAfter := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+			headers := map[string][]string{"Retry-After": {retryAfter}}
+			result, err := handleRateLimitError(ctx, k8sClient, resource, smclientTypes.CREATE, &sm.ServiceManagerError{ResponseHeaders: headers})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Minute, 2*time.Second))
+		})
 	})
 
 	Context("TruncateStringToValidLabelValue", func() {
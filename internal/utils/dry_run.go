@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsDryRun reports whether the object is annotated to only preview the requests sent to Service Manager
+func IsDryRun(object client.Object) bool {
+	return strings.EqualFold(object.GetAnnotations()[common.DryRunAnnotation], "true")
+}
+
+// DiffField describes the change of a field that does not hold sensitive values, or returns an empty string if it did not change
+func DiffField(name string, applied, requested interface{}) string {
+	if reflect.DeepEqual(applied, requested) {
+		return ""
+	}
+	return fmt.Sprintf("%s: %v -> %v", name, quoteString(applied), quoteString(requested))
+}
+
+// DiffParameters returns the sorted changes between the applied and the requested parameters, one entry per changed value.
+// Values are redacted since parameters may hold credentials, only the dotted path and the kind of the change are reported.
+func DiffParameters(applied, requested []byte) ([]string, error) {
+	appliedParams, err := unmarshalParameters(applied)
+	if err != nil {
+		return nil, err
+	}
+	requestedParams, err := unmarshalParameters(requested)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	diffParameters(appliedParams, requestedParams, []string{"parameters"}, true, &changes)
+	sort.Strings(changes)
+	return changes, nil
+}
+
+// DiffRequestedParameters returns the sorted changes of the requested parameters like DiffParameters. Applied parameters
+// that are not requested, e.g. added or defaulted by the broker, are not reported.
+func DiffRequestedParameters(applied, requested []byte) ([]string, error) {
+	appliedParams, err := unmarshalParameters(applied)
+	if err != nil {
+		return nil, err
+	}
+	requestedParams, err := unmarshalParameters(requested)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	diffParameters(appliedParams, requestedParams, []string{"parameters"}, false, &changes)
+	sort.Strings(changes)
+	return changes, nil
+}
+
+func diffParameters(applied, requested map[string]interface{}, prefix []string, reportRemoved bool, changes *[]string) {
+	for key, requestedValue := range requested {
+		path := append(append([]string{}, prefix...), key)
+		appliedValue, found := applied[key]
+		if !found {
+			*changes = append(*changes, fmt.Sprintf("%s: added", strings.Join(path, ".")))
+			continue
+		}
+
+		appliedObject, appliedIsObject := appliedValue.(map[string]interface{})
+		requestedObject, requestedIsObject := requestedValue.(map[string]interface{})
+		if appliedIsObject && requestedIsObject {
+			diffParameters(appliedObject, requestedObject, path, reportRemoved, changes)
+		} else if !reflect.DeepEqual(appliedValue, requestedValue) {
+			*changes = append(*changes, fmt.Sprintf("%s: changed", strings.Join(path, ".")))
+		}
+	}
+
+	if !reportRemoved {
+		return
+	}
+	for key := range applied {
+		if _, found := requested[key]; !found {
+			path := append(append([]string{}, prefix...), key)
+			*changes = append(*changes, fmt.Sprintf("%s: removed", strings.Join(path, ".")))
+		}
+	}
+}
+
+func unmarshalParameters(parameters []byte) (map[string]interface{}, error) {
+	params := map[string]interface{}{}
+	if len(parameters) == 0 {
+		return params, nil
+	}
+	if err := json.Unmarshal(parameters, &params); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters: %w", err)
+	}
+	return params, nil
+}
+
+func quoteString(value interface{}) interface{} {
+	if str, ok := value.(string); ok {
+		return fmt.Sprintf("%q", str)
+	}
+	return value
+}
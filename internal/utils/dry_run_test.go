@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Dry run", func() {
+	Describe("IsDryRun", func() {
+		It("checks the dry run annotation", func() {
+			instance := &v1.ServiceInstance{}
+			Expect(IsDryRun(instance)).To(BeFalse())
+			instance.ObjectMeta = metav1.ObjectMeta{Annotations: map[string]string{common.DryRunAnnotation: "True"}}
+			Expect(IsDryRun(instance)).To(BeTrue())
+			instance.Annotations[common.DryRunAnnotation] = "false"
+			Expect(IsDryRun(instance)).To(BeFalse())
+		})
+	})
+
+	Describe("DiffField", func() {
+		It("describes changed fields", func() {
+			Expect(DiffField("name", "old", "new")).To(Equal(`name: "old" -> "new"`))
+			Expect(DiffField("shared", false, true)).To(Equal("shared: false -> true"))
+			Expect(DiffField("name", "same", "same")).To(BeEmpty())
+		})
+	})
+
+	Describe("DiffParameters", func() {
+		It("reports the changed paths without their values", func() {
+			changes, err := DiffParameters(
+				[]byte(`{"same":"value","changed":"old-secret","removed":1,"nested":{"changed":true,"same":[1]}}`),
+				[]byte(`{"same":"value","changed":"new-secret","added":{"key":"value"},"nested":{"changed":false,"same":[1]}}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(Equal([]string{
+				"parameters.added: added",
+				"parameters.changed: changed",
+				"parameters.nested.changed: changed",
+				"parameters.removed: removed",
+			}))
+			for _, change := range changes {
+				Expect(change).ToNot(ContainSubstring("secret"))
+			}
+		})
+
+		It("reports only the changes of the requested parameters", func() {
+			changes, err := DiffRequestedParameters(
+				[]byte(`{"same":"value","changed":"old","defaulted":1,"nested":{"changed":true,"defaulted":[1]}}`),
+				[]byte(`{"same":"value","changed":"new","added":true,"nested":{"changed":false}}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(Equal([]string{
+				"parameters.added: added",
+				"parameters.changed: changed",
+				"parameters.nested.changed: changed",
+			}))
+		})
+
+		It("treats missing parameters as an empty object", func() {
+			changes, err := DiffParameters(nil, []byte(`{"key":"value"}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(Equal([]string{"parameters.key: added"}))
+		})
+	})
+})
@@ -0,0 +1,355 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/internal/config"
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch
+
+const (
+	OrphansConfigMapName = "sap-btp-operator-orphans"
+
+	orphanKindInstance = "instance"
+	orphanKindBinding  = "binding"
+)
+
+// Orphan is an SM resource labeled with the cluster ID that no k8s resource manages
+type Orphan struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	K8sName   string `json:"k8sName,omitempty"`
+	// InstanceID is the instance of an orphaned binding
+	InstanceID string `json:"instanceID,omitempty"`
+	// Action is the action taken by the orphan policy, empty until the orphan was found in two consecutive checks
+	Action string `json:"action,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// OrphanReport lists the orphans found by a check
+type OrphanReport struct {
+	Instances []Orphan
+	Bindings  []Orphan
+}
+
+// OrphanCollector periodically lists the SM instances and bindings labeled with the cluster ID and looks for the ones no
+// k8s resource manages, left behind for example when a k8s resource was force deleted or the operator failed before
+// recording the ID of a resource it created. Orphans are reported in a config map in the release namespace and in metrics.
+// With the delete or adopt policy, orphans found in two consecutive checks are deleted from SM or adopted by new k8s
+// resources named after their labels, a resource that is still being created is not an orphan for long.
+type OrphanCollector struct {
+	Client      client.Client
+	GetSMClient func(ctx context.Context, instance *v1.ServiceInstance) (sm.Client, error)
+	Config      config.Config
+	Log         logr.Logger
+
+	suspects map[string]bool
+}
+
+func (c *OrphanCollector) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(c)
+}
+
+// NeedLeaderElection makes only the leader look for orphans, replicas would act on the same orphans
+func (c *OrphanCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs a check every orphan check interval until the context is done. The first check runs after one interval,
+// which gives the controllers time to recover the resources of the cluster.
+func (c *OrphanCollector) Start(ctx context.Context) error {
+	c.Log.Info(fmt.Sprintf("checking for orphaned resources every %s, policy: %s", c.Config.OrphanCheckInterval, c.Config.OrphanPolicy))
+	ticker := time.NewTicker(c.Config.OrphanCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := c.Check(ctx); err != nil {
+				c.Log.Error(err, "failed to check for orphaned resources")
+			}
+		}
+	}
+}
+
+// Check finds the orphans of every set of credentials in use, applies the orphan policy and reports the result
+func (c *OrphanCollector) Check(ctx context.Context) (*OrphanReport, error) {
+	owners, err := c.getOwners(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, errs := GetCredentialsInUse(ctx, c.Client, c.GetSMClient, c.Config.ReleaseNamespace)
+	for _, err := range errs {
+		c.Log.Error(err, "skipping credentials in the orphan check")
+	}
+
+	report := &OrphanReport{}
+	suspects := make(map[string]bool)
+	seen := make(map[string]bool)
+	clusterQuery := []string{fmt.Sprintf("%s eq '%s'", common.ClusterIDLabel, c.Config.ClusterID)}
+	for _, creds := range credentials {
+		// bindings first, SM doesn't delete instances that still have bindings
+		bindings, err := creds.Client.ListBindings(ctx, &sm.Parameters{LabelQuery: clusterQuery})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list bindings: %w", err)
+		}
+		if bindings != nil {
+			for i := range bindings.ServiceBindings {
+				smBinding := &bindings.ServiceBindings[i]
+				key := orphanKindBinding + "/" + smBinding.ID
+				if seen[key] || owners.ownsBinding(smBinding.ID, smBinding.Labels) {
+					continue
+				}
+				seen[key] = true
+				suspects[key] = true
+				orphan := newOrphan(smBinding.ID, smBinding.Name, smBinding.Labels)
+				orphan.InstanceID = smBinding.ServiceInstanceID
+				if c.suspects[key] {
+					c.apply(orphanKindBinding, &orphan, func() error {
+						return c.collectBinding(ctx, creds, orphan, owners)
+					})
+				}
+				report.Bindings = append(report.Bindings, orphan)
+			}
+		}
+
+		instances, err := creds.Client.ListInstances(ctx, &sm.Parameters{LabelQuery: clusterQuery})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list instances: %w", err)
+		}
+		if instances == nil {
+			continue
+		}
+		for i := range instances.ServiceInstances {
+			smInstance := &instances.ServiceInstances[i]
+			key := orphanKindInstance + "/" + smInstance.ID
+			if seen[key] || owners.ownsInstance(smInstance.ID, smInstance.Labels) {
+				continue
+			}
+			seen[key] = true
+			suspects[key] = true
+			orphan := newOrphan(smInstance.ID, smInstance.Name, smInstance.Labels)
+			if c.suspects[key] {
+				c.apply(orphanKindInstance, &orphan, func() error {
+					return c.collectInstance(ctx, creds, orphan, smInstance.ServicePlanID)
+				})
+			}
+			report.Instances = append(report.Instances, orphan)
+		}
+	}
+	c.suspects = suspects
+
+	metrics.Orphans.WithLabelValues(orphanKindInstance).Set(float64(len(report.Instances)))
+	metrics.Orphans.WithLabelValues(orphanKindBinding).Set(float64(len(report.Bindings)))
+	if len(report.Instances)+len(report.Bindings) > 0 {
+		c.Log.Info(fmt.Sprintf("found %d orphaned instances and %d orphaned bindings", len(report.Instances), len(report.Bindings)))
+	}
+	return report, c.updateReport(ctx, report)
+}
+
+// apply runs the action of the orphan policy and records its result in the orphan
+func (c *OrphanCollector) apply(kind string, orphan *Orphan, action func() error) {
+	if c.Config.OrphanPolicy != config.OrphanPolicyDelete && c.Config.OrphanPolicy != config.OrphanPolicyAdopt {
+		return
+	}
+	orphan.Action = c.Config.OrphanPolicy
+	result := "success"
+	if err := action(); err != nil {
+		c.Log.Error(err, fmt.Sprintf("failed to %s orphaned %s %s", orphan.Action, kind, orphan.ID))
+		orphan.Error = err.Error()
+		result = "failure"
+	} else {
+		c.Log.Info(fmt.Sprintf("orphaned %s %s (%s): %s", kind, orphan.ID, orphan.Name, orphan.Action))
+	}
+	metrics.OrphanActions.WithLabelValues(kind, orphan.Action, result).Inc()
+}
+
+func (c *OrphanCollector) collectBinding(ctx context.Context, creds SMCredentials, orphan Orphan, owners *resourceOwners) error {
+	if c.Config.OrphanPolicy == config.OrphanPolicyDelete {
+		_, err := creds.Client.Unbind(ctx, orphan.ID, nil, "")
+		return err
+	}
+
+	if len(orphan.Namespace) == 0 || len(orphan.K8sName) == 0 {
+		return fmt.Errorf("binding has no namespace and name labels")
+	}
+	instance, ok := owners.instancesByID[orphan.InstanceID]
+	if !ok {
+		return fmt.Errorf("instance %s of the binding is not managed by a k8s instance", orphan.InstanceID)
+	}
+	binding := &v1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: orphan.K8sName, Namespace: orphan.Namespace},
+		Spec: v1.ServiceBindingSpec{
+			ServiceInstanceName: instance.Name,
+			ExternalName:        orphan.Name,
+		},
+	}
+	if instance.Namespace != orphan.Namespace {
+		binding.Spec.ServiceInstanceNamespace = instance.Namespace
+	}
+	// the binding controller recovers the SM binding by its labels
+	return c.Client.Create(ctx, binding)
+}
+
+func (c *OrphanCollector) collectInstance(ctx context.Context, creds SMCredentials, orphan Orphan, planID string) error {
+	if c.Config.OrphanPolicy == config.OrphanPolicyDelete {
+		_, err := creds.Client.Deprovision(ctx, orphan.ID, nil, "")
+		return err
+	}
+
+	if len(orphan.Namespace) == 0 || len(orphan.K8sName) == 0 {
+		return fmt.Errorf("instance has no namespace and name labels")
+	}
+	plan, offering, err := GetPlanWithOffering(ctx, creds.Client, planID)
+	if err != nil {
+		return err
+	}
+	if plan == nil || offering == nil {
+		return fmt.Errorf("plan %s of the instance was not found", planID)
+	}
+	instance := &v1.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: orphan.K8sName, Namespace: orphan.Namespace},
+		Spec: v1.ServiceInstanceSpec{
+			ServiceOfferingName:        offering.CatalogName,
+			ServicePlanName:            plan.CatalogName,
+			ServicePlanID:              plan.ID,
+			ExternalName:               orphan.Name,
+			InstanceID:                 orphan.ID,
+			BTPAccessCredentialsSecret: creds.BTPAccessCredentialsSecret,
+		},
+	}
+	// the instance controller adopts the SM instance by its ID
+	return c.Client.Create(ctx, instance)
+}
+
+// updateReport writes the orphans of the last check to the orphans config map
+func (c *OrphanCollector) updateReport(ctx context.Context, report *OrphanReport) error {
+	instances, err := json.Marshal(orphanList(report.Instances))
+	if err != nil {
+		return err
+	}
+	bindings, err := json.Marshal(orphanList(report.Bindings))
+	if err != nil {
+		return err
+	}
+	data := map[string]string{
+		"lastCheckTime": time.Now().UTC().Format(time.RFC3339),
+		"instances":     string(instances),
+		"bindings":      string(bindings),
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err = c.Client.Get(ctx, types.NamespacedName{Namespace: c.Config.ReleaseNamespace, Name: OrphansConfigMapName}, configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      OrphansConfigMapName,
+				Namespace: c.Config.ReleaseNamespace,
+				Labels:    map[string]string{common.ManagedByBTPOperatorLabel: "true"},
+			},
+			Data: data,
+		}
+		return c.Client.Create(ctx, configMap)
+	}
+	if err != nil {
+		return err
+	}
+	configMap.Data = data
+	return c.Client.Update(ctx, configMap)
+}
+
+// resourceOwners indexes the k8s resources that manage SM resources by the ID in their status and by namespace and name
+type resourceOwners struct {
+	instancesByID map[string]*v1.ServiceInstance
+	instanceNames map[string]bool
+	bindingIDs    map[string]bool
+	bindingNames  map[string]bool
+}
+
+func (c *OrphanCollector) getOwners(ctx context.Context) (*resourceOwners, error) {
+	owners := &resourceOwners{
+		instancesByID: make(map[string]*v1.ServiceInstance),
+		instanceNames: make(map[string]bool),
+		bindingIDs:    make(map[string]bool),
+		bindingNames:  make(map[string]bool),
+	}
+	instances := &v1.ServiceInstanceList{}
+	if err := c.Client.List(ctx, instances); err != nil {
+		return nil, err
+	}
+	for i := range instances.Items {
+		instance := &instances.Items[i]
+		if len(instance.Status.InstanceID) > 0 {
+			owners.instancesByID[instance.Status.InstanceID] = instance
+		}
+		owners.instanceNames[instance.Namespace+"/"+instance.Name] = true
+	}
+
+	bindings := &v1.ServiceBindingList{}
+	if err := c.Client.List(ctx, bindings); err != nil {
+		return nil, err
+	}
+	for _, binding := range bindings.Items {
+		if len(binding.Status.BindingID) > 0 {
+			owners.bindingIDs[binding.Status.BindingID] = true
+		}
+		owners.bindingNames[binding.Namespace+"/"+binding.Name] = true
+	}
+	return owners, nil
+}
+
+// ownsInstance reports whether a k8s instance manages the SM instance, or will recover it by its labels
+func (o *resourceOwners) ownsInstance(id string, labels map[string][]string) bool {
+	_, ok := o.instancesByID[id]
+	return ok || o.instanceNames[labeledName(labels)]
+}
+
+// ownsBinding reports whether a k8s binding manages the SM binding, or will recover it by its labels
+func (o *resourceOwners) ownsBinding(id string, labels map[string][]string) bool {
+	return o.bindingIDs[id] || o.bindingNames[labeledName(labels)]
+}
+
+func labeledName(labels map[string][]string) string {
+	namespaces, names := labels[common.NamespaceLabel], labels[common.K8sNameLabel]
+	if len(namespaces) == 0 || len(names) == 0 {
+		return ""
+	}
+	return namespaces[0] + "/" + names[0]
+}
+
+func newOrphan(id, name string, labels map[string][]string) Orphan {
+	orphan := Orphan{ID: id, Name: name}
+	if namespaces := labels[common.NamespaceLabel]; len(namespaces) > 0 {
+		orphan.Namespace = namespaces[0]
+	}
+	if names := labels[common.K8sNameLabel]; len(names) > 0 {
+		orphan.K8sName = names[0]
+	}
+	return orphan
+}
+
+// orphanList keeps an empty list as [] in the report rather than null
+func orphanList(orphans []Orphan) []Orphan {
+	if orphans == nil {
+		return []Orphan{}
+	}
+	return orphans
+}
@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/client/sm/smfakes"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"github.com/SAP/sap-btp-service-operator/internal/config"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Orphan collector", func() {
+	var (
+		collector *OrphanCollector
+		smClient  *smfakes.FakeClient
+	)
+
+	smLabels := func(namespace, name string) smClientTypes.Labels {
+		return OwnerLabels(namespace, name, "cluster-id")
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1.AddToScheme(scheme)).To(Succeed())
+		instance := &v1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: testNamespace}}
+		instance.Status.InstanceID = "instance-id"
+		binding := &v1.ServiceBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: testNamespace}}
+
+		smClient = &smfakes.FakeClient{}
+		smClient.ListInstancesReturns(&smClientTypes.ServiceInstances{ServiceInstances: []smClientTypes.ServiceInstance{
+			{ID: "instance-id", Name: "renamed", Labels: smLabels(testNamespace, "other")},
+			{ID: "orphan-instance-id", Name: "orphan-instance", ServicePlanID: "plan-id", Labels: smLabels(testNamespace, "deleted-instance")},
+		}}, nil)
+		smClient.ListBindingsReturns(&smClientTypes.ServiceBindings{ServiceBindings: []smClientTypes.ServiceBinding{
+			{ID: "binding-id", Labels: smLabels(testNamespace, "binding")},
+			{ID: "orphan-binding-id", Name: "orphan-binding", ServiceInstanceID: "instance-id", Labels: smLabels(testNamespace, "deleted-binding")},
+		}}, nil)
+		smClient.ListPlansReturns(&smClientTypes.ServicePlans{ServicePlans: []smClientTypes.ServicePlan{{ID: "plan-id", Name: "Plan", CatalogName: "plan", ServiceOfferingID: "offering-id"}}}, nil)
+		smClient.ListOfferingsReturns(&smClientTypes.ServiceOfferings{ServiceOfferings: []smClientTypes.ServiceOffering{{ID: "offering-id", Name: "Offering", CatalogName: "offering"}}}, nil)
+
+		collector = &OrphanCollector{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance, binding).Build(),
+			GetSMClient: func(_ context.Context, _ *v1.ServiceInstance) (sm.Client, error) {
+				return smClient, nil
+			},
+			Config: config.Config{ClusterID: "cluster-id", ReleaseNamespace: "release-namespace", OrphanPolicy: config.OrphanPolicyReport},
+			Log:    logr.Discard(),
+		}
+	})
+
+	It("should report the SM resources no k8s resource manages", func() {
+		report, err := collector.Check(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Instances).To(ConsistOf(Orphan{ID: "orphan-instance-id", Name: "orphan-instance", Namespace: testNamespace, K8sName: "deleted-instance"}))
+		Expect(report.Bindings).To(ConsistOf(Orphan{ID: "orphan-binding-id", Name: "orphan-binding", Namespace: testNamespace, K8sName: "deleted-binding", InstanceID: "instance-id"}))
+
+		_, params := smClient.ListInstancesArgsForCall(0)
+		Expect(params.LabelQuery).To(ConsistOf("_clusterid eq 'cluster-id'"))
+
+		configMap := &corev1.ConfigMap{}
+		Expect(collector.Client.Get(context.Background(), types.NamespacedName{Name: OrphansConfigMapName, Namespace: "release-namespace"}, configMap)).To(Succeed())
+		Expect(configMap.Labels).To(HaveKeyWithValue(common.ManagedByBTPOperatorLabel, "true"))
+		var instances []Orphan
+		Expect(json.Unmarshal([]byte(configMap.Data["instances"]), &instances)).To(Succeed())
+		Expect(instances).To(Equal(report.Instances))
+		Expect(configMap.Data).To(HaveKey("lastCheckTime"))
+	})
+
+	When("the policy is delete", func() {
+		BeforeEach(func() {
+			collector.Config.OrphanPolicy = config.OrphanPolicyDelete
+		})
+
+		It("should delete orphans found in two consecutive checks", func() {
+			_, err := collector.Check(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(smClient.UnbindCallCount()).To(Equal(0))
+			Expect(smClient.DeprovisionCallCount()).To(Equal(0))
+
+			report, err := collector.Check(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(smClient.UnbindCallCount()).To(Equal(1))
+			_, bindingID, _, _ := smClient.UnbindArgsForCall(0)
+			Expect(bindingID).To(Equal("orphan-binding-id"))
+			Expect(smClient.DeprovisionCallCount()).To(Equal(1))
+			_, instanceID, _, _ := smClient.DeprovisionArgsForCall(0)
+			Expect(instanceID).To(Equal("orphan-instance-id"))
+			Expect(report.Instances[0].Action).To(Equal(config.OrphanPolicyDelete))
+		})
+	})
+
+	When("the policy is adopt", func() {
+		BeforeEach(func() {
+			collector.Config.OrphanPolicy = config.OrphanPolicyAdopt
+		})
+
+		It("should create k8s resources for orphans found in two consecutive checks", func() {
+			for i := 0; i < 2; i++ {
+				_, err := collector.Check(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			instance := &v1.ServiceInstance{}
+			Expect(collector.Client.Get(context.Background(), types.NamespacedName{Name: "deleted-instance", Namespace: testNamespace}, instance)).To(Succeed())
+			Expect(instance.Spec.InstanceID).To(Equal("orphan-instance-id"))
+			Expect(instance.Spec.ServiceOfferingName).To(Equal("offering"))
+			Expect(instance.Spec.ServicePlanName).To(Equal("plan"))
+			Expect(instance.Spec.ExternalName).To(Equal("orphan-instance"))
+
+			binding := &v1.ServiceBinding{}
+			Expect(collector.Client.Get(context.Background(), types.NamespacedName{Name: "deleted-binding", Namespace: testNamespace}, binding)).To(Succeed())
+			Expect(binding.Spec.ServiceInstanceName).To(Equal("instance"))
+			Expect(binding.Spec.ServiceInstanceNamespace).To(BeEmpty())
+			Expect(binding.Spec.ExternalName).To(Equal("orphan-binding"))
+		})
+	})
+})
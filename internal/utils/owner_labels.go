@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+)
+
+// OwnerClusterID returns the ID of the cluster that manages the SM resource, an empty ID means no cluster manages it.
+// The _clusterid label is used rather than the context of the resource, which keeps the creating cluster when the resource is retained.
+func OwnerClusterID(labels smClientTypes.Labels) string {
+	if len(labels[common.ClusterIDLabel]) > 0 {
+		return labels[common.ClusterIDLabel][0]
+	}
+	return ""
+}
+
+// OwnerLabels returns the labels that identify the k8s resource managing an SM resource
+func OwnerLabels(namespace, name, clusterID string) smClientTypes.Labels {
+	return smClientTypes.Labels{
+		common.NamespaceLabel: []string{namespace},
+		common.K8sNameLabel:   []string{name},
+		common.ClusterIDLabel: []string{clusterID},
+	}
+}
+
+// LabelChanges returns the changes that set the desired values of the labels, sorted by key.
+// Removals and additions are returned separately since SM rejects removing and adding the same key in one request.
+func LabelChanges(current, desired smClientTypes.Labels) (removals, additions []*smClientTypes.LabelChange) {
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		values, exists := current[key]
+		if exists && reflect.DeepEqual(values, desired[key]) {
+			continue
+		}
+		if exists {
+			removals = append(removals, &smClientTypes.LabelChange{Operation: smClientTypes.RemoveLabelOperation, Key: key})
+		}
+		additions = append(additions, &smClientTypes.LabelChange{Operation: smClientTypes.AddLabelOperation, Key: key, Values: desired[key]})
+	}
+	return removals, additions
+}
+
+// ApplyLabelChanges sets the desired values of the labels with update, the old values are removed first since SM can't
+// replace a label in one request
+func ApplyLabelChanges(current, desired smClientTypes.Labels, update func(changes []*smClientTypes.LabelChange) error) error {
+	removals, additions := LabelChanges(current, desired)
+	for _, changes := range [][]*smClientTypes.LabelChange{removals, additions} {
+		if len(changes) == 0 {
+			continue
+		}
+		if err := update(changes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OwnerLabelRemovals returns the changes that remove the labels identifying the k8s resource that manages an SM resource
+func OwnerLabelRemovals(labels smClientTypes.Labels) []*smClientTypes.LabelChange {
+	var removals []*smClientTypes.LabelChange
+	for _, key := range []string{common.ClusterIDLabel, common.K8sNameLabel, common.NamespaceLabel} {
+		if _, exists := labels[key]; exists {
+			removals = append(removals, &smClientTypes.LabelChange{Operation: smClientTypes.RemoveLabelOperation, Key: key})
+		}
+	}
+	return removals
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"github.com/SAP/sap-btp-service-operator/api/common"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Owner labels", func() {
+	Describe("OwnerClusterID", func() {
+		It("returns the cluster id label", func() {
+			Expect(OwnerClusterID(smClientTypes.Labels{common.ClusterIDLabel: []string{"cluster"}})).To(Equal("cluster"))
+		})
+
+		It("returns an empty id for resources not managed by a cluster", func() {
+			Expect(OwnerClusterID(smClientTypes.Labels{"subaccount_id": []string{"subaccount"}})).To(BeEmpty())
+			Expect(OwnerClusterID(nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("LabelChanges", func() {
+		It("returns the removals and additions that set the desired labels", func() {
+			current := smClientTypes.Labels{
+				common.NamespaceLabel: []string{"default"},
+				common.K8sNameLabel:   []string{"old-name"},
+				"subaccount_id":       []string{"subaccount"},
+			}
+			removals, additions := LabelChanges(current, OwnerLabels("default", "name", "cluster"))
+			Expect(removals).To(Equal([]*smClientTypes.LabelChange{
+				{Operation: smClientTypes.RemoveLabelOperation, Key: common.K8sNameLabel},
+			}))
+			Expect(additions).To(Equal([]*smClientTypes.LabelChange{
+				{Operation: smClientTypes.AddLabelOperation, Key: common.ClusterIDLabel, Values: []string{"cluster"}},
+				{Operation: smClientTypes.AddLabelOperation, Key: common.K8sNameLabel, Values: []string{"name"}},
+			}))
+		})
+
+		It("returns no changes when the labels are set", func() {
+			removals, additions := LabelChanges(OwnerLabels("default", "name", "cluster"), OwnerLabels("default", "name", "cluster"))
+			Expect(removals).To(BeEmpty())
+			Expect(additions).To(BeEmpty())
+		})
+	})
+
+	Describe("OwnerLabelRemovals", func() {
+		It("removes the owner labels that are set", func() {
+			labels := smClientTypes.Labels{
+				common.ClusterIDLabel: []string{"cluster"},
+				common.K8sNameLabel:   []string{"name"},
+				"subaccount_id":       []string{"subaccount"},
+			}
+			Expect(OwnerLabelRemovals(labels)).To(Equal([]*smClientTypes.LabelChange{
+				{Operation: smClientTypes.RemoveLabelOperation, Key: common.ClusterIDLabel},
+				{Operation: smClientTypes.RemoveLabelOperation, Key: common.K8sNameLabel},
+			}))
+			Expect(OwnerLabelRemovals(nil)).To(BeEmpty())
+		})
+	})
+})
@@ -4,56 +4,62 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	servicesv1 "github.com/SAP/sap-btp-service-operator/api/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 )
 
+// ParametersSources holds the secrets and config maps the parameters were read from, keyed by UID
+type ParametersSources struct {
+	Secrets    map[string]*corev1.Secret
+	ConfigMaps map[string]*corev1.ConfigMap
+}
+
 // BuildSMRequestParameters buildParameters generates the parameters JSON structure to be passed
 // to the broker.
-// The first return value is a map of parameters to send to the Broker, including
-// secret values.
-// The second return value is parameters marshalled to byt array
+// The inline parameters are used as the base document, the parametersFrom sources are then merged into it
+// in order, each at its path and according to its merge strategy.
+// The first return value is parameters marshalled to byte array, including
+// secret and config map values.
+// The second return value holds the secrets and config maps the parameters were read from.
 // The third return value is any error that caused the function to fail.
-func BuildSMRequestParameters(namespace string, parameters *runtime.RawExtension, parametersFrom []servicesv1.ParametersFromSource) ([]byte, map[string]*corev1.Secret, error) {
+func BuildSMRequestParameters(ctx context.Context, namespace string, parameters *runtime.RawExtension, parametersFrom []servicesv1.ParametersFromSource) ([]byte, *ParametersSources, error) {
 	params := make(map[string]interface{})
-	secretsSet := map[string]*corev1.Secret{}
-	if len(parametersFrom) > 0 {
-		for _, p := range parametersFrom {
-			fps, secret, err := fetchParametersFromSource(namespace, &p)
-			if err != nil {
-				return nil, nil, err
-			}
-			if secret != nil {
-				secretsSet[string(secret.UID)] = secret
-				for k, v := range fps {
-					// we don't want to add shared param because sm api does not support updating
-					// shared param with other params, for sharing we have different function.
-					if k == "shared" {
-						continue
-					}
-					if _, ok := params[k]; ok {
-						return nil, nil, fmt.Errorf("conflict: duplicate entry for parameter %q", k)
-					}
-					params[k] = v
-				}
-			}
-		}
+	sources := &ParametersSources{
+		Secrets:    map[string]*corev1.Secret{},
+		ConfigMaps: map[string]*corev1.ConfigMap{},
 	}
 	if parameters != nil {
 		pp, err := UnmarshalRawParameters(parameters.Raw)
 		if err != nil {
 			return nil, nil, err
 		}
-		for k, v := range pp {
-			if _, ok := params[k]; ok {
-				return nil, nil, fmt.Errorf("conflict: duplicate entry for parameter %q", k)
-			}
-			params[k] = v
+		params = pp
+	}
+	for _, p := range parametersFrom {
+		fps, source, err := fetchParametersFromSource(ctx, namespace, &p)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch s := source.(type) {
+		case *corev1.Secret:
+			sources.Secrets[string(s.UID)] = s
+		case *corev1.ConfigMap:
+			sources.ConfigMaps[string(s.UID)] = s
+		}
+		if len(p.Path) == 0 {
+			// we don't want to add shared param because sm api does not support updating
+			// shared param with other params, for sharing we have different function.
+			delete(fps, "shared")
+		}
+		if err := mergeParametersAtPath(params, fps, p.Path, p.MergeStrategy); err != nil {
+			return nil, nil, err
 		}
 	}
 	// Replace empty map with nil so that the params are omitted from the request
@@ -65,7 +71,58 @@ func BuildSMRequestParameters(namespace string, parameters *runtime.RawExtension
 	if err != nil {
 		return nil, nil, err
 	}
-	return parametersRaw, secretsSet, nil
+	return parametersRaw, sources, nil
+}
+
+// mergeParametersAtPath merges the source parameters into the object found at the given dot separated path of params,
+// creating missing objects along the path
+func mergeParametersAtPath(params, source map[string]interface{}, path string, strategy servicesv1.MergeStrategy) error {
+	target := params
+	var prefix []string
+	if len(path) > 0 {
+		for _, key := range strings.Split(path, ".") {
+			prefix = append(prefix, key)
+			value, ok := target[key]
+			if !ok {
+				value = make(map[string]interface{})
+				target[key] = value
+			}
+			nested, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("conflict: parameter %q is not an object, unable to place parameters under it", strings.Join(prefix, "."))
+			}
+			target = nested
+		}
+	}
+	return mergeParameters(target, source, prefix, strategy)
+}
+
+// mergeParameters merges source into target according to the merge strategy
+func mergeParameters(target, source map[string]interface{}, prefix []string, strategy servicesv1.MergeStrategy) error {
+	for k, v := range source {
+		existing, ok := target[k]
+		if !ok {
+			target[k] = v
+			continue
+		}
+		switch strategy {
+		case servicesv1.MergeStrategyLastWins:
+			target[k] = v
+		case servicesv1.MergeStrategyDeepMerge:
+			existingMap, existingIsMap := existing.(map[string]interface{})
+			sourceMap, sourceIsMap := v.(map[string]interface{})
+			if !existingIsMap || !sourceIsMap {
+				target[k] = v
+				continue
+			}
+			if err := mergeParameters(existingMap, sourceMap, append(prefix, k), strategy); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("conflict: duplicate entry for parameter %q", strings.Join(append(prefix, k), "."))
+		}
+	}
+	return nil
 }
 
 // UnmarshalRawParameters produces a map structure from a given raw YAML/JSON input
@@ -96,36 +153,107 @@ func unmarshalJSON(in []byte) (map[string]interface{}, error) {
 	return parameters, nil
 }
 
-// fetchSecretKeyValue requests and returns the contents of the given secret key
-func fetchSecretKeyValue(namespace string, secretKeyRef *servicesv1.SecretKeyReference) ([]byte, *corev1.Secret, error) {
+// fetchSecret requests and returns the given secret, failing if it is marked for deletion
+func fetchSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
 	secret := &corev1.Secret{}
-	err := GetSecretWithFallback(context.Background(), types.NamespacedName{Namespace: namespace, Name: secretKeyRef.Name}, secret)
+	if err := GetSecretWithFallback(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, err
+	}
+	if secret.DeletionTimestamp != nil {
+		return nil, fmt.Errorf("secret %s is marked for deletion", secret.Name)
+	}
+	return secret, nil
+}
 
-	if err != nil {
-		return nil, nil, err
+// fetchConfigMap requests and returns the given config map, failing if it is marked for deletion
+func fetchConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := GetConfigMapWithFallback(ctx, types.NamespacedName{Namespace: namespace, Name: name}, configMap); err != nil {
+		return nil, err
+	}
+	if configMap.DeletionTimestamp != nil {
+		return nil, fmt.Errorf("config map %s is marked for deletion", configMap.Name)
 	}
+	return configMap, nil
+}
 
-	return secret.Data[secretKeyRef.Key], secret, nil
+// configMapValue returns the value of the given config map key, looking at both Data and BinaryData
+func configMapValue(configMap *corev1.ConfigMap, key string) []byte {
+	if value, ok := configMap.Data[key]; ok {
+		return []byte(value)
+	}
+	return configMap.BinaryData[key]
 }
 
 // fetchParametersFromSource fetches data from a specified external source and
-// represents it in the parameters map format
-func fetchParametersFromSource(namespace string, parametersFrom *servicesv1.ParametersFromSource) (map[string]interface{}, *corev1.Secret, error) {
-	var params map[string]interface{}
-	if parametersFrom.SecretKeyRef != nil {
-		data, secret, err := fetchSecretKeyValue(namespace, parametersFrom.SecretKeyRef)
+// represents it in the parameters map format.
+// The returned object is the secret or config map the parameters were read from.
+func fetchParametersFromSource(ctx context.Context, namespace string, parametersFrom *servicesv1.ParametersFromSource) (map[string]interface{}, client.Object, error) {
+	if count := countParametersFromSources(parametersFrom); count > 1 {
+		return nil, nil, fmt.Errorf("only one of secretKeyRef, configMapKeyRef, secretRef or configMapRef can be set in parametersFrom, found %d", count)
+	}
+
+	switch {
+	case parametersFrom.SecretKeyRef != nil:
+		secret, err := fetchSecret(ctx, namespace, parametersFrom.SecretKeyRef.Name)
 		if err != nil {
 			return nil, nil, err
 		}
-		if secret.DeletionTimestamp != nil {
-			return nil, nil, fmt.Errorf("secret %s is marked for deletion", secret.Name)
+		params, err := unmarshalJSON(secret.Data[parametersFrom.SecretKeyRef.Key])
+		if err != nil {
+			return nil, nil, err
+		}
+		return params, secret, nil
+	case parametersFrom.ConfigMapKeyRef != nil:
+		configMap, err := fetchConfigMap(ctx, namespace, parametersFrom.ConfigMapKeyRef.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		params, err := unmarshalJSON(configMapValue(configMap, parametersFrom.ConfigMapKeyRef.Key))
+		if err != nil {
+			return nil, nil, err
 		}
-		p, err := unmarshalJSON(data)
+		return params, configMap, nil
+	case parametersFrom.SecretRef != nil:
+		secret, err := fetchSecret(ctx, namespace, parametersFrom.SecretRef.Name)
 		if err != nil {
 			return nil, nil, err
 		}
-		params = p
+		params := make(map[string]interface{}, len(secret.Data))
+		for k, v := range secret.Data {
+			params[k] = string(v)
+		}
 		return params, secret, nil
+	case parametersFrom.ConfigMapRef != nil:
+		configMap, err := fetchConfigMap(ctx, namespace, parametersFrom.ConfigMapRef.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		params := make(map[string]interface{}, len(configMap.Data)+len(configMap.BinaryData))
+		for k, v := range configMap.Data {
+			params[k] = v
+		}
+		for k, v := range configMap.BinaryData {
+			params[k] = string(v)
+		}
+		return params, configMap, nil
+	}
+	return nil, nil, nil
+}
+
+func countParametersFromSources(parametersFrom *servicesv1.ParametersFromSource) int {
+	count := 0
+	if parametersFrom.SecretKeyRef != nil {
+		count++
+	}
+	if parametersFrom.ConfigMapKeyRef != nil {
+		count++
+	}
+	if parametersFrom.SecretRef != nil {
+		count++
+	}
+	if parametersFrom.ConfigMapRef != nil {
+		count++
 	}
-	return params, nil, nil
+	return count
 }
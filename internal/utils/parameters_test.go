@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"context"
+
 	"github.com/SAP/sap-btp-service-operator/internal/config"
 
 	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
@@ -18,11 +20,11 @@ var _ = Describe("Parameters", func() {
 			var parametersFrom []v1.ParametersFromSource
 			parameters := (*runtime.RawExtension)(nil)
 
-			rawParam, secrets, err := BuildSMRequestParameters("", parameters, parametersFrom)
+			rawParam, sources, err := BuildSMRequestParameters(context.TODO(), "", parameters, parametersFrom)
 
 			Expect(err).To(BeNil())
 			Expect(rawParam).To(BeNil())
-			Expect(len(secrets)).To(BeZero())
+			Expect(len(sources.Secrets)).To(BeZero())
 		})
 		It("handles parameters from source", func() {
 			var parametersFrom []v1.ParametersFromSource
@@ -30,11 +32,11 @@ var _ = Describe("Parameters", func() {
 				Raw: []byte(`{"key":"value"}`),
 			}
 
-			rawParam, secrets, err := BuildSMRequestParameters("", parameters, parametersFrom)
+			rawParam, sources, err := BuildSMRequestParameters(context.TODO(), "", parameters, parametersFrom)
 
 			Expect(err).To(BeNil())
 			Expect(rawParam).To(Equal([]byte(`{"key":"value"}`)))
-			Expect(len(secrets)).To(BeZero())
+			Expect(len(sources.Secrets)).To(BeZero())
 		})
 		It("handles parameters from source with secrets", func() {
 			// Setup
@@ -69,7 +71,7 @@ var _ = Describe("Parameters", func() {
 			})
 
 			// Test
-			parametersRaw, secretsSet, err := BuildSMRequestParameters(namespace, parameters, parametersFrom)
+			parametersRaw, sources, err := BuildSMRequestParameters(context.TODO(), namespace, parameters, parametersFrom)
 
 			// Assertions
 			Expect(err).To(BeNil())
@@ -80,8 +82,133 @@ var _ = Describe("Parameters", func() {
 			rawParameters, err := MarshalRawParameters(expectedParams)
 			Expect(err).To(BeNil())
 			Expect(parametersRaw).To(Equal(rawParameters))
-			Expect(len(secretsSet)).To(Equal(1))
-			Expect(secretsSet[string(secret.UID)]).To(Equal(secret))
+			Expect(len(sources.Secrets)).To(Equal(1))
+			Expect(sources.Secrets[string(secret.UID)]).To(Equal(secret))
+		})
+
+		When("parameters are taken from config maps and whole secrets", func() {
+			namespace := "test-namespace"
+			var configMap *corev1.ConfigMap
+			var secret *corev1.Secret
+
+			BeforeEach(func() {
+				configMap = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "param-configmap", Namespace: namespace, UID: "configmap-uid"},
+					Data: map[string]string{
+						"json-parameter": `{"param1":"value1"}`,
+						"param2":         "value2",
+					},
+				}
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "param-secret", Namespace: namespace, UID: "secret-uid"},
+					Data:       map[string][]byte{"param3": []byte("value3")},
+				}
+				k8sClient := fake.NewClientBuilder().WithObjects(configMap, secret).Build()
+				InitializeSecretsClient(k8sClient, k8sClient, config.Config{})
+			})
+
+			It("reads a json object from a config map key", func() {
+				parametersFrom := []v1.ParametersFromSource{
+					{ConfigMapKeyRef: &v1.ConfigMapKeyReference{Name: "param-configmap", Key: "json-parameter"}},
+				}
+				parametersRaw, sources, err := BuildSMRequestParameters(context.TODO(), namespace, nil, parametersFrom)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(parametersRaw).To(MatchJSON(`{"param1":"value1"}`))
+				Expect(sources.ConfigMaps).To(HaveKey("configmap-uid"))
+				Expect(sources.Secrets).To(BeEmpty())
+			})
+
+			It("uses every key of whole secrets and config maps as parameters", func() {
+				parametersFrom := []v1.ParametersFromSource{
+					{ConfigMapRef: &v1.ConfigMapReference{Name: "param-configmap"}},
+					{SecretRef: &v1.SecretReference{Name: "param-secret"}},
+				}
+				parametersRaw, sources, err := BuildSMRequestParameters(context.TODO(), namespace, nil, parametersFrom)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(parametersRaw).To(MatchJSON(`{"json-parameter":"{\"param1\":\"value1\"}","param2":"value2","param3":"value3"}`))
+				Expect(sources.ConfigMaps).To(HaveKey("configmap-uid"))
+				Expect(sources.Secrets).To(HaveKey("secret-uid"))
+			})
+
+			It("fails when the config map key is not a json object", func() {
+				parametersFrom := []v1.ParametersFromSource{
+					{ConfigMapKeyRef: &v1.ConfigMapKeyReference{Name: "param-configmap", Key: "param2"}},
+				}
+				_, _, err := BuildSMRequestParameters(context.TODO(), namespace, nil, parametersFrom)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("fails when more than one source is set", func() {
+				parametersFrom := []v1.ParametersFromSource{
+					{
+						SecretRef:    &v1.SecretReference{Name: "param-secret"},
+						ConfigMapRef: &v1.ConfigMapReference{Name: "param-configmap"},
+					},
+				}
+				_, _, err := BuildSMRequestParameters(context.TODO(), namespace, nil, parametersFrom)
+				Expect(err).To(MatchError(ContainSubstring("only one of")))
+			})
+
+			It("places parameters under the given path", func() {
+				parametersFrom := []v1.ParametersFromSource{
+					{ConfigMapKeyRef: &v1.ConfigMapKeyReference{Name: "param-configmap", Key: "json-parameter"}, Path: "oauth2-configuration.credentials"},
+				}
+				parameters := &runtime.RawExtension{Raw: []byte(`{"oauth2-configuration":{"redirect-uris":["https://uri"]}}`)}
+				parametersRaw, _, err := BuildSMRequestParameters(context.TODO(), namespace, parameters, parametersFrom)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(parametersRaw).To(MatchJSON(`{"oauth2-configuration":{"redirect-uris":["https://uri"],"credentials":{"param1":"value1"}}}`))
+			})
+
+			It("fails when the path goes through a non object parameter", func() {
+				parametersFrom := []v1.ParametersFromSource{
+					{SecretRef: &v1.SecretReference{Name: "param-secret"}, Path: "oauth2-configuration.credentials"},
+				}
+				parameters := &runtime.RawExtension{Raw: []byte(`{"oauth2-configuration":"value"}`)}
+				_, _, err := BuildSMRequestParameters(context.TODO(), namespace, parameters, parametersFrom)
+				Expect(err).To(MatchError(ContainSubstring(`parameter "oauth2-configuration" is not an object`)))
+			})
+
+			It("fails on nested duplicate parameters with the error strategy", func() {
+				parametersFrom := []v1.ParametersFromSource{
+					{SecretRef: &v1.SecretReference{Name: "param-secret"}, Path: "config"},
+				}
+				parameters := &runtime.RawExtension{Raw: []byte(`{"config":{"param3":"inline"}}`)}
+				_, _, err := BuildSMRequestParameters(context.TODO(), namespace, parameters, parametersFrom)
+				Expect(err).To(MatchError(ContainSubstring(`duplicate entry for parameter "config.param3"`)))
+			})
+
+			It("deep merges objects with the deep merge strategy", func() {
+				configMap.Data["json-parameter"] = `{"config":{"nested":{"b":"source"},"c":"source"}}`
+				Expect(secretsClient.Client.Update(context.TODO(), configMap)).To(Succeed())
+				parametersFrom := []v1.ParametersFromSource{
+					{ConfigMapKeyRef: &v1.ConfigMapKeyReference{Name: "param-configmap", Key: "json-parameter"}, MergeStrategy: v1.MergeStrategyDeepMerge},
+				}
+				parameters := &runtime.RawExtension{Raw: []byte(`{"config":{"nested":{"a":"inline"},"c":"inline"}}`)}
+				parametersRaw, _, err := BuildSMRequestParameters(context.TODO(), namespace, parameters, parametersFrom)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(parametersRaw).To(MatchJSON(`{"config":{"nested":{"a":"inline","b":"source"},"c":"source"}}`))
+			})
+
+			It("replaces duplicate keys with the last wins strategy", func() {
+				configMap.Data["json-parameter"] = `{"config":{"b":"source"}}`
+				Expect(secretsClient.Client.Update(context.TODO(), configMap)).To(Succeed())
+				parametersFrom := []v1.ParametersFromSource{
+					{ConfigMapKeyRef: &v1.ConfigMapKeyReference{Name: "param-configmap", Key: "json-parameter"}, MergeStrategy: v1.MergeStrategyLastWins},
+				}
+				parameters := &runtime.RawExtension{Raw: []byte(`{"config":{"a":"inline"},"other":"inline"}`)}
+				parametersRaw, _, err := BuildSMRequestParameters(context.TODO(), namespace, parameters, parametersFrom)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(parametersRaw).To(MatchJSON(`{"config":{"b":"source"},"other":"inline"}`))
+			})
+
+			It("fails on duplicate parameters across sources", func() {
+				parametersFrom := []v1.ParametersFromSource{
+					{ConfigMapRef: &v1.ConfigMapReference{Name: "param-configmap"}},
+				}
+				parameters := &runtime.RawExtension{Raw: []byte(`{"param2":"other"}`)}
+				_, _, err := BuildSMRequestParameters(context.TODO(), namespace, parameters, parametersFrom)
+				Expect(err).To(MatchError(ContainSubstring("duplicate entry")))
+			})
 		})
 	})
 })
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// PlanSchemaOperation identifies the schema of a service plan that parameters are validated against
+type PlanSchemaOperation string
+
+const (
+	InstanceCreateSchema PlanSchemaOperation = "service_instance.create"
+	InstanceUpdateSchema PlanSchemaOperation = "service_instance.update"
+	BindingCreateSchema  PlanSchemaOperation = "service_binding.create"
+
+	parametersSchemaRoot = "parameters"
+)
+
+// ParametersSchemaError is returned when parameters do not match the schema of the service plan
+type ParametersSchemaError struct {
+	PlanName   string
+	Operation  PlanSchemaOperation
+	Violations []string
+}
+
+func (e *ParametersSchemaError) Error() string {
+	return fmt.Sprintf("parameters do not match the %s schema of plan '%s': %s", e.Operation, e.PlanName, strings.Join(e.Violations, "; "))
+}
+
+// ValidateParametersSchema validates the parameters against the given schema of the plan.
+// Plans without a schema for the operation accept any parameters.
+func ValidateParametersSchema(plan *smClientTypes.ServicePlan, operation PlanSchemaOperation, parameters []byte) error {
+	if plan == nil {
+		return nil
+	}
+	schema, err := getPlanParametersSchema(plan, operation)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	var params interface{} = map[string]interface{}{}
+	if len(parameters) > 0 {
+		if err := json.Unmarshal(parameters, &params); err != nil {
+			return fmt.Errorf("failed to unmarshal parameters: %w", err)
+		}
+	}
+
+	result := validate.NewSchemaValidator(schema, nil, parametersSchemaRoot, strfmt.Default).Validate(params)
+	if result.IsValid() {
+		return nil
+	}
+
+	violations := make([]string, 0, len(result.Errors))
+	for _, validationErr := range result.Errors {
+		violations = append(violations, validationErr.Error())
+	}
+	sort.Strings(violations)
+	return &ParametersSchemaError{PlanName: plan.Name, Operation: operation, Violations: violations}
+}
+
+// getPlanParametersSchema extracts the parameters schema of the operation from the plan schemas.
+// References ($ref) are not followed, the referenced parts of the schema accept any value.
+func getPlanParametersSchema(plan *smClientTypes.ServicePlan, operation PlanSchemaOperation) (*spec.Schema, error) {
+	if len(plan.Schemas) == 0 {
+		return nil, nil
+	}
+
+	schemas := map[string]map[string]struct {
+		Parameters json.RawMessage `json:"parameters"`
+	}{}
+	if err := json.Unmarshal(plan.Schemas, &schemas); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schemas of plan '%s': %w", plan.Name, err)
+	}
+
+	resource, action, _ := strings.Cut(string(operation), ".")
+	raw := schemas[resource][action].Parameters
+	if len(raw) == 0 || string(raw) == "null" || string(raw) == "{}" {
+		return nil, nil
+	}
+
+	schema := &spec.Schema{}
+	if err := json.Unmarshal(raw, schema); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s schema of plan '%s': %w", operation, plan.Name, err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plan schema", func() {
+	var plan *smClientTypes.ServicePlan
+
+	BeforeEach(func() {
+		plan = &smClientTypes.ServicePlan{
+			Name: "plan",
+			Schemas: []byte(`{
+				"service_instance": {
+					"create": {"parameters": {
+						"$schema": "http://json-schema.org/draft-04/schema#",
+						"type": "object",
+						"required": ["xsappname"],
+						"properties": {
+							"xsappname": {"type": "string"},
+							"oauth2-configuration": {"type": "object", "properties": {"token-validity": {"type": "integer"}}}
+						}
+					}},
+					"update": {"parameters": {}}
+				},
+				"service_binding": {
+					"create": {"parameters": {"type": "object", "additionalProperties": false, "properties": {"role": {"type": "string", "enum": ["viewer", "admin"]}}}}
+				}
+			}`),
+		}
+	})
+
+	Describe("ValidateParametersSchema", func() {
+		It("accepts parameters matching the schema", func() {
+			Expect(ValidateParametersSchema(plan, InstanceCreateSchema, []byte(`{"xsappname":"app","oauth2-configuration":{"token-validity":300}}`))).To(Succeed())
+		})
+
+		It("reports violations with their field paths", func() {
+			err := ValidateParametersSchema(plan, InstanceCreateSchema, []byte(`{"oauth2-configuration":{"token-validity":"long"}}`))
+			Expect(err).To(HaveOccurred())
+			schemaErr, ok := err.(*ParametersSchemaError)
+			Expect(ok).To(BeTrue())
+			Expect(schemaErr.Violations).To(HaveLen(2))
+			Expect(err.Error()).To(ContainSubstring("parameters.xsappname"))
+			Expect(err.Error()).To(ContainSubstring("parameters.oauth2-configuration.token-validity"))
+		})
+
+		It("validates missing parameters as an empty object", func() {
+			Expect(ValidateParametersSchema(plan, InstanceCreateSchema, nil)).To(MatchError(ContainSubstring("parameters.xsappname")))
+		})
+
+		It("validates binding parameters against the binding schema", func() {
+			Expect(ValidateParametersSchema(plan, BindingCreateSchema, []byte(`{"role":"viewer"}`))).To(Succeed())
+			Expect(ValidateParametersSchema(plan, BindingCreateSchema, []byte(`{"role":"owner"}`))).To(MatchError(ContainSubstring("parameters.role")))
+			Expect(ValidateParametersSchema(plan, BindingCreateSchema, []byte(`{"other":"value"}`))).To(MatchError(ContainSubstring("other")))
+		})
+
+		It("accepts any parameters when the plan has no schema for the operation", func() {
+			Expect(ValidateParametersSchema(plan, InstanceUpdateSchema, []byte(`{"any":"value"}`))).To(Succeed())
+			Expect(ValidateParametersSchema(&smClientTypes.ServicePlan{Name: "plan"}, InstanceCreateSchema, []byte(`{"any":"value"}`))).To(Succeed())
+			Expect(ValidateParametersSchema(nil, InstanceCreateSchema, []byte(`{"any":"value"}`))).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+)
+
+// PlanNotUpdatableError is returned when the plan of an instance is changed although its current plan is not updatable
+type PlanNotUpdatableError struct {
+	CurrentPlan  string
+	TargetPlan   string
+	OfferingName string
+}
+
+func (e *PlanNotUpdatableError) Error() string {
+	return fmt.Sprintf("plan '%s' of service offering '%s' is not updatable, the instance can't be moved to plan '%s'", e.CurrentPlan, e.OfferingName, e.TargetPlan)
+}
+
+// IsPlanUpdatable reports whether instances of the plan can be moved to another plan of the offering.
+// The plan_updateable value of the plan overrides the value of the offering.
+func IsPlanUpdatable(plan *smClientTypes.ServicePlan, offering *smClientTypes.ServiceOffering) bool {
+	if plan != nil && plan.PlanUpdatable != nil {
+		return *plan.PlanUpdatable
+	}
+	return offering != nil && offering.PlanUpdatable
+}
+
+// AllowedPlanTransitions returns the sorted catalog names of the plans an instance of the current plan can be updated to
+func AllowedPlanTransitions(current *smClientTypes.ServicePlan, offering *smClientTypes.ServiceOffering, plans []smClientTypes.ServicePlan) []string {
+	if current == nil || !IsPlanUpdatable(current, offering) {
+		return nil
+	}
+
+	var allowed []string
+	for _, plan := range plans {
+		if plan.ID == current.ID || plan.ServiceOfferingID != current.ServiceOfferingID {
+			continue
+		}
+		allowed = append(allowed, plan.CatalogName)
+	}
+	sort.Strings(allowed)
+	return allowed
+}
+
+// GetAllowedPlanTransitions returns the catalog names of the plans an instance of the given plan can be updated to
+func GetAllowedPlanTransitions(ctx context.Context, smClient sm.Client, planID string) ([]string, error) {
+	current, offering, err := GetPlanWithOffering(ctx, smClient, planID)
+	if err != nil || current == nil || !IsPlanUpdatable(current, offering) {
+		return nil, err
+	}
+
+	plans, err := smClient.ListPlans(ctx, &sm.Parameters{
+		FieldQuery: []string{fmt.Sprintf("service_offering_id eq '%s'", current.ServiceOfferingID)},
+	})
+	if err != nil || plans == nil {
+		return nil, err
+	}
+	return AllowedPlanTransitions(current, offering, plans.ServicePlans), nil
+}
+
+// ValidatePlanTransition verifies that the instance can be moved from its current plan to the plan requested in its spec.
+// A PlanNotUpdatableError is returned when the change is not allowed, other errors mean the plans couldn't be resolved.
+func ValidatePlanTransition(ctx context.Context, smClient sm.Client, currentPlanID string, instance *v1.ServiceInstance) error {
+	if len(currentPlanID) == 0 {
+		return nil
+	}
+
+	target, _, err := smClient.GetPlan(ctx, instance.Spec.ServicePlanID, instance.Spec.ServiceOfferingName, instance.Spec.ServicePlanName, instance.Spec.DataCenter)
+	if err != nil || target == nil || target.ID == currentPlanID {
+		return err
+	}
+
+	current, offering, err := GetPlanWithOffering(ctx, smClient, currentPlanID)
+	if err != nil || current == nil || current.ServiceOfferingID != target.ServiceOfferingID {
+		// moving between offerings is left for Service Manager to decide
+		return err
+	}
+
+	if !IsPlanUpdatable(current, offering) {
+		offeringName := instance.Spec.ServiceOfferingName
+		if offering != nil {
+			offeringName = offering.CatalogName
+		}
+		return &PlanNotUpdatableError{CurrentPlan: current.CatalogName, TargetPlan: target.CatalogName, OfferingName: offeringName}
+	}
+	return nil
+}
+
+// GetPlanWithOffering returns the plan with the given ID and its offering, the plan is nil when it isn't found
+func GetPlanWithOffering(ctx context.Context, smClient sm.Client, planID string) (*smClientTypes.ServicePlan, *smClientTypes.ServiceOffering, error) {
+	plans, err := smClient.ListPlans(ctx, &sm.Parameters{
+		FieldQuery: []string{fmt.Sprintf("id eq '%s'", planID)},
+	})
+	if err != nil || plans == nil || len(plans.ServicePlans) == 0 {
+		return nil, nil, err
+	}
+	plan := &plans.ServicePlans[0]
+
+	offerings, err := smClient.ListOfferings(ctx, &sm.Parameters{
+		FieldQuery: []string{fmt.Sprintf("id eq '%s'", plan.ServiceOfferingID)},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if offerings == nil || len(offerings.ServiceOfferings) == 0 {
+		return plan, nil, nil
+	}
+	return plan, &offerings.ServiceOfferings[0], nil
+}
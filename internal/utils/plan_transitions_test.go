@@ -0,0 +1,52 @@
+package utils
+
+import (
+	smClientTypes "github.com/SAP/sap-btp-service-operator/client/sm/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plan transitions", func() {
+	var (
+		offering *smClientTypes.ServiceOffering
+		current  *smClientTypes.ServicePlan
+		plans    []smClientTypes.ServicePlan
+	)
+
+	BeforeEach(func() {
+		offering = &smClientTypes.ServiceOffering{ID: "offering-id", PlanUpdatable: true}
+		plans = []smClientTypes.ServicePlan{
+			{ID: "standard-id", CatalogName: "standard", ServiceOfferingID: "offering-id"},
+			{ID: "premium-id", CatalogName: "premium", ServiceOfferingID: "offering-id"},
+			{ID: "basic-id", CatalogName: "basic", ServiceOfferingID: "offering-id"},
+			{ID: "other-id", CatalogName: "other", ServiceOfferingID: "other-offering-id"},
+		}
+		current = &plans[0]
+	})
+
+	Describe("IsPlanUpdatable", func() {
+		It("uses the offering value when the plan does not set it", func() {
+			Expect(IsPlanUpdatable(current, offering)).To(BeTrue())
+			offering.PlanUpdatable = false
+			Expect(IsPlanUpdatable(current, offering)).To(BeFalse())
+			Expect(IsPlanUpdatable(current, nil)).To(BeFalse())
+		})
+
+		It("lets the plan override the offering", func() {
+			updatable := false
+			current.PlanUpdatable = &updatable
+			Expect(IsPlanUpdatable(current, offering)).To(BeFalse())
+		})
+	})
+
+	Describe("AllowedPlanTransitions", func() {
+		It("returns the other plans of the offering", func() {
+			Expect(AllowedPlanTransitions(current, offering, plans)).To(Equal([]string{"basic", "premium"}))
+		})
+
+		It("returns nothing when the plan is not updatable", func() {
+			offering.PlanUpdatable = false
+			Expect(AllowedPlanTransitions(current, offering, plans)).To(BeEmpty())
+		})
+	})
+})
@@ -5,6 +5,10 @@ import (
 	"fmt"
 
 	"github.com/SAP/sap-btp-service-operator/internal/config"
+	"github.com/SAP/sap-btp-service-operator/internal/tracing"
+	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -47,15 +51,41 @@ func InitializeSecretsClient(client, nonCachedClient client.Client, config confi
 }
 
 func GetSecretWithFallback(ctx context.Context, namespacedName types.NamespacedName, secret *v1.Secret) error {
-	return secretsClient.getWithClientFallback(ctx, namespacedName, secret)
+	ctx, span := startResolverSpan(ctx, "GetSecretWithFallback", "secret", namespacedName.Namespace, namespacedName.Name)
+	err := secretsClient.getWithClientFallback(ctx, namespacedName, secret)
+	tracing.End(span, err)
+	return err
+}
+
+// GetConfigMapWithFallback fetches a config map, falling back to the non cached client when the limited cache is enabled
+func GetConfigMapWithFallback(ctx context.Context, namespacedName types.NamespacedName, configMap *v1.ConfigMap) error {
+	ctx, span := startResolverSpan(ctx, "GetConfigMapWithFallback", "configmap", namespacedName.Namespace, namespacedName.Name)
+	err := secretsClient.getWithClientFallback(ctx, namespacedName, configMap)
+	tracing.End(span, err)
+	return err
 }
 
 func GetSecretFromManagementNamespace(ctx context.Context, name string) (*v1.Secret, error) {
-	return secretsClient.getSecretFromManagementNamespace(ctx, name)
+	ctx, span := startResolverSpan(ctx, "GetSecretFromManagementNamespace", "secret", secretsClient.ManagementNamespace, name)
+	secret, err := secretsClient.getSecretFromManagementNamespace(ctx, name)
+	tracing.End(span, err)
+	return secret, err
 }
 
 func GetSecretForResource(ctx context.Context, namespace, name string) (*v1.Secret, error) {
-	return secretsClient.getSecretForResource(ctx, namespace, name)
+	ctx, span := startResolverSpan(ctx, "GetSecretForResource", "secret", namespace, name)
+	secret, err := secretsClient.getSecretForResource(ctx, namespace, name)
+	tracing.End(span, err)
+	return secret, err
+}
+
+// startResolverSpan starts the span of a lookup of the resolver, kind is the semantic convention name of the object kind,
+// e.g. secret or configmap
+func startResolverSpan(ctx context.Context, name, kind, namespace, objectName string) (context.Context, trace.Span) {
+	return tracing.StartSpan(ctx, "secret-resolver "+name,
+		attribute.String("k8s.namespace.name", namespace),
+		attribute.String("k8s."+kind+".name", objectName),
+		tracing.CorrelationIDKey.String(logutils.GetCorrelationID(ctx)))
 }
 
 func (sr *secretClient) getSecretFromManagementNamespace(ctx context.Context, name string) (*v1.Secret, error) {
@@ -108,11 +138,11 @@ func (sr *secretClient) getClusterDefaultSecret(ctx context.Context, name string
 	return secretForResource, nil
 }
 
-func (sr *secretClient) getWithClientFallback(ctx context.Context, key types.NamespacedName, secretForResource *v1.Secret) error {
-	err := sr.Client.Get(ctx, key, secretForResource)
+func (sr *secretClient) getWithClientFallback(ctx context.Context, key types.NamespacedName, object client.Object) error {
+	err := sr.Client.Get(ctx, key, object)
 	if err != nil {
 		if errors.IsNotFound(err) && sr.LimitedCacheEnabled {
-			err = sr.NonCachedClient.Get(ctx, key, secretForResource)
+			err = sr.NonCachedClient.Get(ctx, key, object)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	evictionReasonSecretChanged = "secret_changed"
+	evictionReasonIdle          = "idle"
+)
+
+// SMClientKey identifies the secrets an SM client is built from, TLS is empty when the credentials secret holds the
+// client certificate or a client secret
+type SMClientKey struct {
+	Credentials types.NamespacedName
+	TLS         types.NamespacedName
+}
+
+type pooledSMClient struct {
+	client   sm.Client
+	version  string
+	lastUsed time.Time
+}
+
+// SMClientPool caches authenticated SM clients per credentials and TLS secret so that the
+// oauth2 token source and http transport are reused across reconciles.
+// An entry is replaced once the secret versions change and dropped once it was not used for idleTimeout.
+type SMClientPool struct {
+	mu          sync.Mutex
+	clients     map[SMClientKey]*pooledSMClient
+	idleTimeout time.Duration
+	now         func() time.Time
+}
+
+func NewSMClientPool(idleTimeout time.Duration) *SMClientPool {
+	return &SMClientPool{
+		clients:     make(map[SMClientKey]*pooledSMClient),
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+	}
+}
+
+// Get returns the cached client for the given secrets if it was built from the same version,
+// otherwise it builds a new one using create and caches it.
+func (p *SMClientPool) Get(key SMClientKey, version string, create func() (sm.Client, error)) (sm.Client, error) {
+	if p.idleTimeout <= 0 {
+		metrics.SMClientPoolMisses.Inc()
+		return create()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.evictIdle(now)
+
+	if entry, ok := p.clients[key]; ok {
+		if entry.version == version {
+			entry.lastUsed = now
+			metrics.SMClientPoolHits.Inc()
+			return entry.client, nil
+		}
+		p.evict(key, evictionReasonSecretChanged)
+	}
+
+	metrics.SMClientPoolMisses.Inc()
+	client, err := create()
+	if err != nil {
+		return nil, err
+	}
+	p.clients[key] = &pooledSMClient{client: client, version: version, lastUsed: now}
+	metrics.SMClientPoolSize.Set(float64(len(p.clients)))
+	return client, nil
+}
+
+func (p *SMClientPool) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(p)
+}
+
+// NeedLeaderElection makes every replica sweep its own pool
+func (p *SMClientPool) NeedLeaderElection() bool {
+	return false
+}
+
+// Start sweeps the idle clients every idle timeout until the context is done, clients of deleted secrets are not
+// requested anymore and would otherwise be kept until the next Get
+func (p *SMClientPool) Start(ctx context.Context) error {
+	if p.idleTimeout <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(p.idleTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			p.Sweep()
+		}
+	}
+}
+
+// Sweep evicts the clients that were idle for longer than the idle timeout
+func (p *SMClientPool) Sweep() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.evictIdle(p.now())
+}
+
+func (p *SMClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.clients)
+}
+
+func (p *SMClientPool) evictIdle(now time.Time) {
+	for key, entry := range p.clients {
+		if now.Sub(entry.lastUsed) > p.idleTimeout {
+			p.evict(key, evictionReasonIdle)
+		}
+	}
+}
+
+func (p *SMClientPool) evict(key SMClientKey, reason string) {
+	if closer, ok := p.clients[key].client.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+	delete(p.clients, key)
+	metrics.SMClientPoolEvictions.WithLabelValues(reason).Inc()
+	metrics.SMClientPoolSize.Set(float64(len(p.clients)))
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/client/sm/smfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("SMClientPool", func() {
+	var (
+		pool    *SMClientPool
+		key     SMClientKey
+		now     time.Time
+		created int
+		create  func() (sm.Client, error)
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		pool = NewSMClientPool(10 * time.Minute)
+		pool.now = func() time.Time { return now }
+		key = SMClientKey{Credentials: types.NamespacedName{Namespace: "management", Name: "sap-btp-service-operator"}}
+		created = 0
+		create = func() (sm.Client, error) {
+			created++
+			return &smfakes.FakeClient{}, nil
+		}
+	})
+
+	It("reuses the client for the same secret version", func() {
+		first, err := pool.Get(key, "1", create)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := pool.Get(key, "1", create)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+		Expect(created).To(Equal(1))
+	})
+
+	It("replaces the client when the secret version changes", func() {
+		first, err := pool.Get(key, "1", create)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := pool.Get(key, "2", create)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second).ToNot(BeIdenticalTo(first))
+		Expect(created).To(Equal(2))
+		Expect(pool.Len()).To(Equal(1))
+	})
+
+	It("keeps separate clients per secret", func() {
+		_, err := pool.Get(key, "1", create)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = pool.Get(SMClientKey{Credentials: types.NamespacedName{Namespace: "ns", Name: "sap-btp-service-operator"}}, "1", create)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(Equal(2))
+		Expect(pool.Len()).To(Equal(2))
+	})
+
+	It("keeps separate clients per TLS secret of shared credentials", func() {
+		first := SMClientKey{Credentials: key.Credentials, TLS: types.NamespacedName{Namespace: "first", Name: "sap-btp-service-operator-tls"}}
+		second := SMClientKey{Credentials: key.Credentials, TLS: types.NamespacedName{Namespace: "second", Name: "sap-btp-service-operator-tls"}}
+		for i := 0; i < 2; i++ {
+			_, err := pool.Get(first, "1/1", create)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = pool.Get(second, "1/1", create)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(created).To(Equal(2))
+		Expect(pool.Len()).To(Equal(2))
+	})
+
+	It("evicts clients that were idle for longer than the idle timeout", func() {
+		_, err := pool.Get(key, "1", create)
+		Expect(err).ToNot(HaveOccurred())
+		now = now.Add(11 * time.Minute)
+		_, err = pool.Get(SMClientKey{Credentials: types.NamespacedName{Namespace: "ns", Name: "other"}}, "1", create)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pool.Len()).To(Equal(1))
+
+		_, err = pool.Get(key, "1", create)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(Equal(3))
+	})
+
+	It("sweeps idle clients without a new request", func() {
+		_, err := pool.Get(key, "1", create)
+		Expect(err).ToNot(HaveOccurred())
+		pool.Sweep()
+		Expect(pool.Len()).To(Equal(1))
+
+		now = now.Add(11 * time.Minute)
+		pool.Sweep()
+		Expect(pool.Len()).To(Equal(0))
+		Expect(pool.NeedLeaderElection()).To(BeFalse())
+	})
+
+	It("does not cache failures", func() {
+		_, err := pool.Get(key, "1", func() (sm.Client, error) { return nil, fmt.Errorf("boom") })
+		Expect(err).To(HaveOccurred())
+		Expect(pool.Len()).To(Equal(0))
+
+		_, err = pool.Get(key, "1", create)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(Equal(1))
+	})
+
+	When("idle timeout is not positive", func() {
+		BeforeEach(func() {
+			pool = NewSMClientPool(0)
+		})
+
+		It("does not reuse clients", func() {
+			_, err := pool.Get(key, "1", create)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = pool.Get(key, "1", create)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(Equal(2))
+			Expect(pool.Len()).To(Equal(0))
+		})
+	})
+})
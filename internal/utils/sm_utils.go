@@ -3,14 +3,32 @@ package utils
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
 	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/internal/config"
 	"github.com/SAP/sap-btp-service-operator/internal/utils/logutils"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+var (
+	smClientPool     *SMClientPool
+	smClientPoolOnce sync.Once
+
+	catalogCache     *sm.CatalogCache
+	catalogCacheOnce sync.Once
+
+	rateLimiter     *sm.RateLimiter
+	rateLimiterOnce sync.Once
+
+	circuitBreaker     *sm.CircuitBreaker
+	circuitBreakerOnce sync.Once
+)
+
 type InvalidCredentialsError struct{}
 
 func (ic *InvalidCredentialsError) Error() string {
@@ -46,8 +64,22 @@ func GetSMClient(ctx context.Context, serviceInstance *v1.ServiceInstance) (sm.C
 		TLSPrivateKey:  string(secret.Data[corev1.TLSPrivateKeyKey]),
 		TLSCertKey:     string(secret.Data[corev1.TLSCertKey]),
 		SSLDisabled:    false,
+
+		RequestTimeout:    config.Get().SMRequestTimeout,
+		OperationTimeouts: config.Get().SMOperationTimeouts,
+		CatalogCache:      GetCatalogCache(),
+		RateLimiter:       getRateLimiter(),
+		RetryPolicy: sm.RetryPolicy{
+			MaxRetries: config.Get().SMRequestRetries,
+			BaseDelay:  config.Get().SMRetryBaseDelay,
+			MaxDelay:   config.Get().SMRetryMaxDelay,
+		},
+		CircuitBreaker: getCircuitBreaker(),
 	}
 
+	key := SMClientKey{Credentials: client.ObjectKeyFromObject(secret)}
+	version := secret.ResourceVersion
+
 	if len(clientConfig.ClientID) == 0 || len(clientConfig.URL) == 0 || len(clientConfig.TokenURL) == 0 {
 		log.Info("credentials secret found but did not contain all the required data")
 		return nil, fmt.Errorf("invalid Service-Manager credentials, contact your cluster administrator")
@@ -73,7 +105,89 @@ func GetSMClient(ctx context.Context, serviceInstance *v1.ServiceInstance) (sm.C
 		log.Info("found tls configuration")
 		clientConfig.TLSCertKey = string(tlsSecret.Data[corev1.TLSCertKey])
 		clientConfig.TLSPrivateKey = string(tlsSecret.Data[corev1.TLSPrivateKeyKey])
+		key.TLS = client.ObjectKeyFromObject(tlsSecret)
+		version = fmt.Sprintf("%s/%s", version, tlsSecret.ResourceVersion)
+	}
+
+	return getSMClientPool().Get(key, version, func() (sm.Client, error) {
+		// the client outlives the reconcile, so the token source must not be bound to its cancellation
+		return sm.NewClient(context.WithoutCancel(ctx), clientConfig, nil)
+	})
+}
+
+// SMCredentials is a client for a set of SM credentials in use in the cluster
+type SMCredentials struct {
+	Client sm.Client
+	// BTPAccessCredentialsSecret is the secret of the credentials, empty for namespace and cluster credentials
+	BTPAccessCredentialsSecret string
+}
+
+// GetCredentialsInUse returns a client for the credentials of every instance in the cluster and for the cluster default
+// credentials, instances with the same credentials are reported once. The errors of credentials that can't be used are returned.
+func GetCredentialsInUse(ctx context.Context, reader client.Reader, getSMClient func(ctx context.Context, instance *v1.ServiceInstance) (sm.Client, error), releaseNamespace string) ([]SMCredentials, []error) {
+	instances := &v1.ServiceInstanceList{}
+	if err := reader.List(ctx, instances); err != nil {
+		return nil, []error{err}
+	}
+	instances.Items = append(instances.Items, v1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Namespace: releaseNamespace}})
+
+	var credentials []SMCredentials
+	var errs []error
+	found := make(map[interface{}]bool)
+	for i := range instances.Items {
+		instance := &instances.Items[i]
+		smClient, err := getSMClient(ctx, instance)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("credentials of instance %s/%s: %w", instance.Namespace, instance.Name, err))
+			continue
+		}
+		if key := credentialsKey(smClient); !found[key] {
+			found[key] = true
+			credentials = append(credentials, SMCredentials{Client: smClient, BTPAccessCredentialsSecret: instance.Spec.BTPAccessCredentialsSecret})
+		}
+	}
+	return credentials, errs
+}
+
+// SetupSMClientPoolWithManager sweeps the idle clients of the SM client pool while the manager runs
+func SetupSMClientPoolWithManager(mgr ctrl.Manager) error {
+	return getSMClientPool().SetupWithManager(mgr)
+}
+
+// credentialsKey identifies the credentials of a client, clients that were built separately for the same credentials,
+// e.g. when the client pool is disabled, have the same key
+func credentialsKey(smClient sm.Client) interface{} {
+	if scoped, ok := smClient.(interface{ Scope() string }); ok {
+		return scoped.Scope()
 	}
+	return smClient
+}
+
+func getSMClientPool() *SMClientPool {
+	smClientPoolOnce.Do(func() {
+		smClientPool = NewSMClientPool(config.Get().SMClientIdleTimeout)
+	})
+	return smClientPool
+}
+
+// GetCatalogCache returns the catalog cache shared by all SM clients
+func GetCatalogCache() *sm.CatalogCache {
+	catalogCacheOnce.Do(func() {
+		catalogCache = sm.NewCatalogCache(config.Get().SMCatalogCacheTTL)
+	})
+	return catalogCache
+}
+
+func getRateLimiter() *sm.RateLimiter {
+	rateLimiterOnce.Do(func() {
+		rateLimiter = sm.NewRateLimiter(config.Get().SMRateLimit, config.Get().SMRateLimitBurst)
+	})
+	return rateLimiter
+}
 
-	return sm.NewClient(ctx, clientConfig, nil)
+func getCircuitBreaker() *sm.CircuitBreaker {
+	circuitBreakerOnce.Do(func() {
+		circuitBreaker = sm.NewCircuitBreaker(config.Get().SMBreakerThreshold, config.Get().SMBreakerCooldown)
+	})
+	return circuitBreaker
 }
@@ -1,13 +1,19 @@
 package utils
 
 import (
+	"context"
+
 	v1 "github.com/SAP/sap-btp-service-operator/api/v1"
+	"github.com/SAP/sap-btp-service-operator/client/sm"
+	"github.com/SAP/sap-btp-service-operator/client/sm/smfakes"
 	"github.com/SAP/sap-btp-service-operator/internal/config"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var _ = Describe("SM Utils", func() {
@@ -443,3 +449,34 @@ LNk67JSJiTqe6bDYB6FcDUWw7j2EnhegjcxE205T1vc4BqNEJ4Ilruz7T0w+T5N9
 VsxDsSwDp033fe6+XBSPEf879UZgcrq7eSqCfk+NGf2rcjcsdD8z8wd3IkqPCtKW
 ICwycby2nLYd40HJv2+G3mdR
 -----END PRIVATE KEY-----`
+
+type scopedFakeClient struct {
+	smfakes.FakeClient
+	scope string
+}
+
+func (c *scopedFakeClient) Scope() string {
+	return c.scope
+}
+
+var _ = Describe("GetCredentialsInUse", func() {
+	It("reports credentials used by clients that were built separately once", func() {
+		scheme := runtime.NewScheme()
+		Expect(v1.AddToScheme(scheme)).To(Succeed())
+		reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "namespace"}},
+			&v1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "namespace"}},
+			&v1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other-namespace"}},
+		).Build()
+		getSMClient := func(_ context.Context, instance *v1.ServiceInstance) (sm.Client, error) {
+			if instance.Namespace == "other-namespace" {
+				return &scopedFakeClient{scope: "https://sm.url|other-client-id"}, nil
+			}
+			return &scopedFakeClient{scope: "https://sm.url|client-id"}, nil
+		}
+
+		credentials, errs := GetCredentialsInUse(context.Background(), reader, getSMClient, "namespace")
+		Expect(errs).To(BeEmpty())
+		Expect(credentials).To(HaveLen(2))
+	})
+})
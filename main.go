@@ -44,6 +44,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	"github.com/SAP/sap-btp-service-operator/internal/config"
+	"github.com/SAP/sap-btp-service-operator/internal/metrics"
+	"github.com/SAP/sap-btp-service-operator/internal/tracing"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -89,6 +91,20 @@ func main() {
 
 	sm.AppVersion = os.Getenv("APP_VERSION")
 	setupLog.Info("starting btp-service-operator", "version", sm.AppVersion)
+	if err := config.Get().Validate(); err != nil {
+		setupLog.Error(err, "invalid configuration")
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Endpoint:    config.Get().TracingEndpoint,
+		Insecure:    config.Get().TracingInsecure,
+		SampleRatio: config.Get().TracingSampleRatio,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
 
 	mgrOptions := ctrl.Options{
 		Scheme: scheme,
@@ -145,11 +161,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	migrateClusterID := false
 	if len(config.Get().InitialClusterID) == 0 {
 		setupLog.Info("cluster secret not found, creating it")
 		createClusterSecret(mgr.GetClient())
 	} else if config.Get().InitialClusterID != config.Get().ClusterID {
-		panic(fmt.Sprintf("ClusterID changed, which is not supported. Please redeploy with --set cluster.id=%s", config.Get().InitialClusterID))
+		if !config.Get().ClusterIDMigration {
+			panic(fmt.Sprintf("ClusterID changed, which is not supported. Please redeploy with --set cluster.id=%s, or with --set cluster.migrate=true to migrate the resources to the new ID", config.Get().InitialClusterID))
+		}
+		migrateClusterID = true
 	}
 
 	var nonCachedClient client.Client
@@ -164,38 +184,23 @@ func main() {
 
 	utils.InitializeSecretsClient(mgr.GetClient(), nonCachedClient, config.Get())
 
-	if err = (&controllers.ServiceInstanceReconciler{
-		Client:      mgr.GetClient(),
-		Log:         ctrl.Log.WithName("controllers").WithName("ServiceInstance"),
-		Scheme:      mgr.GetScheme(),
-		Config:      config.Get(),
-		Recorder:    mgr.GetEventRecorder("ServiceInstance"),
-		GetSMClient: utils.GetSMClient,
-		Retries:     utils.NewRetryStore(config.Get().RetryBaseDelay, config.Get().RetryMaxDelay),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ServiceInstance")
+	if err = metrics.RegisterResourceCollector(mgr.GetClient()); err != nil {
+		setupLog.Error(err, "unable to register resource metrics")
 		os.Exit(1)
 	}
-	if err = (&controllers.ServiceBindingReconciler{
-		Client:      mgr.GetClient(),
-		Log:         ctrl.Log.WithName("controllers").WithName("ServiceBinding"),
-		Scheme:      mgr.GetScheme(),
-		Config:      config.Get(),
-		Recorder:    mgr.GetEventRecorder("ServiceBinding"),
-		GetSMClient: utils.GetSMClient,
-		Retries:     utils.NewRetryStore(config.Get().RetryBaseDelay, config.Get().RetryMaxDelay),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ServiceBinding")
+
+	if err = utils.SetupSMClientPoolWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up the SM client pool")
 		os.Exit(1)
 	}
-	if err = (&controllers.SecretReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("Secret"),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Secret")
-		os.Exit(1)
+
+	if migrateClusterID {
+		// the controllers are set up by the leader once the migration completed
+		setupClusterIDMigration(k8sConfig, mgr)
+	} else {
+		setupControllers(mgr)
 	}
+
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		mgr.GetWebhookServer().Register("/mutate-services-cloud-sap-com-v1-serviceinstance", &webhook.Admission{Handler: &webhooks.ServiceInstanceDefaulter{Decoder: admission.NewDecoder(mgr.GetScheme())}})
 		mgr.GetWebhookServer().Register("/mutate-services-cloud-sap-com-v1-servicebinding", &webhook.Admission{Handler: &webhooks.ServiceBindingDefaulter{Decoder: admission.NewDecoder(mgr.GetScheme())}})
@@ -207,6 +212,14 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ServiceInstance")
 			os.Exit(1)
 		}
+		servicesv1.SetPlanChangeValidator(&webhooks.PlanChangeValidator{GetSMClient: utils.GetSMClient})
+		if config.Get().ParamsSchemaValidation != config.SchemaValidationDisabled {
+			servicesv1.SetParametersValidator(&webhooks.ParametersSchemaValidator{
+				Client:      mgr.GetClient(),
+				GetSMClient: utils.GetSMClient,
+				Enforce:     config.Get().ParamsSchemaValidation == config.SchemaValidationEnforce,
+			})
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
@@ -220,8 +233,12 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-		setupLog.Error(err, "problem running manager")
+	startErr := mgr.Start(ctrl.SetupSignalHandler())
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "failed to flush traces")
+	}
+	if startErr != nil {
+		setupLog.Error(startErr, "problem running manager")
 		os.Exit(1)
 	}
 
@@ -229,16 +246,109 @@ func main() {
 
 func createClusterSecret(client client.Client) {
 	clusterSecret := &v1.Secret{}
-	clusterSecret.Name = "sap-btp-operator-clusterid"
+	clusterSecret.Name = utils.ClusterIDSecretName
 	clusterSecret.Namespace = config.Get().ReleaseNamespace
 	clusterSecret.Labels = map[string]string{common.ManagedByBTPOperatorLabel: "true", common.ClusterSecretLabel: "true"}
-	clusterSecret.StringData = map[string]string{"INITIAL_CLUSTER_ID": config.Get().ClusterID}
+	clusterSecret.StringData = map[string]string{utils.InitialClusterIDKey: config.Get().ClusterID}
 	clusterSecret.Labels = map[string]string{common.ManagedByBTPOperatorLabel: "true"}
 	if err := client.Create(context.Background(), clusterSecret); err != nil {
 		setupLog.Error(err, "failed to create cluster secret")
 	}
 }
 
+// setupControllers sets up the controllers and the runnables that act on the SM resources of the cluster
+func setupControllers(mgr ctrl.Manager) {
+	var err error
+	if err = (&controllers.ServiceInstanceReconciler{
+		Client:      mgr.GetClient(),
+		Log:         ctrl.Log.WithName("controllers").WithName("ServiceInstance"),
+		Scheme:      mgr.GetScheme(),
+		Config:      config.Get(),
+		Recorder:    mgr.GetEventRecorder("ServiceInstance"),
+		GetSMClient: utils.GetSMClient,
+		Retries:     utils.NewRetryStore(config.Get().RetryBaseDelay, config.Get().RetryMaxDelay),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ServiceInstance")
+		os.Exit(1)
+	}
+	if err = (&controllers.ServiceBindingReconciler{
+		Client:      mgr.GetClient(),
+		Log:         ctrl.Log.WithName("controllers").WithName("ServiceBinding"),
+		Scheme:      mgr.GetScheme(),
+		Config:      config.Get(),
+		Recorder:    mgr.GetEventRecorder("ServiceBinding"),
+		GetSMClient: utils.GetSMClient,
+		Retries:     utils.NewRetryStore(config.Get().RetryBaseDelay, config.Get().RetryMaxDelay),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ServiceBinding")
+		os.Exit(1)
+	}
+	if err = (&controllers.SecretReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("Secret"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Secret")
+		os.Exit(1)
+	}
+	if err = (&controllers.ConfigMapReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("ConfigMap"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ConfigMap")
+		os.Exit(1)
+	}
+	if err = (&controllers.VCAPServicesReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("VCAPServices"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("VCAPServices"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VCAPServices")
+		os.Exit(1)
+	}
+	if config.Get().OrphanCheckInterval > 0 {
+		if err = (&utils.OrphanCollector{
+			Client:      mgr.GetClient(),
+			GetSMClient: utils.GetSMClient,
+			Config:      config.Get(),
+			Log:         ctrl.Log.WithName("orphans"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to set up the orphan collector")
+			os.Exit(1)
+		}
+	}
+}
+
+// setupClusterIDMigration moves the SM resources of the initial cluster ID to the configured one before the controllers
+// are set up. The migration runs on the leader only and reads through a non cached client, the cluster ID secret may not
+// be in the limited cache.
+func setupClusterIDMigration(k8sConfig *rest.Config, mgr ctrl.Manager) {
+	migrationClient, err := client.New(k8sConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create cluster ID migration client")
+		os.Exit(1)
+	}
+
+	migration := &utils.ClusterIDMigration{
+		Client:           migrationClient,
+		GetSMClient:      utils.GetSMClient,
+		ReleaseNamespace: config.Get().ReleaseNamespace,
+		FromID:           config.Get().InitialClusterID,
+		ToID:             config.Get().ClusterID,
+		Log:              ctrl.Log.WithName("cluster-id-migration"),
+		OnComplete: func() error {
+			setupControllers(mgr)
+			return nil
+		},
+	}
+	if err := migration.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up the cluster ID migration")
+		os.Exit(1)
+	}
+}
+
 // getFipsCompliantConfig creates a FIPS-compliant Kubernetes rest.Config
 func getFipsCompliantConfig() *rest.Config {
 	fipsCompliantConfig := ctrl.GetConfigOrDie()